@@ -3,21 +3,29 @@ module github.com/seongpil0948/otel-kafka-pg/cmd/app
 go 1.24.2
 
 require (
+	github.com/seongpil0948/otel-kafka-pg/modules/alert v0.0.0-00010101000000-000000000000
 	github.com/seongpil0948/otel-kafka-pg/modules/api v0.0.0-20250505092541-8ec1922b0f76
 	github.com/seongpil0948/otel-kafka-pg/modules/cleanup v0.0.0-20250505092541-8ec1922b0f76
 	github.com/seongpil0948/otel-kafka-pg/modules/common v0.0.0
+	github.com/seongpil0948/otel-kafka-pg/modules/common/telemetry v0.0.0-00010101000000-000000000000
 	github.com/seongpil0948/otel-kafka-pg/modules/kafka/consumer v0.0.0-20250505092541-8ec1922b0f76
+	github.com/seongpil0948/otel-kafka-pg/modules/kafka/policy v0.0.0-00010101000000-000000000000
 	github.com/seongpil0948/otel-kafka-pg/modules/kafka/processor v0.0.0-20250505092541-8ec1922b0f76
 	github.com/seongpil0948/otel-kafka-pg/modules/log v0.0.0
 	github.com/seongpil0948/otel-kafka-pg/modules/trace v0.0.0
+	go.opentelemetry.io/proto/otlp v1.7.0
+	google.golang.org/protobuf v1.36.6
 )
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/PuerkitoBio/purell v1.1.1 // indirect
 	github.com/PuerkitoBio/urlesc v0.0.0-20170810143723-de5bf2ad4578 // indirect
+	github.com/XSAM/otelsql v0.39.0 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/bytedance/sonic v1.13.2 // indirect
 	github.com/bytedance/sonic/loader v0.2.4 // indirect
+	github.com/cenkalti/backoff/v5 v5.0.2 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/cloudwego/base64x v0.1.5 // indirect
 	github.com/confluentinc/confluent-kafka-go/v2 v2.3.0 // indirect
@@ -27,6 +35,8 @@ require (
 	github.com/gin-contrib/cors v1.7.5 // indirect
 	github.com/gin-contrib/sse v1.0.0 // indirect
 	github.com/gin-gonic/gin v1.10.0 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/go-openapi/jsonpointer v0.19.5 // indirect
 	github.com/go-openapi/jsonreference v0.19.6 // indirect
 	github.com/go-openapi/spec v0.20.4 // indirect
@@ -38,7 +48,7 @@ require (
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
 	github.com/google/uuid v1.6.0 // indirect
-	github.com/grpc-ecosystem/grpc-gateway/v2 v2.25.1 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.1 // indirect
 	github.com/hashicorp/hcl v1.0.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
@@ -52,13 +62,19 @@ require (
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.3 // indirect
+	github.com/prometheus/client_golang v1.22.0 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.65.0 // indirect
+	github.com/prometheus/procfs v0.16.1 // indirect
 	github.com/redis/go-redis/v9 v9.8.0 // indirect
 	github.com/rs/zerolog v1.32.0 // indirect
 	github.com/sagikazarmark/locafero v0.4.0 // indirect
 	github.com/sagikazarmark/slog-shim v0.1.0 // indirect
 	github.com/seongpil0948/otel-kafka-pg/docs v0.0.0-20250505092541-8ec1922b0f76 // indirect
 	github.com/seongpil0948/otel-kafka-pg/modules/api/middleware v0.0.0-20250505092541-8ec1922b0f76 // indirect
+	github.com/seongpil0948/otel-kafka-pg/modules/auth v0.0.0-00010101000000-000000000000 // indirect
 	github.com/seongpil0948/otel-kafka-pg/modules/common/cache v0.0.0-20250505092541-8ec1922b0f76 // indirect
 	github.com/seongpil0948/otel-kafka-pg/modules/common/redis v0.0.0-20250505092541-8ec1922b0f76 // indirect
 	github.com/sourcegraph/conc v0.3.0 // indirect
@@ -72,28 +88,40 @@ require (
 	github.com/swaggo/swag v1.16.4 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/ugorji/go/codec v1.2.12 // indirect
-	go.opentelemetry.io/proto/otlp v1.5.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel v1.37.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp v1.37.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.37.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.37.0 // indirect
+	go.opentelemetry.io/otel/exporters/prometheus v0.59.0 // indirect
+	go.opentelemetry.io/otel/metric v1.37.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.37.0 // indirect
+	go.opentelemetry.io/otel/sdk/metric v1.37.0 // indirect
+	go.opentelemetry.io/otel/trace v1.37.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/arch v0.15.0 // indirect
-	golang.org/x/crypto v0.36.0 // indirect
+	golang.org/x/crypto v0.39.0 // indirect
 	golang.org/x/exp v0.0.0-20230905200255-921286631fa9 // indirect
-	golang.org/x/net v0.38.0 // indirect
-	golang.org/x/sys v0.31.0 // indirect
-	golang.org/x/text v0.23.0 // indirect
-	golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d // indirect
-	google.golang.org/genproto/googleapis/api v0.0.0-20250102185135-69823020774d // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20250102185135-69823020774d // indirect
-	google.golang.org/grpc v1.69.2 // indirect
-	google.golang.org/protobuf v1.36.6 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	golang.org/x/tools v0.33.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/grpc v1.73.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace (
+	github.com/seongpil0948/otel-kafka-pg/modules/alert => ../../modules/alert
+	github.com/seongpil0948/otel-kafka-pg/modules/auth => ../../modules/auth
 	github.com/seongpil0948/otel-kafka-pg/modules/common => ../../modules/common
+	github.com/seongpil0948/otel-kafka-pg/modules/common/telemetry => ../../modules/common/telemetry
 	github.com/seongpil0948/otel-kafka-pg/modules/kafka => ../../modules/kafka
+	github.com/seongpil0948/otel-kafka-pg/modules/kafka/policy => ../../modules/kafka/policy
 	github.com/seongpil0948/otel-kafka-pg/modules/log => ../../modules/log
 	github.com/seongpil0948/otel-kafka-pg/modules/trace => ../../modules/trace
 )
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	alertNotify "github.com/seongpil0948/otel-kafka-pg/modules/alert/notify"
+	alertRepository "github.com/seongpil0948/otel-kafka-pg/modules/alert/repository"
+	alertService "github.com/seongpil0948/otel-kafka-pg/modules/alert/service"
+	"github.com/seongpil0948/otel-kafka-pg/modules/cleanup"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	commonDB "github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
+	traceRepository "github.com/seongpil0948/otel-kafka-pg/modules/trace/repository"
+	traceService "github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
+)
+
+// 백그라운드 작업 리더 잠금 이름 (같은 데이터베이스를 쓰는 모든 인스턴스가 공유)
+const jobsLockName = "otel-kafka-pg/background-jobs"
+
+// backgroundJobs는 데이터 정리, 트레이스 집계, 알림 평가, 알림 전송 작업입니다.
+// 여러 인스턴스가 같은 작업을 중복 실행하지 않도록 리더 잠금을 얻은 인스턴스 하나만 실행합니다.
+// 리더 자격을 잃으면 작업을 중지하고 다시 리더 선출에 참여합니다.
+// 잠금 연결이 끊긴 것을 알아차리기까지 최대 LeaderRetryInterval 동안 다른 인스턴스와 작업이 겹칠 수 있습니다 (commonDB.LeaderLock 참고).
+type backgroundJobs struct {
+	cleanupSvc      cleanup.CleanupService
+	rollupSvc       traceService.RollupService
+	alertEvaluator  alertService.Evaluator
+	alertDispatcher alertNotify.Dispatcher
+	leader          commonDB.LeaderLock
+	log             logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newBackgroundJobs는 백그라운드 작업과 리더 잠금을 생성합니다. 작업은 start에서 리더로 선출된 뒤 시작합니다.
+func newBackgroundJobs(database commonDB.Database, traceRepo traceRepository.TraceRepository, traceSvc traceService.TraceService, logSvc logService.LogService, cfg *config.Config, log logger.Logger) *backgroundJobs {
+	alertRepo := alertRepository.NewAlertRepository(database)
+	alertDispatcher := alertNotify.NewDispatcher(alertRepo, cfg)
+
+	return &backgroundJobs{
+		cleanupSvc:      cleanup.NewCleanupService(database, cfg),
+		rollupSvc:       traceService.NewRollupService(traceRepo, cfg),
+		alertEvaluator:  alertService.NewEvaluator(alertRepo, traceSvc, logSvc, alertDispatcher, cfg),
+		alertDispatcher: alertDispatcher,
+		leader:          commonDB.NewLeaderLock(database, jobsLockName, time.Duration(cfg.Runtime.LeaderRetryInterval)*time.Second),
+		log:             log,
+		done:            make(chan struct{}),
+	}
+}
+
+// start는 리더 선출과 작업 실행을 반복하는 루프를 시작합니다. 잠금을 기다리는 동안 호출한 쪽을 막지 않습니다.
+func (j *backgroundJobs) start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(j.done)
+
+		for {
+			j.log.Info().Msg("백그라운드 작업 리더 선출 대기 중...")
+			if err := j.leader.Acquire(ctx); err != nil {
+				return
+			}
+			j.log.Info().Msg("리더로 선출되어 백그라운드 작업을 시작합니다")
+
+			// 리더 자격을 잃으면 진행 중인 쿼리도 취소되도록 리더 기간마다 컨텍스트를 따로 둠
+			termCtx, cancelTerm := context.WithCancel(ctx)
+			j.startJobs(termCtx)
+
+			select {
+			case <-j.leader.Lost():
+				j.log.Error().Msg("백그라운드 작업 리더 자격을 잃어 작업을 중지하고 리더 선출에 다시 참여합니다")
+				cancelTerm()
+				j.stopJobs()
+			case <-ctx.Done():
+				cancelTerm()
+				j.stopJobs()
+				return
+			}
+		}
+	}()
+}
+
+// startJobs는 리더로 선출된 뒤 작업을 시작합니다.
+func (j *backgroundJobs) startJobs(ctx context.Context) {
+	// 데이터 정리 서비스 시작
+	if err := j.cleanupSvc.Start(ctx); err != nil {
+		j.log.Error().Err(err).Msg("데이터 정리 서비스 시작 실패")
+	}
+
+	// 트레이스 집계 작업 시작 (서비스 의존성 그래프 등)
+	if err := j.rollupSvc.Start(ctx); err != nil {
+		j.log.Error().Err(err).Msg("트레이스 집계 작업 시작 실패")
+	}
+
+	// 알림 전송 및 알림 규칙 평가 작업 시작
+	if err := j.alertDispatcher.Start(ctx); err != nil {
+		j.log.Error().Err(err).Msg("알림 전송 작업 시작 실패")
+	}
+	// 복제 지연으로 최근 데이터를 놓치지 않도록 알림 규칙은 주 데이터베이스에서 평가
+	if err := j.alertEvaluator.Start(commonDB.WithPrimary(ctx)); err != nil {
+		j.log.Error().Err(err).Msg("알림 규칙 평가 작업 시작 실패")
+	}
+}
+
+// stopJobs는 시작한 작업을 중지한 뒤 다른 인스턴스가 이어받도록 리더 잠금을 풉니다.
+func (j *backgroundJobs) stopJobs() {
+	// 데이터 정리 서비스 종료
+	j.log.Info().Msg("데이터 정리 서비스 종료 중...")
+	if err := j.cleanupSvc.Stop(); err != nil {
+		j.log.Error().Err(err).Msg("데이터 정리 서비스 종료 실패")
+	} else {
+		j.log.Info().Msg("데이터 정리 서비스가 정상적으로 종료되었습니다")
+	}
+
+	// 트레이스 집계 작업 종료
+	j.log.Info().Msg("트레이스 집계 작업 종료 중...")
+	if err := j.rollupSvc.Stop(); err != nil {
+		j.log.Error().Err(err).Msg("트레이스 집계 작업 종료 실패")
+	} else {
+		j.log.Info().Msg("트레이스 집계 작업이 정상적으로 종료되었습니다")
+	}
+
+	// 알림 규칙 평가 작업 종료
+	j.log.Info().Msg("알림 규칙 평가 작업 종료 중...")
+	if err := j.alertEvaluator.Stop(); err != nil {
+		j.log.Error().Err(err).Msg("알림 규칙 평가 작업 종료 실패")
+	} else {
+		j.log.Info().Msg("알림 규칙 평가 작업이 정상적으로 종료되었습니다")
+	}
+
+	// 알림 전송 작업 종료 (대기 중인 알림 전송 후 종료)
+	j.log.Info().Msg("알림 전송 작업 종료 중...")
+	if err := j.alertDispatcher.Stop(); err != nil {
+		j.log.Error().Err(err).Msg("알림 전송 작업 종료 실패")
+	} else {
+		j.log.Info().Msg("알림 전송 작업이 정상적으로 종료되었습니다")
+	}
+
+	// 작업을 모두 멈춘 뒤 잠금을 풀어 다른 인스턴스와 작업이 겹치지 않도록 함
+	if err := j.leader.Release(); err != nil {
+		j.log.Error().Err(err).Msg("백그라운드 작업 리더 잠금 해제 실패")
+	}
+}
+
+// stop은 리더 잠금 대기를 멈추고, 실행 중인 작업을 중지한 뒤 리더 잠금을 풉니다.
+func (j *backgroundJobs) stop() {
+	j.cancel()
+	<-j.done
+}
@@ -15,7 +15,13 @@
 
 //	@securityDefinitions.apikey	ApiKeyAuth
 //	@in							header
+//	@name						X-API-Key
+//	@description				발급된 API 키 또는 설정 API 키
+
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
 //	@name						Authorization
+//	@description				"Bearer " 뒤에 OIDC 공급자가 발급한 JWT
 
 //	@externalDocs.description	OpenTelemetry 설명 문서
 //	@externalDocs.url			https://opentelemetry.io/docs/
@@ -24,25 +30,44 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/seongpil0948/otel-kafka-pg/modules/api"
-	"github.com/seongpil0948/otel-kafka-pg/modules/cleanup"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	commonDB "github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/stream"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/telemetry"
 	"github.com/seongpil0948/otel-kafka-pg/modules/kafka/consumer"
+	"github.com/seongpil0948/otel-kafka-pg/modules/kafka/policy"
 	"github.com/seongpil0948/otel-kafka-pg/modules/kafka/processor"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
 	"github.com/seongpil0948/otel-kafka-pg/modules/log/repository"
 	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 	traceRepository "github.com/seongpil0948/otel-kafka-pg/modules/trace/repository"
 	traceService "github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func main() {
+	// 실행 모드 (지정하면 APP_MODE 환경 변수와 설정 파일보다 우선)
+	mode := flag.String("mode", "", "실행할 구성 요소: ingest, query, cleanup, all (기본값: APP_MODE 또는 all)")
+	flag.Parse()
+	if *mode != "" {
+		// 설정 검증과 관리자 설정 조회에 실제 실행 모드가 반영되도록 설정 로드 전에 환경 변수로 전달
+		os.Setenv("APP_MODE", *mode)
+	}
+
 	// 컨텍스트 생성
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -54,6 +79,27 @@ func main() {
 	log := logger.Init()
 	log.Info().Msg("OpenTelemetry 텔레메트리 백엔드 시작 중...")
 
+	// 실행 모드별 구성 요소 (all은 모두 실행)
+	runMode := cfg.Runtime.Mode
+	runIngest := runMode == config.ModeIngest || runMode == config.ModeAll
+	runQuery := runMode == config.ModeQuery || runMode == config.ModeAll
+	runJobs := runMode == config.ModeCleanup || runMode == config.ModeAll
+	log.Info().
+		Str("mode", runMode).
+		Bool("ingest", runIngest).
+		Bool("query", runQuery).
+		Bool("background_jobs", runJobs).
+		Msg("실행 모드 확인")
+
+	// 자체 텔레메트리 초기화 (데이터베이스, Redis 계측이 전역 프로바이더를 사용하므로 가장 먼저 설정)
+	telemetryProvider, err := telemetry.Setup(ctx, cfg, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("자체 텔레메트리 초기화 실패")
+	}
+	if cfg.Telemetry.Enabled && cfg.Telemetry.Exporter == telemetry.ExporterLoopback && !runIngest {
+		log.Warn().Str("mode", runMode).Msg("loopback 방식은 수집 경로를 실행하는 모드(ingest, all)에서만 자체 스팬을 저장합니다, otlp 방식을 사용하세요")
+	}
+
 	// 3. 데이터베이스 연결 설정
 	log.Info().Msg("데이터베이스 연결 초기화 중...")
 	database, err := commonDB.NewDatabase()
@@ -62,27 +108,20 @@ func main() {
 	}
 	log.Info().Msg("데이터베이스 연결 성공")
 
-	// 4. 데이터베이스 스키마 확인 및 초기화
-	initialized, err := commonDB.IsDatabaseInitialized(database)
-	if err != nil {
-		log.Fatal().Err(err).Msg("데이터베이스 초기화 확인 실패")
-	}
-
-	if !initialized {
-		log.Info().Msg("데이터베이스 스키마가 존재하지 않음, 초기화 시작...")
-		if err := commonDB.InitializeSchema(database); err != nil {
-			log.Fatal().Err(err).Msg("데이터베이스 스키마 초기화 실패")
-		}
-		log.Info().Msg("데이터베이스 스키마 초기화 완료")
-	} else {
-		log.Info().Msg("데이터베이스 스키마가 이미 초기화되어 있음")
+	// 4. 데이터베이스 스키마 확인 및 초기화 (여러 인스턴스가 동시에 시작해도 한 번에 하나만 실행)
+	if err := commonDB.WithLock(ctx, database, schemaLockName, func() error {
+		return initSchema(database, log)
+	}); err != nil {
+		log.Fatal().Err(err).Msg("데이터베이스 스키마 초기화 실패")
 	}
 
 	// 5. Redis 및 캐싱 설정은 api.go에서 처리
-	log.Info().Bool("enable_cache", cfg.Redis.EnableCache).
-		Str("redis_address", cfg.Redis.Address).
-		Int("redis_ttl", cfg.Redis.TTL).
-		Msg("Redis 설정 확인")
+	if runQuery {
+		log.Info().Bool("enable_cache", cfg.Redis.EnableCache).
+			Str("redis_address", cfg.Redis.Address).
+			Int("redis_ttl", cfg.Redis.TTL).
+			Msg("Redis 설정 확인")
+	}
 
 	// 6. 저장소 및 서비스 계층 설정
 	logRepo := repository.NewLogRepository(database)
@@ -91,25 +130,70 @@ func main() {
 	logSvc := logService.NewLogService(logRepo)
 	traceSvc := traceService.NewTraceService(traceRepo)
 
-	// 7. 데이터 정리 서비스 설정 및 시작
-	cleanupSvc := cleanup.NewCleanupService(database, cfg)
-	if err := cleanupSvc.Start(ctx); err != nil {
-		log.Error().Err(err).Msg("데이터 정리 서비스 시작 실패")
+	// 7. 백그라운드 작업 (데이터 정리, 트레이스 집계, 알림), 리더로 선출된 인스턴스 하나만 실행
+	var jobs *backgroundJobs
+	if runJobs {
+		jobs = newBackgroundJobs(database, traceRepo, traceSvc, logSvc, cfg, log)
+		jobs.start(ctx)
+	}
+
+	// 8. 실시간 tail 허브 (같은 프로세스의 Kafka 컨슈머가 저장한 항목을 조회 API의 tail 스트림으로 전달)
+	// 컨슈머를 실행하지 않는 모드에서는 허브를 만들지 않고 tail 요청에 501을 반환
+	var traceHub *stream.Hub[traceDomain.TraceItem]
+	var logHub *stream.Hub[logDomain.LogItem]
+	if runIngest {
+		traceHub = stream.NewHub[traceDomain.TraceItem](cfg.Tail.MaxSubscribers, cfg.Tail.BufferSize)
+		logHub = stream.NewHub[logDomain.LogItem](cfg.Tail.MaxSubscribers, cfg.Tail.BufferSize)
 	}
 
-	// 8. Kafka 프로세서 및 컨슈머 설정
-	proc := processor.NewProcessor()
-	kafkaConsumer := consumer.NewConsumer(proc, traceSvc, logSvc)
+	probes := health.NewProbes(time.Duration(cfg.Health.CheckTimeout) * time.Millisecond)
+
+	// 9. Kafka 프로세서, 컨슈머 설정 및 시작
+	var kafkaConsumer consumer.Consumer
+	if runIngest {
+		proc := processor.NewProcessor()
+		pipeline, err := policy.NewPipeline(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("수집 정책 로드 실패")
+		}
+		kafkaConsumer = consumer.NewConsumer(proc, pipeline, traceSvc, logSvc, traceHub, logHub)
+
+		// loopback 방식이면 자체 스팬을 Kafka 메시지와 같은 OTLP 요청으로 직렬화해 같은 변환, 수집 경로로 저장
+		telemetryProvider.SetLoopback(func(_ context.Context, resourceSpans []*tracepb.ResourceSpans) error {
+			data, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: resourceSpans})
+			if err != nil {
+				return err
+			}
+			traces, err := proc.ProcessTraceData(data)
+			if err != nil {
+				return err
+			}
+			kafkaConsumer.IngestTraces(traces)
+			return nil
+		})
 
-	// 9. Kafka 컨슈머 시작
-	log.Info().Msg("Kafka 컨슈머 시작 중...")
-	if err := kafkaConsumer.Start(ctx); err != nil {
-		log.Fatal().Err(err).Msg("Kafka 컨슈머 시작 실패")
+		log.Info().Msg("Kafka 컨슈머 시작 중...")
+		if err := kafkaConsumer.Start(ctx); err != nil {
+			log.Fatal().Err(err).Msg("Kafka 컨슈머 시작 실패")
+		}
+		log.Info().Msg("Kafka 컨슈머가 실행 중입니다")
+
+		// 상태 점검 등록 (데이터베이스, Redis 점검은 API 서버에서 등록)
+		probes.Live.Register("kafka_poll", kafkaConsumer.CheckPolling)
+		probes.Ready.Register("kafka", kafkaConsumer.CheckKafka)
+		probes.Ready.Register("pipeline", kafkaConsumer.CheckPipeline)
 	}
-	log.Info().Msg("Kafka 컨슈머가 실행 중입니다")
 
-	// 10. API 서버 설정 및 시작
-	apiServer := api.NewServer(cfg, log, database)
+	// 10. API 서버 설정 및 시작 (조회 API를 실행하지 않는 모드는 상태 점검, 메트릭 엔드포인트만 제공)
+	var apiServer *api.Server
+	if runQuery {
+		apiServer = api.NewServer(cfg, log, database, traceHub, logHub, probes)
+		if !runIngest {
+			log.Info().Msg("이 모드에서는 Kafka 컨슈머를 실행하지 않으므로 실시간 tail 요청에 501을 반환합니다")
+		}
+	} else {
+		apiServer = api.NewOpsServer(cfg, log, database, probes)
+	}
 	go func() {
 		if err := apiServer.Start(); err != nil {
 			log.Error().Err(err).Msg("API 서버 시작 실패")
@@ -117,27 +201,65 @@ func main() {
 	}()
 
 	// 11. 애플리케이션 상태 로깅
-	log.Info().Msg("OpenTelemetry 텔레메트리 백엔드가 정상적으로 실행 중입니다")
-	log.Info().Msg("프로토콜 버퍼를 사용하여 로그 및 트레이스 데이터 처리 중...")
+	log.Info().Str("mode", runMode).Msg("OpenTelemetry 텔레메트리 백엔드가 정상적으로 실행 중입니다")
+	if runIngest {
+		log.Info().Msg("프로토콜 버퍼를 사용하여 로그 및 트레이스 데이터 처리 중...")
+	}
 	log.Info().Int("port", cfg.API.Port).Msg("API 서버가 실행 중입니다")
-	if cfg.Redis.EnableCache {
+	if runQuery && cfg.Redis.EnableCache {
 		log.Info().Int("ttl", cfg.Redis.TTL).Msg("API 응답 캐싱이 활성화되었습니다")
 	}
 
-	// 종료 시그널 처리
+	// 설정 파일이 바뀌면 다시 로드
+	if err := config.Watch(ctx); err != nil {
+		log.Warn().Err(err).Msg("설정 파일 감시 시작 실패, SIGHUP으로만 다시 로드할 수 있습니다")
+	}
+
+	// 종료 및 설정 다시 로드 시그널 처리
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
 
-	// 종료 시그널 대기
+	// 종료 시그널 대기 (SIGHUP은 설정을 다시 로드하고 계속 실행)
 	sig := <-sigCh
+	for sig == syscall.SIGHUP {
+		log.Info().Msg("SIGHUP 수신, 설정을 다시 로드합니다")
+		if _, err := config.Reload(); err != nil {
+			log.Error().Strs("errors", strings.Split(err.Error(), "\n")).Msg("설정 다시 로드 실패, 기존 설정 유지")
+		}
+		sig = <-sigCh
+	}
 	log.Info().Str("signal", sig.String()).Msg("종료 신호 수신, 정상 종료를 시작합니다")
 
 	// 12. 정상 종료 처리
-	shutdown(ctx, database, kafkaConsumer, cleanupSvc, apiServer, log)
+	shutdown(ctx, database, kafkaConsumer, jobs, apiServer, telemetryProvider, log)
+}
+
+// 스키마 초기화, 마이그레이션 잠금 이름
+const schemaLockName = "otel-kafka-pg/schema"
+
+// initSchema는 데이터베이스 스키마가 없으면 만들고 마이그레이션을 적용합니다.
+func initSchema(database commonDB.Database, log logger.Logger) error {
+	initialized, err := commonDB.IsDatabaseInitialized(database)
+	if err != nil {
+		return fmt.Errorf("데이터베이스 초기화 확인 실패: %w", err)
+	}
+
+	if !initialized {
+		log.Info().Msg("데이터베이스 스키마가 존재하지 않음, 초기화 시작...")
+		if err := commonDB.InitializeSchema(database); err != nil {
+			return err
+		}
+		log.Info().Msg("데이터베이스 스키마 초기화 완료")
+	} else {
+		log.Info().Msg("데이터베이스 스키마가 이미 초기화되어 있음")
+	}
+
+	return commonDB.ApplyMigrations(database)
 }
 
 // shutdown은 애플리케이션을 정상적으로 종료합니다.
-func shutdown(ctx context.Context, database commonDB.Database, kafkaConsumer consumer.Consumer, cleanupSvc cleanup.CleanupService, apiServer *api.Server, log logger.Logger) {
+// 실행 모드에서 만들지 않은 구성 요소(kafkaConsumer, jobs)는 nil이며 건너뜁니다.
+func shutdown(ctx context.Context, database commonDB.Database, kafkaConsumer consumer.Consumer, jobs *backgroundJobs, apiServer *api.Server, telemetryProvider *telemetry.Provider, log logger.Logger) {
 	// 종료 컨텍스트 생성
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -150,20 +272,25 @@ func shutdown(ctx context.Context, database commonDB.Database, kafkaConsumer con
 		log.Info().Msg("API 서버가 정상적으로 종료되었습니다")
 	}
 
-	// 데이터 정리 서비스 종료
-	log.Info().Msg("데이터 정리 서비스 종료 중...")
-	if err := cleanupSvc.Stop(); err != nil {
-		log.Error().Err(err).Msg("데이터 정리 서비스 종료 실패")
-	} else {
-		log.Info().Msg("데이터 정리 서비스가 정상적으로 종료되었습니다")
+	// 백그라운드 작업 종료 및 리더 잠금 해제
+	if jobs != nil {
+		jobs.stop()
+	}
+
+	// 자체 텔레메트리 종료 (loopback 방식의 마지막 스팬이 컨슈머의 마지막 플러시에 포함되도록 컨슈머보다 먼저 종료)
+	log.Info().Msg("자체 텔레메트리 종료 중...")
+	if err := telemetryProvider.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("자체 텔레메트리 종료 실패")
 	}
 
 	// Kafka 컨슈머 종료
-	log.Info().Msg("Kafka 컨슈머 종료 중...")
-	if err := kafkaConsumer.Stop(); err != nil {
-		log.Error().Err(err).Msg("Kafka 컨슈머 종료 실패")
-	} else {
-		log.Info().Msg("Kafka 컨슈머가 정상적으로 종료되었습니다")
+	if kafkaConsumer != nil {
+		log.Info().Msg("Kafka 컨슈머 종료 중...")
+		if err := kafkaConsumer.Stop(); err != nil {
+			log.Error().Err(err).Msg("Kafka 컨슈머 종료 실패")
+		} else {
+			log.Info().Msg("Kafka 컨슈머가 정상적으로 종료되었습니다")
+		}
 	}
 
 	// 데이터베이스 연결 종료
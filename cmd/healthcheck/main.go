@@ -1,39 +1,140 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/redis"
+)
+
+// 점검 종류 (API 서버 엔드포인트 이름)
+const (
+	probeReady = "readyz"
+	probeLive  = "livez"
 )
 
 func main() {
-	// 설정 로드 (사용하지 않으므로 변수 할당 제거)
-	_ = config.LoadConfig()
-	
-	fmt.Println("Health check 실행 중...")
-	
-	// 데이터베이스 연결 확인
-	database, err := db.NewDatabase()
-	if err != nil {
-		fmt.Printf("Health check 실패: 데이터베이스 연결 오류: %v\n", err)
-		os.Exit(1)
+	probe := flag.String("probe", probeReady, "점검 종류 (readyz: 준비 상태, livez: 활성 상태)")
+	addr := flag.String("addr", "", "API 서버 주소 (기본값: http://127.0.0.1:API_PORT)")
+	direct := flag.Bool("direct", false, "API 서버를 거치지 않고 데이터베이스와 Redis를 직접 점검 (Kafka, 파이프라인 점검 제외)")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+
+	if *probe != probeReady && *probe != probeLive {
+		fmt.Printf("Health check 실패: 알 수 없는 점검 종류: %s\n", *probe)
+		os.Exit(2)
 	}
-	
-	// 간단한 쿼리 실행
-	_, err = database.Execute("SELECT 1")
-	if err != nil {
-		fmt.Printf("Health check 실패: 데이터베이스 쿼리 오류: %v\n", err)
-		os.Exit(1)
+
+	fmt.Printf("Health check 실행 중... (%s)\n", *probe)
+
+	timeout := time.Duration(cfg.Health.CheckTimeout) * time.Millisecond
+	var (
+		report health.Report
+		err    error
+	)
+	if *direct {
+		report = runDirect(cfg, *probe, timeout)
+	} else {
+		baseURL := *addr
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("http://127.0.0.1:%d", cfg.API.Port)
+		}
+		report, err = fetchReport(strings.TrimRight(baseURL, "/")+"/"+*probe, timeout)
+		if err != nil {
+			fmt.Printf("Health check 실패: %v\n", err)
+			os.Exit(1)
+		}
 	}
-	
-	// 데이터베이스 연결 종료
-	if err := database.Close(); err != nil {
-		fmt.Printf("Health check 실패: 데이터베이스 연결 종료 오류: %v\n", err)
+
+	printReport(report)
+
+	if !report.Ready() {
+		fmt.Println("Health check 실패")
 		os.Exit(1)
 	}
-	
+
 	fmt.Println("Health check 성공")
 	os.Exit(0)
-}
\ No newline at end of file
+}
+
+// fetchReport는 실행 중인 API 서버의 점검 엔드포인트를 호출해 보고서를 가져옵니다.
+// 서버는 각 점검에 timeout을 적용하므로 요청 제한 시간은 여유를 더해 설정합니다.
+func fetchReport(url string, timeout time.Duration) (health.Report, error) {
+	var report health.Report
+
+	client := &http.Client{Timeout: timeout + 3*time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return report, fmt.Errorf("API 서버 점검 요청 오류: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return report, fmt.Errorf("API 서버 점검 응답 읽기 오류: %w", err)
+	}
+	if err := json.Unmarshal(body, &report); err != nil {
+		return report, fmt.Errorf("API 서버 점검 응답 파싱 오류 (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK && report.Ready() {
+		report.Status = health.StatusDown
+	}
+	return report, nil
+}
+
+// runDirect는 이 프로세스에서 데이터베이스와 (캐싱 사용 시) Redis를 직접 점검합니다.
+// Kafka 컨슈머와 파이프라인 상태는 애플리케이션 프로세스 안에만 있으므로 API 서버 점검으로만 확인할 수 있습니다.
+func runDirect(cfg *config.Config, probe string, timeout time.Duration) health.Report {
+	checker := health.NewChecker(timeout)
+
+	// 활성 상태 점검은 애플리케이션 내부 상태만 보므로 직접 점검할 항목이 없음
+	if probe == probeReady {
+		checker.Register("database", func(ctx context.Context) health.Result {
+			database, err := db.NewDatabase()
+			if err != nil {
+				return health.Down("데이터베이스 연결 오류: "+err.Error(), nil)
+			}
+			defer database.Close()
+
+			if _, err := database.ExecuteContext(ctx, "SELECT 1"); err != nil {
+				return health.Down("데이터베이스 쿼리 오류: "+err.Error(), nil)
+			}
+			return health.OK(nil)
+		})
+
+		if cfg.Redis.EnableCache {
+			checker.Register("redis", func(ctx context.Context) health.Result {
+				client, err := redis.NewRedisClient()
+				if err != nil {
+					return health.Down("Redis 연결 오류: "+err.Error(), nil)
+				}
+				defer client.Close()
+
+				return health.Ping(client.Ping)(ctx)
+			})
+		}
+	}
+
+	return checker.Run(context.Background())
+}
+
+// printReport는 점검 보고서를 JSON으로 출력합니다.
+func printReport(report health.Report) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Printf("점검 보고서 출력 오류: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RuleType은 알림 규칙이 평가하는 지표 종류입니다.
+type RuleType string
+
+const (
+	RuleTypeErrorRate RuleType = "error_rate" // 서비스 오류율 (%)
+	RuleTypeLatency   RuleType = "latency"    // 서비스 지연 시간 백분위 (밀리초)
+	RuleTypeLogCount  RuleType = "log_count"  // 조건에 맞는 로그 수
+)
+
+// 규칙 비교 연산자
+const (
+	OperatorGreaterThan    = ">"
+	OperatorGreaterOrEqual = ">="
+	OperatorLessThan       = "<"
+	OperatorLessOrEqual    = "<="
+)
+
+// 알림 상태
+const (
+	StateInactive = "inactive" // 조건을 만족하지 않음
+	StatePending  = "pending"  // 조건을 만족했지만 유지 시간(for)이 지나지 않음
+	StateFiring   = "firing"   // 유지 시간 동안 조건을 계속 만족함
+	StateResolved = "resolved" // firing 상태에서 조건을 더 이상 만족하지 않음 (이력 전용)
+)
+
+// 규칙 평가 구간 제한 (초)
+const (
+	DefaultRuleWindow = int64(300)
+	MaxRuleWindow     = int64(86400)
+)
+
+// ErrRuleNotFound는 요청한 알림 규칙이 존재하지 않을 때 반환됩니다.
+var ErrRuleNotFound = errors.New("alert rule not found")
+
+// Rule은 사용자가 정의한 알림 규칙입니다.
+// 예: 서비스 X의 오류율 > 5% 가 5분간 지속, p99 지연 시간 > N ms, 검색어에 맞는 로그 수 > N
+type Rule struct {
+	ID          int64             `json:"id"`
+	TenantID    string            `json:"-"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Type        RuleType          `json:"type"`
+	ServiceName string            `json:"serviceName,omitempty"`
+	Percentile  int               `json:"percentile,omitempty"` // latency 규칙: 95 또는 99
+	Severity    string            `json:"severity,omitempty"`   // log_count 규칙: 로그 심각도
+	Query       string            `json:"query,omitempty"`      // log_count 규칙: 메시지 검색어
+	Operator    string            `json:"operator"`
+	Threshold   float64           `json:"threshold"`
+	Window      int64             `json:"window"` // 평가 구간 (초)
+	For         int64             `json:"for"`    // firing 전환까지 조건이 유지되어야 하는 시간 (초)
+	Labels      map[string]string `json:"labels,omitempty"`
+	Enabled     bool              `json:"enabled"`
+	CreatedAt   int64             `json:"createdAt"`
+	UpdatedAt   int64             `json:"updatedAt"`
+}
+
+// Validate는 규칙 정의가 올바른지 확인하고 생략된 값에 기본값을 채웁니다.
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	switch r.Type {
+	case RuleTypeErrorRate:
+		if r.ServiceName == "" {
+			return errors.New("serviceName is required for error_rate rules")
+		}
+	case RuleTypeLatency:
+		if r.ServiceName == "" {
+			return errors.New("serviceName is required for latency rules")
+		}
+		if r.Percentile == 0 {
+			r.Percentile = 99
+		}
+		if r.Percentile != 95 && r.Percentile != 99 {
+			return fmt.Errorf("unsupported percentile %d (95 or 99)", r.Percentile)
+		}
+	case RuleTypeLogCount:
+	default:
+		return fmt.Errorf("unsupported rule type %q", r.Type)
+	}
+
+	switch r.Operator {
+	case "":
+		r.Operator = OperatorGreaterThan
+	case OperatorGreaterThan, OperatorGreaterOrEqual, OperatorLessThan, OperatorLessOrEqual:
+	default:
+		return fmt.Errorf("unsupported operator %q", r.Operator)
+	}
+
+	if r.Window == 0 {
+		r.Window = DefaultRuleWindow
+	}
+	if r.Window < 0 || r.Window > MaxRuleWindow {
+		return fmt.Errorf("window must be between 1 and %d seconds", MaxRuleWindow)
+	}
+	if r.For < 0 {
+		return errors.New("for must not be negative")
+	}
+
+	return nil
+}
+
+// Matches는 평가 값이 규칙 조건을 만족하는지 확인합니다.
+func (r *Rule) Matches(value float64) bool {
+	switch r.Operator {
+	case OperatorGreaterOrEqual:
+		return value >= r.Threshold
+	case OperatorLessThan:
+		return value < r.Threshold
+	case OperatorLessOrEqual:
+		return value <= r.Threshold
+	default:
+		return value > r.Threshold
+	}
+}
+
+// Alert는 규칙의 현재 알림 상태입니다.
+type Alert struct {
+	RuleID        int64             `json:"ruleId"`
+	RuleName      string            `json:"ruleName"`
+	State         string            `json:"state"`
+	Value         float64           `json:"value"`
+	Threshold     float64           `json:"threshold"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	ActiveSince   int64             `json:"activeSince,omitempty"` // 조건을 처음 만족한 시간 (밀리초)
+	FiredAt       int64             `json:"firedAt,omitempty"`     // firing 전환 시간 (밀리초)
+	LastEvaluated int64             `json:"lastEvaluated"`
+}
+
+// AlertEvent는 알림 상태 전환 이력입니다.
+type AlertEvent struct {
+	ID        int64   `json:"id"`
+	TenantID  string  `json:"-"`
+	RuleID    int64   `json:"ruleId"`
+	RuleName  string  `json:"ruleName"`
+	State     string  `json:"state"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Message   string  `json:"message"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// AlertHistoryFilter는 알림 이력 조회 조건입니다.
+type AlertHistoryFilter struct {
+	TenantID  string
+	RuleID    int64
+	State     string
+	StartTime int64
+	EndTime   int64
+	Limit     int
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ChannelType은 알림 채널 종류입니다.
+type ChannelType string
+
+const (
+	ChannelTypeWebhook ChannelType = "webhook" // 알림 JSON을 그대로 POST하는 일반 웹훅
+	ChannelTypeSlack   ChannelType = "slack"   // Slack 호환 웹훅 ({"text": ...})
+	ChannelTypeEmail   ChannelType = "email"   // SMTP 이메일
+)
+
+var (
+	// ErrChannelNotFound는 요청한 알림 채널이 존재하지 않을 때 반환됩니다.
+	ErrChannelNotFound = errors.New("notification channel not found")
+	// ErrSilenceNotFound는 요청한 사일런스가 존재하지 않을 때 반환됩니다.
+	ErrSilenceNotFound = errors.New("silence not found")
+)
+
+// Channel은 알림을 전달할 채널입니다.
+// Matchers가 모두 일치하는 알림만 받으며, 같은 GroupBy 라벨 값을 가진 알림은 한 메시지로 묶여 전송됩니다.
+type Channel struct {
+	ID         int64             `json:"id"`
+	TenantID   string            `json:"-"`
+	Name       string            `json:"name"`
+	Type       ChannelType       `json:"type"`
+	URL        string            `json:"url,omitempty"`        // webhook, slack
+	Headers    map[string]string `json:"headers,omitempty"`    // webhook 요청 헤더
+	Recipients []string          `json:"recipients,omitempty"` // email 수신자
+	Matchers   map[string]string `json:"matchers,omitempty"`
+	GroupBy    []string          `json:"groupBy,omitempty"`
+	Template   string            `json:"template,omitempty"` // Go text/template, 비어 있으면 기본 템플릿
+	Enabled    bool              `json:"enabled"`
+	CreatedAt  int64             `json:"createdAt"`
+	UpdatedAt  int64             `json:"updatedAt"`
+}
+
+// Validate는 채널 정의가 올바른지 확인합니다.
+func (c *Channel) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+
+	switch c.Type {
+	case ChannelTypeWebhook, ChannelTypeSlack:
+		parsed, err := url.Parse(c.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("url must be an absolute http(s) URL for %s channels", c.Type)
+		}
+	case ChannelTypeEmail:
+		if len(c.Recipients) == 0 {
+			return errors.New("recipients are required for email channels")
+		}
+	default:
+		return fmt.Errorf("unsupported channel type %q", c.Type)
+	}
+
+	return nil
+}
+
+// 비밀 값 대신 보여주는 문자열
+const maskedValue = "******"
+
+// Masked는 웹훅 URL의 사용자 정보, 경로, 쿼리와 요청 헤더 값을 가린 채널 복사본을 반환합니다.
+// Slack 웹훅처럼 URL 경로나 헤더에 토큰이 들어 있으므로 채널을 수정할 수 없는 사용자에게는 가린 값을 보여줍니다.
+// 전송 대상을 알 수 있도록 스킴과 호스트는 그대로 둡니다.
+func (c Channel) Masked() Channel {
+	masked := c
+	masked.URL = maskURL(c.URL)
+
+	if len(c.Headers) > 0 {
+		masked.Headers = make(map[string]string, len(c.Headers))
+		for name, value := range c.Headers {
+			masked.Headers[name] = mask(value)
+		}
+	}
+
+	return masked
+}
+
+// mask는 비어 있지 않은 값을 가립니다.
+func mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	return maskedValue
+}
+
+// maskURL은 URL의 스킴과 호스트만 남기고 나머지를 가립니다. URL이 아니면 전체를 가립니다.
+func maskURL(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return maskedValue
+	}
+
+	// url.URL.String은 가린 값의 *를 이스케이프하므로 직접 조합
+	masked := u.Scheme + "://"
+	if u.User != nil {
+		masked += maskedValue + "@"
+	}
+	masked += u.Host
+	if u.Path != "" && u.Path != "/" {
+		masked += "/" + maskedValue
+	}
+	if u.RawQuery != "" {
+		masked += "?" + maskedValue
+	}
+	return masked
+}
+
+// Silence는 일정 기간 동안 Matchers가 모두 일치하는 알림의 전송을 막습니다. 알림 상태와 이력에는 영향을 주지 않습니다.
+type Silence struct {
+	ID        int64             `json:"id"`
+	TenantID  string            `json:"-"`
+	Matchers  map[string]string `json:"matchers"`
+	StartsAt  int64             `json:"startsAt"` // 밀리초
+	EndsAt    int64             `json:"endsAt"`   // 밀리초
+	Comment   string            `json:"comment,omitempty"`
+	CreatedBy string            `json:"createdBy,omitempty"`
+	CreatedAt int64             `json:"createdAt"`
+}
+
+// Validate는 사일런스 정의가 올바른지 확인합니다.
+func (s *Silence) Validate() error {
+	if len(s.Matchers) == 0 {
+		return errors.New("at least one matcher is required")
+	}
+	if s.EndsAt <= s.StartsAt {
+		return errors.New("endsAt must be after startsAt")
+	}
+	return nil
+}
+
+// Active는 주어진 시간에 사일런스가 유효한지 확인합니다.
+func (s *Silence) Active(now int64) bool {
+	return s.StartsAt <= now && now < s.EndsAt
+}
+
+// MatchLabels는 matchers의 모든 라벨이 labels와 일치하는지 확인합니다. matchers가 비어 있으면 항상 일치합니다.
+func MatchLabels(matchers, labels map[string]string) bool {
+	for name, value := range matchers {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// AlertLabels는 알림 라우팅과 사일런스에 사용하는 라벨을 반환합니다.
+// 규칙 라벨에 alertname, rule_id, service(설정된 경우)가 추가됩니다.
+func (r *Rule) AlertLabels() map[string]string {
+	labels := make(map[string]string, len(r.Labels)+3)
+	for name, value := range r.Labels {
+		labels[name] = value
+	}
+	labels["alertname"] = r.Name
+	labels["rule_id"] = strconv.FormatInt(r.ID, 10)
+	if r.ServiceName != "" {
+		labels["service"] = r.ServiceName
+	}
+	return labels
+}
+
+// AlertNotice는 알림 메시지에 포함되는 개별 알림입니다.
+type AlertNotice struct {
+	RuleID    int64             `json:"ruleId"`
+	RuleName  string            `json:"ruleName"`
+	State     string            `json:"state"`
+	Value     float64           `json:"value"`
+	Threshold float64           `json:"threshold"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// Notification은 채널로 전송되는 그룹 알림 메시지이며 템플릿 데이터로도 사용됩니다.
+type Notification struct {
+	Channel     string            `json:"channel"`
+	Status      string            `json:"status"` // firing 알림이 하나라도 있으면 firing, 아니면 resolved
+	GroupLabels map[string]string `json:"groupLabels"`
+	Alerts      []AlertNotice     `json:"alerts"`
+	Firing      int               `json:"firing"`
+	Resolved    int               `json:"resolved"`
+	Timestamp   int64             `json:"timestamp"`
+}
@@ -0,0 +1,378 @@
+package notify
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/repository"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// 모인 그룹을 확인하는 주기
+const dispatchInterval = time.Second
+
+// Dispatcher는 알림 상태 전환을 채널별로 묶어 전송하는 백그라운드 작업입니다.
+type Dispatcher interface {
+	// Start는 전송 작업을 시작합니다.
+	Start(ctx context.Context) error
+	// Stop은 대기 중인 알림을 모두 전송한 뒤 작업을 중지합니다.
+	Stop() error
+	// Notify는 상태 전환을 전송 대기열에 추가합니다. firing, resolved 전환만 전송합니다.
+	Notify(rule domain.Rule, event domain.AlertEvent)
+}
+
+// group은 같은 채널, 같은 그룹 라벨로 묶여 함께 전송될 알림입니다.
+type group struct {
+	channel   domain.Channel
+	labels    map[string]string
+	alerts    []domain.AlertNotice
+	dedupKeys []string // 그룹에 담긴 알림의 중복 제거 키
+	firstAdd  time.Time
+}
+
+// dispatcherImpl은 Dispatcher 인터페이스의 구현체입니다.
+type dispatcherImpl struct {
+	repository repository.AlertRepository
+	config     *config.Config
+	log        logger.Logger
+
+	mu       sync.Mutex
+	groups   map[string]*group
+	lastSent map[string]time.Time // 채널/규칙/상태별 마지막 전송 성공 시간 (중복 제거용)
+	pending  map[string]bool      // 그룹에서 대기 중이거나 전송 중인 채널/규칙/상태
+
+	ticker    *time.Ticker
+	stopChan  chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	isRunning bool
+}
+
+// NewDispatcher는 새 Dispatcher 인스턴스를 생성합니다.
+func NewDispatcher(repo repository.AlertRepository, config *config.Config) Dispatcher {
+	return &dispatcherImpl{
+		repository: repo,
+		config:     config,
+		log:        logger.GetLogger(),
+		groups:     make(map[string]*group),
+		lastSent:   make(map[string]time.Time),
+		pending:    make(map[string]bool),
+		stopChan:   make(chan struct{}),
+		isRunning:  false,
+	}
+}
+
+// Start는 전송 작업을 시작합니다.
+func (d *dispatcherImpl) Start(ctx context.Context) error {
+	if d.isRunning {
+		d.log.Info().Msg("알림 전송 작업이 이미 실행 중입니다")
+		return nil
+	}
+
+	if !d.config.Notification.Enabled {
+		d.log.Info().Msg("알림 전송 작업이 비활성화되어 있습니다")
+		return nil
+	}
+
+	// 종료 시 재시도 대기를 중단하기 위해 별도 컨텍스트 사용
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	d.ticker = time.NewTicker(dispatchInterval)
+	d.stopChan = make(chan struct{})
+	d.isRunning = true
+
+	d.log.Info().
+		Int("group_wait_seconds", d.config.Notification.GroupWait).
+		Int("dedup_window_seconds", d.config.Notification.DedupWindow).
+		Int("max_retries", d.config.Notification.MaxRetries).
+		Msg("알림 전송 작업 시작")
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for {
+			select {
+			case <-d.ticker.C:
+				d.flush(false)
+			case <-d.stopChan:
+				d.log.Info().Msg("알림 전송 작업 루프 종료")
+				return
+			case <-ctx.Done():
+				d.log.Info().Msg("컨텍스트 종료로 인한 알림 전송 작업 루프 종료")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop은 대기 중인 알림을 모두 전송한 뒤 작업을 중지합니다.
+func (d *dispatcherImpl) Stop() error {
+	if !d.isRunning {
+		return nil
+	}
+
+	d.ticker.Stop()
+	close(d.stopChan)
+	d.flush(true)
+
+	// 전송 제한 시간과 재시도를 고려해 대기하고, 넘으면 남은 재시도를 취소
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Duration(d.config.Notification.Timeout) * time.Second):
+		d.log.Warn().Msg("알림 전송 대기 시간 초과, 남은 재시도를 취소합니다")
+		d.cancel()
+		<-done
+	}
+	d.cancel()
+
+	d.isRunning = false
+	d.log.Info().Msg("알림 전송 작업 중지됨")
+	return nil
+}
+
+// Notify는 상태 전환을 사일런스, 채널 매칭, 중복 제거를 거쳐 채널별 그룹에 추가합니다.
+func (d *dispatcherImpl) Notify(rule domain.Rule, event domain.AlertEvent) {
+	if !d.isRunning {
+		return
+	}
+	if event.State != domain.StateFiring && event.State != domain.StateResolved {
+		return
+	}
+
+	labels := rule.AlertLabels()
+
+	silenced, err := d.silenced(rule.TenantID, labels, event.Timestamp)
+	if err != nil {
+		d.log.Error().Err(err).Msg("사일런스 조회 실패")
+	}
+	if silenced {
+		d.log.Info().Int64("rule_id", rule.ID).Str("state", event.State).Msg("사일런스로 알림 전송 생략")
+		return
+	}
+
+	channels, err := d.repository.ListChannels(rule.TenantID)
+	if err != nil {
+		d.log.Error().Err(err).Msg("알림 채널 조회 실패")
+		return
+	}
+
+	notice := domain.AlertNotice{
+		RuleID:    event.RuleID,
+		RuleName:  event.RuleName,
+		State:     event.State,
+		Value:     event.Value,
+		Threshold: event.Threshold,
+		Message:   event.Message,
+		Labels:    labels,
+		Timestamp: event.Timestamp,
+	}
+
+	now := time.Now()
+	dedupWindow := time.Duration(d.config.Notification.DedupWindow) * time.Second
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, channel := range channels {
+		if !channel.Enabled || !domain.MatchLabels(channel.Matchers, labels) {
+			continue
+		}
+
+		// 같은 채널, 규칙, 상태의 알림은 전송을 기다리는 동안과 전송에 성공한 뒤 중복 제거 시간 동안 한 번만 전송
+		// 전송에 실패하면 기록하지 않으므로 다음 상태 전환 때 다시 전송
+		dedupKey := strconv.FormatInt(channel.ID, 10) + "/" + strconv.FormatInt(rule.ID, 10) + "/" + event.State
+		sentAt, sent := d.lastSent[dedupKey]
+		if d.pending[dedupKey] || (sent && now.Sub(sentAt) < dedupWindow) {
+			d.log.Debug().Int64("channel_id", channel.ID).Int64("rule_id", rule.ID).Str("state", event.State).Msg("중복 알림 생략")
+			continue
+		}
+		d.pending[dedupKey] = true
+
+		groupLabels := make(map[string]string, len(channel.GroupBy))
+		for _, name := range channel.GroupBy {
+			groupLabels[name] = labels[name]
+		}
+		key := groupKey(channel.ID, groupLabels)
+
+		g, ok := d.groups[key]
+		if !ok {
+			g = &group{channel: channel, labels: groupLabels, firstAdd: now}
+			d.groups[key] = g
+		}
+		g.alerts = append(g.alerts, notice)
+		g.dedupKeys = append(g.dedupKeys, dedupKey)
+	}
+}
+
+// flush는 대기 시간이 지난 그룹(all이면 모든 그룹)을 전송합니다.
+func (d *dispatcherImpl) flush(all bool) {
+	groupWait := time.Duration(d.config.Notification.GroupWait) * time.Second
+	dedupWindow := time.Duration(d.config.Notification.DedupWindow) * time.Second
+	now := time.Now()
+
+	d.mu.Lock()
+	ready := make([]*group, 0)
+	for key, g := range d.groups {
+		if all || now.Sub(g.firstAdd) >= groupWait {
+			ready = append(ready, g)
+			delete(d.groups, key)
+		}
+	}
+	for key, sentAt := range d.lastSent {
+		if now.Sub(sentAt) >= dedupWindow {
+			delete(d.lastSent, key)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, g := range ready {
+		notification := newNotification(g.channel.Name, g.labels, g.alerts)
+		channel := g.channel
+		dedupKeys := g.dedupKeys
+
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.sent(dedupKeys, d.send(channel, notification))
+		}()
+	}
+}
+
+// sent는 그룹 전송이 끝난 알림을 대기 목록에서 빼고, 전송에 성공했으면 중복 제거 시간을 기록합니다.
+func (d *dispatcherImpl) sent(dedupKeys []string, ok bool) {
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, key := range dedupKeys {
+		delete(d.pending, key)
+		if ok {
+			d.lastSent[key] = now
+		}
+	}
+}
+
+// send는 실패 시 지수 백오프로 재시도하며 알림을 전송하고, 전송에 성공했는지 반환합니다.
+func (d *dispatcherImpl) send(channel domain.Channel, notification domain.Notification) bool {
+	sender, err := NewSender(channel, d.config)
+	if err != nil {
+		d.log.Error().Err(err).Int64("channel_id", channel.ID).Msg("알림 채널 생성 실패")
+		return false
+	}
+
+	backoff := time.Duration(d.config.Notification.RetryBackoff) * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := sender.Send(d.ctx, notification)
+		if err == nil {
+			d.log.Info().
+				Int64("channel_id", channel.ID).
+				Str("channel", channel.Name).
+				Str("status", notification.Status).
+				Int("alerts", len(notification.Alerts)).
+				Int("attempt", attempt+1).
+				Msg("알림 전송 완료")
+			return true
+		}
+
+		if attempt >= d.config.Notification.MaxRetries {
+			d.log.Error().Err(err).Int64("channel_id", channel.ID).Str("channel", channel.Name).Int("attempts", attempt+1).Msg("알림 전송 실패, 재시도 횟수 초과")
+			return false
+		}
+
+		d.log.Warn().Err(err).Int64("channel_id", channel.ID).Str("channel", channel.Name).Dur("backoff", backoff).Msg("알림 전송 실패, 재시도 예정")
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-d.ctx.Done():
+			d.log.Error().Int64("channel_id", channel.ID).Msg("종료로 인해 알림 재시도 취소")
+			return false
+		}
+	}
+}
+
+// silenced는 테넌트에 라벨과 일치하는 유효한 사일런스가 있는지 확인합니다.
+func (d *dispatcherImpl) silenced(tenantID string, labels map[string]string, now int64) (bool, error) {
+	silences, err := d.repository.ListSilences(tenantID, now)
+	if err != nil {
+		return false, err
+	}
+
+	for _, silence := range silences {
+		if silence.Active(now) && domain.MatchLabels(silence.Matchers, labels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newNotification은 그룹의 알림으로 전송 메시지를 만듭니다.
+func newNotification(channelName string, groupLabels map[string]string, alerts []domain.AlertNotice) domain.Notification {
+	notification := domain.Notification{
+		Channel:     channelName,
+		Status:      domain.StateResolved,
+		GroupLabels: groupLabels,
+		Alerts:      alerts,
+		Timestamp:   time.Now().UnixMilli(),
+	}
+
+	for _, alert := range alerts {
+		if alert.State == domain.StateFiring {
+			notification.Firing++
+		} else {
+			notification.Resolved++
+		}
+	}
+	if notification.Firing > 0 {
+		notification.Status = domain.StateFiring
+	}
+
+	return notification
+}
+
+// groupKey는 채널 ID와 그룹 라벨로 그룹 키를 만듭니다.
+func groupKey(channelID int64, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatInt(channelID, 10))
+	for _, name := range names {
+		b.WriteString("\x00" + name + "=" + labels[name])
+	}
+	return b.String()
+}
+
+// SendTest는 채널 설정 확인을 위해 예시 알림을 재시도 없이 한 번 전송합니다.
+func SendTest(ctx context.Context, channel domain.Channel, cfg *config.Config) error {
+	sender, err := NewSender(channel, cfg)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	notification := newNotification(channel.Name, map[string]string{}, []domain.AlertNotice{{
+		RuleName:  "test",
+		State:     domain.StateFiring,
+		Message:   "알림 채널 테스트 메시지입니다",
+		Labels:    map[string]string{"alertname": "test"},
+		Timestamp: now,
+	}})
+
+	return sender.Send(ctx, notification)
+}
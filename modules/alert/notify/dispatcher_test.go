@@ -0,0 +1,319 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/repository"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// fakeRepository는 디스패처가 사용하는 채널, 사일런스 조회만 구현한 저장소입니다.
+type fakeRepository struct {
+	repository.AlertRepository
+	channels []domain.Channel
+	silences []domain.Silence
+}
+
+func (r *fakeRepository) ListChannels(tenantID string) ([]domain.Channel, error) {
+	return r.channels, nil
+}
+
+func (r *fakeRepository) ListSilences(tenantID string, since int64) ([]domain.Silence, error) {
+	return r.silences, nil
+}
+
+// webhookSink는 받은 알림을 기록하고 statuses 순서대로 응답하는 웹훅 수신기입니다. statuses를 다 쓰면 200을 반환합니다.
+type webhookSink struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	statuses      []int
+	notifications []domain.Notification
+	attempts      []time.Time
+}
+
+func newWebhookSink(t *testing.T, statuses ...int) *webhookSink {
+	sink := &webhookSink{statuses: statuses}
+	sink.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+
+		sink.attempts = append(sink.attempts, time.Now())
+		if len(sink.statuses) > 0 {
+			status := sink.statuses[0]
+			sink.statuses = sink.statuses[1:]
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+		}
+
+		var notification domain.Notification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Errorf("decode notification: %v", err)
+		}
+		sink.notifications = append(sink.notifications, notification)
+	}))
+	t.Cleanup(sink.server.Close)
+	return sink
+}
+
+func (s *webhookSink) received() []domain.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.Notification(nil), s.notifications...)
+}
+
+// newTestDispatcher는 수신기로 보내는 웹훅 채널 하나를 가진 디스패처를 실행 중 상태로 만듭니다.
+// 주기적인 전송 대신 flushAndWait로 전송합니다.
+func newTestDispatcher(t *testing.T, sink *webhookSink, channel domain.Channel, silences ...domain.Silence) *dispatcherImpl {
+	cfg := &config.Config{}
+	cfg.Notification.Enabled = true
+	cfg.Notification.AllowPrivateNetworks = true // httptest 서버는 루프백 주소
+	cfg.Notification.Timeout = 5
+	cfg.Notification.GroupWait = 3600
+	cfg.Notification.DedupWindow = 3600
+	cfg.Notification.MaxRetries = 3
+	cfg.Notification.RetryBackoff = 20
+
+	channel.ID = 1
+	channel.Name = "ops"
+	channel.Type = domain.ChannelTypeWebhook
+	channel.URL = sink.server.URL
+	channel.Enabled = true
+
+	d := NewDispatcher(&fakeRepository{channels: []domain.Channel{channel}, silences: silences}, cfg).(*dispatcherImpl)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	d.isRunning = true
+	t.Cleanup(d.cancel)
+	return d
+}
+
+// flushAndWait는 대기 중인 그룹을 모두 전송하고 전송이 끝날 때까지 기다립니다.
+func (d *dispatcherImpl) flushAndWait() {
+	d.flush(true)
+	d.wg.Wait()
+}
+
+func testRule(id int64, service string) domain.Rule {
+	return domain.Rule{ID: id, TenantID: "default", Name: "rule", ServiceName: service}
+}
+
+func testEvent(rule domain.Rule, state string) domain.AlertEvent {
+	return domain.AlertEvent{RuleID: rule.ID, RuleName: rule.Name, State: state, Timestamp: time.Now().UnixMilli()}
+}
+
+func TestDispatcherGroupsAlerts(t *testing.T) {
+	sink := newWebhookSink(t)
+	d := newTestDispatcher(t, sink, domain.Channel{GroupBy: []string{"service"}})
+
+	for _, rule := range []domain.Rule{testRule(1, "api"), testRule(2, "api"), testRule(3, "web")} {
+		d.Notify(rule, testEvent(rule, domain.StateFiring))
+	}
+	// pending 전환은 전송하지 않음
+	d.Notify(testRule(4, "api"), testEvent(testRule(4, "api"), domain.StatePending))
+	d.flushAndWait()
+
+	got := sink.received()
+	if len(got) != 2 {
+		t.Fatalf("received %d notifications, want 2", len(got))
+	}
+	counts := map[string]int{}
+	for _, notification := range got {
+		counts[notification.GroupLabels["service"]] = len(notification.Alerts)
+		if notification.Status != domain.StateFiring || notification.Firing != len(notification.Alerts) {
+			t.Errorf("notification status = %s firing = %d, want firing for all alerts", notification.Status, notification.Firing)
+		}
+	}
+	if counts["api"] != 2 || counts["web"] != 1 {
+		t.Errorf("alerts per group = %v, want api:2 web:1", counts)
+	}
+}
+
+func TestDispatcherDedup(t *testing.T) {
+	sink := newWebhookSink(t)
+	d := newTestDispatcher(t, sink, domain.Channel{})
+	rule := testRule(1, "api")
+
+	// 전송을 기다리는 동안 같은 전환은 한 번만 담김
+	d.Notify(rule, testEvent(rule, domain.StateFiring))
+	d.Notify(rule, testEvent(rule, domain.StateFiring))
+	d.flushAndWait()
+
+	// 전송에 성공한 뒤 중복 제거 시간 동안은 다시 보내지 않고, 다른 상태는 보냄
+	d.Notify(rule, testEvent(rule, domain.StateFiring))
+	d.Notify(rule, testEvent(rule, domain.StateResolved))
+	d.flushAndWait()
+
+	got := sink.received()
+	if len(got) != 2 {
+		t.Fatalf("received %d notifications, want 2", len(got))
+	}
+	if len(got[0].Alerts) != 1 || got[0].Alerts[0].State != domain.StateFiring {
+		t.Errorf("first notification alerts = %+v, want one firing alert", got[0].Alerts)
+	}
+	if len(got[1].Alerts) != 1 || got[1].Alerts[0].State != domain.StateResolved {
+		t.Errorf("second notification alerts = %+v, want one resolved alert", got[1].Alerts)
+	}
+}
+
+// 전송에 실패한 알림은 중복 제거 기록을 남기지 않아 다음 전환 때 다시 전송되어야 함
+func TestDispatcherDedupAfterFailure(t *testing.T) {
+	sink := newWebhookSink(t, http.StatusInternalServerError)
+	d := newTestDispatcher(t, sink, domain.Channel{})
+	d.config.Notification.MaxRetries = 0
+	rule := testRule(1, "api")
+
+	d.Notify(rule, testEvent(rule, domain.StateFiring))
+	d.flushAndWait()
+	if got := sink.received(); len(got) != 0 {
+		t.Fatalf("received %d notifications after failure, want 0", len(got))
+	}
+
+	d.Notify(rule, testEvent(rule, domain.StateFiring))
+	d.flushAndWait()
+	if got := sink.received(); len(got) != 1 {
+		t.Fatalf("received %d notifications after retry, want 1", len(got))
+	}
+}
+
+func TestDispatcherSilences(t *testing.T) {
+	now := time.Now().UnixMilli()
+	tests := []struct {
+		name    string
+		silence domain.Silence
+		want    int
+	}{
+		{
+			name:    "matching",
+			silence: domain.Silence{Matchers: map[string]string{"service": "api"}, StartsAt: now - 60_000, EndsAt: now + 60_000},
+			want:    0,
+		},
+		{
+			name:    "other labels",
+			silence: domain.Silence{Matchers: map[string]string{"service": "web"}, StartsAt: now - 60_000, EndsAt: now + 60_000},
+			want:    1,
+		},
+		{
+			name:    "not started",
+			silence: domain.Silence{Matchers: map[string]string{"service": "api"}, StartsAt: now + 60_000, EndsAt: now + 120_000},
+			want:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newWebhookSink(t)
+			d := newTestDispatcher(t, sink, domain.Channel{}, tt.silence)
+			rule := testRule(1, "api")
+
+			d.Notify(rule, testEvent(rule, domain.StateFiring))
+			d.flushAndWait()
+
+			if got := sink.received(); len(got) != tt.want {
+				t.Errorf("received %d notifications, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcherRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxRetries   int
+		wantAttempts int
+		wantSent     int
+	}{
+		{name: "succeeds after retries", statuses: []int{503, 502}, maxRetries: 3, wantAttempts: 3, wantSent: 1},
+		{name: "retries exhausted", statuses: []int{500, 500, 500, 500}, maxRetries: 2, wantAttempts: 3, wantSent: 0},
+		{name: "no retries", statuses: []int{500}, maxRetries: 0, wantAttempts: 1, wantSent: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newWebhookSink(t, tt.statuses...)
+			d := newTestDispatcher(t, sink, domain.Channel{})
+			d.config.Notification.MaxRetries = tt.maxRetries
+			backoff := time.Duration(d.config.Notification.RetryBackoff) * time.Millisecond
+			rule := testRule(1, "api")
+
+			d.Notify(rule, testEvent(rule, domain.StateFiring))
+			d.flushAndWait()
+
+			sink.mu.Lock()
+			attempts := append([]time.Time(nil), sink.attempts...)
+			sink.mu.Unlock()
+
+			if len(attempts) != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", len(attempts), tt.wantAttempts)
+			}
+			if got := sink.received(); len(got) != tt.wantSent {
+				t.Errorf("received %d notifications, want %d", len(got), tt.wantSent)
+			}
+			// 재시도 간격은 매번 두 배로 늘어남
+			for i := 1; i < len(attempts); i++ {
+				want := backoff << (i - 1)
+				if gap := attempts[i].Sub(attempts[i-1]); gap < want {
+					t.Errorf("gap before attempt %d = %v, want at least %v", i+1, gap, want)
+				}
+			}
+		})
+	}
+}
+
+// 종료하면 재시도 대기를 멈춰야 함
+func TestDispatcherRetryCanceled(t *testing.T) {
+	sink := newWebhookSink(t, 500, 500)
+	d := newTestDispatcher(t, sink, domain.Channel{})
+	d.config.Notification.RetryBackoff = 60_000
+	rule := testRule(1, "api")
+
+	d.Notify(rule, testEvent(rule, domain.StateFiring))
+	d.flush(true)
+	time.Sleep(50 * time.Millisecond)
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry was not canceled")
+	}
+	if len(sink.received()) != 0 {
+		t.Error("notification sent after cancel")
+	}
+}
+
+// 리더 자격을 잃었다가 다시 얻으면 같은 인스턴스를 다시 시작할 수 있어야 함
+func TestDispatcherRestart(t *testing.T) {
+	sink := newWebhookSink(t)
+	d := newTestDispatcher(t, sink, domain.Channel{})
+	d.isRunning = false
+	rule := testRule(1, "api")
+
+	for _, state := range []string{domain.StateFiring, domain.StateResolved} {
+		if err := d.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		d.Notify(rule, testEvent(rule, state))
+		if err := d.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := sink.received(); len(got) != 2 {
+		t.Fatalf("received %d notifications, want 2", len(got))
+	}
+}
@@ -0,0 +1,213 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// Sender는 알림 메시지를 하나의 채널로 전송합니다.
+type Sender interface {
+	Send(ctx context.Context, notification domain.Notification) error
+}
+
+// NewSender는 채널 종류에 맞는 Sender를 생성합니다.
+// 웹훅, Slack 채널의 URL이 허용하지 않은 대상이면 ErrTargetNotAllowed를 반환합니다.
+func NewSender(channel domain.Channel, cfg *config.Config) (Sender, error) {
+	if channel.Type == domain.ChannelTypeWebhook || channel.Type == domain.ChannelTypeSlack {
+		if err := CheckURL(channel.URL, cfg); err != nil {
+			return nil, err
+		}
+	}
+	client := newHTTPClient(cfg)
+
+	switch channel.Type {
+	case domain.ChannelTypeWebhook:
+		return &webhookSender{channel: channel, client: client}, nil
+	case domain.ChannelTypeSlack:
+		return &slackSender{channel: channel, client: client}, nil
+	case domain.ChannelTypeEmail:
+		return &emailSender{
+			channel: channel,
+			host:    cfg.SMTP.Host,
+			port:    cfg.SMTP.Port,
+			user:    cfg.SMTP.Username,
+			pass:    cfg.SMTP.Password,
+			from:    cfg.SMTP.From,
+			timeout: time.Duration(cfg.Notification.Timeout) * time.Second,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported channel type %q", channel.Type)
+}
+
+// webhookSender는 알림을 JSON으로 POST합니다. 템플릿이 있으면 렌더링 결과를 본문으로 보냅니다.
+type webhookSender struct {
+	channel domain.Channel
+	client  *http.Client
+}
+
+func (s *webhookSender) Send(ctx context.Context, notification domain.Notification) error {
+	var body []byte
+	if s.channel.Template != "" {
+		rendered, err := Render(s.channel.Template, "", notification)
+		if err != nil {
+			return err
+		}
+		body = []byte(rendered)
+	} else {
+		data, err := json.Marshal(notification)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification: %w", err)
+		}
+		body = data
+	}
+
+	return postJSON(ctx, s.client, s.channel.URL, s.channel.Headers, body)
+}
+
+// slackSender는 Slack 호환 웹훅 페이로드({"text": ...})를 POST합니다.
+type slackSender struct {
+	channel domain.Channel
+	client  *http.Client
+}
+
+func (s *slackSender) Send(ctx context.Context, notification domain.Notification) error {
+	text, err := Render(s.channel.Template, DefaultTextTemplate, notification)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	return postJSON(ctx, s.client, s.channel.URL, nil, body)
+}
+
+// postJSON은 JSON 본문을 POST하고 2xx가 아닌 응답을 StatusError로 반환합니다.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 연결을 재사용할 수 있도록 본문을 일부 읽고 버림 (응답 내용은 오류에 담지 않음)
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// emailSender는 SMTP로 알림 메일을 보냅니다.
+// 서버가 STARTTLS를 지원하면 사용하고, 사용자 이름이 설정된 경우에만 인증합니다.
+type emailSender struct {
+	channel domain.Channel
+	host    string
+	port    int
+	user    string
+	pass    string
+	from    string
+	timeout time.Duration
+}
+
+func (s *emailSender) Send(ctx context.Context, notification domain.Notification) error {
+	subject, err := Render(subjectTemplate, "", notification)
+	if err != nil {
+		return err
+	}
+	body, err := Render(s.channel.Template, DefaultTextTemplate, notification)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.user != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.user, s.pass, s.host)); err != nil {
+			return fmt.Errorf("failed to authenticate to SMTP server: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("failed to set mail sender: %w", err)
+	}
+	for _, recipient := range s.channel.Recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to add mail recipient %s: %w", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start mail data: %w", err)
+	}
+	if _, err := writer.Write(buildMessage(s.from, s.channel.Recipients, subject, body)); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write mail data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage는 RFC 5322 형식의 텍스트 메일 메시지를 만듭니다.
+func buildMessage(from string, to []string, subject, body string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", strings.ReplaceAll(subject, "\n", " ")) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return buf.Bytes()
+}
@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// ErrTargetNotAllowed는 웹훅 URL이 전송을 허용하지 않은 대상일 때 반환됩니다.
+var ErrTargetNotAllowed = errors.New("notification target is not allowed")
+
+// 공유 주소 공간 (RFC 6598, 통신사 NAT), net.IP.IsPrivate에 포함되지 않음
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// StatusError는 웹훅이 2xx가 아닌 상태 코드를 반환했을 때의 오류입니다.
+// 내부 응답이 호출자에게 노출되지 않도록 응답 본문은 담지 않습니다.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d", e.StatusCode)
+}
+
+// CheckURL은 웹훅 URL이 전송을 허용한 대상인지 확인합니다.
+// Notification.AllowedHosts가 있으면 그 호스트와 하위 도메인만 허용하고,
+// Notification.AllowPrivateNetworks가 아니면 localhost와 루프백, 사설, 링크 로컬 IP 주소를 거부합니다.
+// 호스트 이름이 내부 주소로 풀리는 경우는 전송할 때 연결 주소로 다시 확인합니다.
+func CheckURL(rawURL string, cfg *config.Config) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrTargetNotAllowed, err)
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+
+	if allowed := cfg.Notification.AllowedHosts; len(allowed) > 0 && !hostAllowed(host, allowed) {
+		return fmt.Errorf("%w: host %q is not in the allowed hosts", ErrTargetNotAllowed, host)
+	}
+
+	if !cfg.Notification.AllowPrivateNetworks {
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return fmt.Errorf("%w: %s is a loopback host", ErrTargetNotAllowed, host)
+		}
+		if ip := net.ParseIP(host); ip != nil && internalIP(ip) {
+			return fmt.Errorf("%w: %s is a private or link-local address", ErrTargetNotAllowed, host)
+		}
+	}
+
+	return nil
+}
+
+// hostAllowed는 host가 allowed 항목과 같거나 그 하위 도메인인지 확인합니다.
+func hostAllowed(host string, allowed []string) bool {
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(entry), "."))
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// internalIP는 외부 웹훅 대상이 될 수 없는 루프백, 사설, 링크 로컬(클라우드 메타데이터 포함), 미지정, 멀티캐스트 주소인지 확인합니다.
+func internalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// newHTTPClient는 웹훅 전송용 HTTP 클라이언트를 생성합니다.
+// 사설망 전송을 허용하지 않으면 DNS 응답이나 리디렉션으로 내부 주소에 연결하지 않도록 실제 연결 주소를 확인하고,
+// 리디렉션 대상도 CheckURL로 확인합니다.
+func newHTTPClient(cfg *config.Config) *http.Client {
+	timeout := time.Duration(cfg.Notification.Timeout) * time.Second
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.Notification.AllowPrivateNetworks {
+		dialer := &net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || internalIP(ip) {
+					return fmt.Errorf("%w: %s is a private or link-local address", ErrTargetNotAllowed, host)
+				}
+				return nil
+			},
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return CheckURL(req.URL.String(), cfg)
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		allowedHosts []string
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{name: "public host", url: "https://hooks.slack.com/services/x"},
+		{name: "public ip", url: "http://8.8.8.8/hook"},
+		{name: "localhost", url: "http://localhost:8080/hook", wantErr: true},
+		{name: "localhost subdomain", url: "http://api.localhost/hook", wantErr: true},
+		{name: "loopback", url: "http://127.0.0.1/hook", wantErr: true},
+		{name: "loopback v6", url: "http://[::1]/hook", wantErr: true},
+		{name: "private", url: "http://10.0.0.5/hook", wantErr: true},
+		{name: "private 192.168", url: "http://192.168.1.1/hook", wantErr: true},
+		{name: "metadata", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "unspecified", url: "http://0.0.0.0/hook", wantErr: true},
+		{name: "shared address space", url: "http://100.64.0.1/hook", wantErr: true},
+		{name: "unique local v6", url: "http://[fd00::1]/hook", wantErr: true},
+		{name: "private allowed", url: "http://10.0.0.5/hook", allowPrivate: true},
+		{name: "allowlisted host", url: "https://hooks.slack.com/x", allowedHosts: []string{"hooks.slack.com"}},
+		{name: "allowlisted subdomain", url: "https://a.example.com/x", allowedHosts: []string{"example.com"}},
+		{name: "allowlist case and dot", url: "https://A.Example.COM./x", allowedHosts: []string{"example.com."}},
+		{name: "not allowlisted", url: "https://evil.com/x", allowedHosts: []string{"example.com"}, wantErr: true},
+		{name: "suffix without dot", url: "https://badexample.com/x", allowedHosts: []string{"example.com"}, wantErr: true},
+		{name: "allowlisted private still blocked", url: "http://10.0.0.5/x", allowedHosts: []string{"10.0.0.5"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Notification.AllowedHosts = tt.allowedHosts
+			cfg.Notification.AllowPrivateNetworks = tt.allowPrivate
+
+			err := CheckURL(tt.url, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrTargetNotAllowed) {
+				t.Fatalf("CheckURL(%q) error = %v, want ErrTargetNotAllowed", tt.url, err)
+			}
+		})
+	}
+}
+
+// 호스트 이름 검사를 통과해도 실제 연결 주소가 내부 주소이면 연결하지 않아야 함
+func TestHTTPClientBlocksInternalDial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Notification.Timeout = 5
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	_, err := newHTTPClient(cfg).Do(req)
+	if !errors.Is(err, ErrTargetNotAllowed) {
+		t.Fatalf("Do() error = %v, want ErrTargetNotAllowed", err)
+	}
+
+	cfg.Notification.AllowPrivateNetworks = true
+	resp, err := newHTTPClient(cfg).Do(req)
+	if err != nil {
+		t.Fatalf("Do() with private networks allowed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+// 리디렉션 대상도 CheckURL로 확인해야 함
+func TestHTTPClientChecksRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Notification.Timeout = 5
+	cfg.Notification.AllowPrivateNetworks = true
+	cfg.Notification.AllowedHosts = []string{"127.0.0.1"}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	_, err := newHTTPClient(cfg).Do(req)
+	if !errors.Is(err, ErrTargetNotAllowed) {
+		t.Fatalf("Do() error = %v, want ErrTargetNotAllowed", err)
+	}
+}
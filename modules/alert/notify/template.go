@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+)
+
+// DefaultTextTemplate은 Slack, 이메일 본문에 사용하는 기본 템플릿입니다.
+const DefaultTextTemplate = `[{{ upper .Status }}{{ if .Firing }}:{{ .Firing }}{{ end }}]{{ range $name, $value := .GroupLabels }} {{ $name }}={{ $value }}{{ end }}
+{{ range .Alerts }}- [{{ upper .State }}] {{ .RuleName }} (값 {{ printf "%.2f" .Value }}, 임계값 {{ printf "%.2f" .Threshold }}, {{ formatTime .Timestamp }})
+  {{ .Message }}
+{{ end }}`
+
+// 이메일 제목 템플릿
+const subjectTemplate = `[{{ upper .Status }}{{ if .Firing }}:{{ .Firing }}{{ end }}] {{ range $i, $alert := .Alerts }}{{ if $i }}, {{ end }}{{ $alert.RuleName }}{{ end }}`
+
+// templateFuncs는 알림 템플릿에서 사용할 수 있는 함수입니다.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+	"formatTime": func(timestamp int64) string {
+		return time.UnixMilli(timestamp).UTC().Format(time.RFC3339)
+	},
+}
+
+// ParseTemplate은 알림 템플릿을 파싱합니다. 채널 등록 시 템플릿 검증에도 사용합니다.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("notification").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render는 템플릿에 알림 데이터를 적용합니다. text가 비어 있으면 fallback 템플릿을 사용합니다.
+func Render(text, fallback string, notification domain.Notification) (string, error) {
+	if text == "" {
+		text = fallback
+	}
+
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notification); err != nil {
+		return "", fmt.Errorf("failed to render notification template: %w", err)
+	}
+	return buf.String(), nil
+}
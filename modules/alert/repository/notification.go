@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+// 채널 조회 컬럼 (scanChannel과 순서를 맞춰야 함)
+const channelColumns = `id, name, channel_type, COALESCE(url, ''), headers, recipients, matchers, group_by,
+	COALESCE(template, ''), enabled, created_at, updated_at`
+
+// CreateChannel은 알림 채널을 저장하고 생성된 ID를 channel에 채웁니다.
+func (r *PostgresAlertRepository) CreateChannel(channel *domain.Channel) error {
+	columns, err := channelJSONColumns(channel)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(
+		`INSERT INTO notification_channels(
+			name, channel_type, url, headers, recipients, matchers, group_by, template, enabled, created_at, updated_at, tenant_id
+		) VALUES($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12)
+		RETURNING id`,
+		channel.Name,
+		string(channel.Type),
+		channel.URL,
+		columns[0],
+		columns[1],
+		columns[2],
+		columns[3],
+		channel.Template,
+		channel.Enabled,
+		channel.CreatedAt,
+		channel.UpdatedAt,
+		tenant.OrDefault(channel.TenantID),
+	).Scan(&channel.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert notification channel: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateChannel은 알림 채널을 수정합니다. 채널이 없으면 domain.ErrChannelNotFound를 반환합니다.
+func (r *PostgresAlertRepository) UpdateChannel(channel *domain.Channel) error {
+	columns, err := channelJSONColumns(channel)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Execute(
+		`UPDATE notification_channels SET
+			name = $2, channel_type = $3, url = NULLIF($4, ''), headers = $5, recipients = $6,
+			matchers = $7, group_by = $8, template = NULLIF($9, ''), enabled = $10, updated_at = $11
+		WHERE id = $1 AND tenant_id = $12`,
+		channel.ID,
+		channel.Name,
+		string(channel.Type),
+		channel.URL,
+		columns[0],
+		columns[1],
+		columns[2],
+		columns[3],
+		channel.Template,
+		channel.Enabled,
+		channel.UpdatedAt,
+		tenant.OrDefault(channel.TenantID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrChannelNotFound
+	}
+
+	return nil
+}
+
+// DeleteChannel은 알림 채널을 삭제합니다.
+func (r *PostgresAlertRepository) DeleteChannel(tenantID string, id int64) error {
+	result, err := r.db.Execute(`DELETE FROM notification_channels WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrChannelNotFound
+	}
+
+	return nil
+}
+
+// GetChannel은 알림 채널을 조회합니다.
+func (r *PostgresAlertRepository) GetChannel(tenantID string, id int64) (*domain.Channel, error) {
+	row := r.db.QueryRow(`SELECT `+channelColumns+` FROM notification_channels WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+
+	channel, err := r.scanChannel(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// ListChannels는 테넌트의 알림 채널을 ID 순으로 조회합니다.
+func (r *PostgresAlertRepository) ListChannels(tenantID string) ([]domain.Channel, error) {
+	rows, err := r.db.Query(`SELECT `+channelColumns+` FROM notification_channels WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification channels: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []domain.Channel{}
+	for rows.Next() {
+		channel, err := r.scanChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification channel row: %w", err)
+		}
+		channels = append(channels, *channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification channel rows: %w", err)
+	}
+
+	return channels, nil
+}
+
+// CreateSilence는 사일런스를 저장하고 생성된 ID를 silence에 채웁니다.
+func (r *PostgresAlertRepository) CreateSilence(silence *domain.Silence) error {
+	matchers, err := json.Marshal(silence.Matchers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal silence matchers: %w", err)
+	}
+
+	err = r.db.QueryRow(
+		`INSERT INTO alert_silences(matchers, starts_at, ends_at, comment, created_by, created_at, tenant_id)
+		VALUES($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		RETURNING id`,
+		string(matchers),
+		silence.StartsAt,
+		silence.EndsAt,
+		silence.Comment,
+		silence.CreatedBy,
+		silence.CreatedAt,
+		tenant.OrDefault(silence.TenantID),
+	).Scan(&silence.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert silence: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSilence는 사일런스를 삭제합니다.
+func (r *PostgresAlertRepository) DeleteSilence(tenantID string, id int64) error {
+	result, err := r.db.Execute(`DELETE FROM alert_silences WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete silence: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrSilenceNotFound
+	}
+
+	return nil
+}
+
+// ListSilences는 테넌트의 종료 시간이 since 이후인 사일런스를 시작 시간 순으로 조회합니다.
+func (r *PostgresAlertRepository) ListSilences(tenantID string, since int64) ([]domain.Silence, error) {
+	rows, err := r.db.Query(`
+		SELECT id, matchers, starts_at, ends_at, COALESCE(comment, ''), COALESCE(created_by, ''), created_at
+		FROM alert_silences
+		WHERE ends_at > $1 AND tenant_id = $2
+		ORDER BY starts_at, id
+	`, since, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query silences: %w", err)
+	}
+	defer rows.Close()
+
+	silences := []domain.Silence{}
+	for rows.Next() {
+		var silence domain.Silence
+		var matchersJSON sql.NullString
+
+		if err := rows.Scan(
+			&silence.ID,
+			&matchersJSON,
+			&silence.StartsAt,
+			&silence.EndsAt,
+			&silence.Comment,
+			&silence.CreatedBy,
+			&silence.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan silence row: %w", err)
+		}
+		r.parseJSONColumn(matchersJSON, &silence.Matchers)
+
+		silences = append(silences, silence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating silence rows: %w", err)
+	}
+
+	return silences, nil
+}
+
+// scanChannel은 channelColumns 순서로 조회한 행을 채널로 변환합니다.
+func (r *PostgresAlertRepository) scanChannel(row scanner) (*domain.Channel, error) {
+	var channel domain.Channel
+	var channelType string
+	var headersJSON, recipientsJSON, matchersJSON, groupByJSON sql.NullString
+
+	if err := row.Scan(
+		&channel.ID,
+		&channel.Name,
+		&channelType,
+		&channel.URL,
+		&headersJSON,
+		&recipientsJSON,
+		&matchersJSON,
+		&groupByJSON,
+		&channel.Template,
+		&channel.Enabled,
+		&channel.CreatedAt,
+		&channel.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	channel.Type = domain.ChannelType(channelType)
+	r.parseJSONColumn(headersJSON, &channel.Headers)
+	r.parseJSONColumn(recipientsJSON, &channel.Recipients)
+	r.parseJSONColumn(matchersJSON, &channel.Matchers)
+	r.parseJSONColumn(groupByJSON, &channel.GroupBy)
+
+	return &channel, nil
+}
+
+// parseJSONColumn은 JSONB 컬럼 값을 dest로 변환합니다. NULL이면 dest를 그대로 둡니다.
+func (r *PostgresAlertRepository) parseJSONColumn(column sql.NullString, dest interface{}) {
+	if !column.Valid || column.String == "" {
+		return
+	}
+	if err := json.Unmarshal([]byte(column.String), dest); err != nil {
+		r.log.Error().Err(err).Msg("failed to parse JSON column")
+	}
+}
+
+// channelJSONColumns는 채널의 headers, recipients, matchers, group_by 컬럼 값을 만듭니다. 비어 있으면 NULL을 저장합니다.
+func channelJSONColumns(channel *domain.Channel) ([4]interface{}, error) {
+	var columns [4]interface{}
+	values := []struct {
+		value interface{}
+		empty bool
+	}{
+		{channel.Headers, len(channel.Headers) == 0},
+		{channel.Recipients, len(channel.Recipients) == 0},
+		{channel.Matchers, len(channel.Matchers) == 0},
+		{channel.GroupBy, len(channel.GroupBy) == 0},
+	}
+
+	for i, v := range values {
+		if v.empty {
+			continue
+		}
+		data, err := json.Marshal(v.value)
+		if err != nil {
+			return columns, fmt.Errorf("failed to marshal notification channel column: %w", err)
+		}
+		columns[i] = string(data)
+	}
+
+	return columns, nil
+}
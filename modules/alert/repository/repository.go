@@ -0,0 +1,415 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+// AlertRepository는 알림 규칙/상태/이력 저장소 인터페이스입니다.
+// 규칙, 채널, 사일런스는 테넌트별로 분리되며, 저장 시에는 각 항목의 TenantID를 사용합니다.
+type AlertRepository interface {
+	// 알림 규칙 관리 (ListRules의 tenantID가 비어 있으면 모든 테넌트)
+	CreateRule(rule *domain.Rule) error
+	UpdateRule(rule *domain.Rule) error
+	DeleteRule(tenantID string, id int64) error
+	GetRule(tenantID string, id int64) (*domain.Rule, error)
+	ListRules(tenantID string) ([]domain.Rule, error)
+
+	// 규칙별 현재 알림 상태 (tenantID가 비어 있으면 모든 테넌트)
+	GetAlerts(tenantID string) ([]domain.Alert, error)
+	SaveAlert(alert domain.Alert) error
+
+	// 상태 전환 이력
+	AddAlertEvent(event domain.AlertEvent) error
+	GetAlertHistory(filter domain.AlertHistoryFilter) ([]domain.AlertEvent, error)
+
+	// 알림 채널 관리
+	CreateChannel(channel *domain.Channel) error
+	UpdateChannel(channel *domain.Channel) error
+	DeleteChannel(tenantID string, id int64) error
+	GetChannel(tenantID string, id int64) (*domain.Channel, error)
+	ListChannels(tenantID string) ([]domain.Channel, error)
+
+	// 사일런스 관리 (since 이후에 끝나는 사일런스 조회)
+	CreateSilence(silence *domain.Silence) error
+	DeleteSilence(tenantID string, id int64) error
+	ListSilences(tenantID string, since int64) ([]domain.Silence, error)
+}
+
+// PostgresAlertRepository는 PostgreSQL 알림 저장소 구현체입니다.
+type PostgresAlertRepository struct {
+	db  db.Database
+	log logger.Logger
+}
+
+// NewAlertRepository는 새 알림 저장소 인스턴스를 생성합니다.
+func NewAlertRepository(database db.Database) AlertRepository {
+	return &PostgresAlertRepository{
+		db:  database,
+		log: logger.GetLogger(),
+	}
+}
+
+// 규칙 조회 컬럼 (scanRule과 순서를 맞춰야 함)
+const ruleColumns = `id, name, COALESCE(description, ''), rule_type, COALESCE(service_name, ''),
+	COALESCE(percentile, 0), COALESCE(severity, ''), COALESCE(query, ''), operator, threshold,
+	window_seconds, for_seconds, labels, enabled, created_at, updated_at, tenant_id`
+
+// CreateRule은 알림 규칙을 저장하고 생성된 ID를 rule에 채웁니다.
+func (r *PostgresAlertRepository) CreateRule(rule *domain.Rule) error {
+	labels, err := labelsToJSON(rule.Labels)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(
+		`INSERT INTO alert_rules(
+			name, description, rule_type, service_name, percentile, severity, query,
+			operator, threshold, window_seconds, for_seconds, labels, enabled, created_at, updated_at, tenant_id
+		) VALUES($1, NULLIF($2, ''), $3, NULLIF($4, ''), NULLIF($5, 0), NULLIF($6, ''), NULLIF($7, ''),
+			$8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id`,
+		rule.Name,
+		rule.Description,
+		string(rule.Type),
+		rule.ServiceName,
+		rule.Percentile,
+		rule.Severity,
+		rule.Query,
+		rule.Operator,
+		rule.Threshold,
+		rule.Window,
+		rule.For,
+		labels,
+		rule.Enabled,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+		tenant.OrDefault(rule.TenantID),
+	).Scan(&rule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert alert rule: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRule은 알림 규칙을 수정합니다. 규칙이 없으면 domain.ErrRuleNotFound를 반환합니다.
+func (r *PostgresAlertRepository) UpdateRule(rule *domain.Rule) error {
+	labels, err := labelsToJSON(rule.Labels)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Execute(
+		`UPDATE alert_rules SET
+			name = $2, description = NULLIF($3, ''), rule_type = $4, service_name = NULLIF($5, ''),
+			percentile = NULLIF($6, 0), severity = NULLIF($7, ''), query = NULLIF($8, ''),
+			operator = $9, threshold = $10, window_seconds = $11, for_seconds = $12,
+			labels = $13, enabled = $14, updated_at = $15
+		WHERE id = $1 AND tenant_id = $16`,
+		rule.ID,
+		rule.Name,
+		rule.Description,
+		string(rule.Type),
+		rule.ServiceName,
+		rule.Percentile,
+		rule.Severity,
+		rule.Query,
+		rule.Operator,
+		rule.Threshold,
+		rule.Window,
+		rule.For,
+		labels,
+		rule.Enabled,
+		rule.UpdatedAt,
+		tenant.OrDefault(rule.TenantID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRule은 알림 규칙을 삭제합니다. 현재 상태는 함께 삭제되고 이력은 유지됩니다.
+func (r *PostgresAlertRepository) DeleteRule(tenantID string, id int64) error {
+	result, err := r.db.Execute(`DELETE FROM alert_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// GetRule은 알림 규칙을 조회합니다.
+func (r *PostgresAlertRepository) GetRule(tenantID string, id int64) (*domain.Rule, error) {
+	row := r.db.QueryRow(`SELECT `+ruleColumns+` FROM alert_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+
+	rule, err := r.scanRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// ListRules는 테넌트의 알림 규칙(tenantID가 비어 있으면 모든 테넌트의 규칙)을 ID 순으로 조회합니다.
+func (r *PostgresAlertRepository) ListRules(tenantID string) ([]domain.Rule, error) {
+	rows, err := r.db.Query(`SELECT `+ruleColumns+` FROM alert_rules WHERE ($1 = '' OR tenant_id = $1) ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []domain.Rule{}
+	for rows.Next() {
+		rule, err := r.scanRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert rule row: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alert rule rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+// GetAlerts는 테넌트(tenantID가 비어 있으면 모든 테넌트) 규칙별 현재 알림 상태를 조회합니다.
+func (r *PostgresAlertRepository) GetAlerts(tenantID string) ([]domain.Alert, error) {
+	rows, err := r.db.Query(`
+		SELECT
+			s.rule_id, ar.name, s.state, s.value, ar.threshold, ar.labels,
+			COALESCE(s.active_since, 0), COALESCE(s.fired_at, 0), s.last_evaluated
+		FROM alert_states s
+		JOIN alert_rules ar ON ar.id = s.rule_id
+		WHERE ($1 = '' OR ar.tenant_id = $1)
+		ORDER BY s.rule_id
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert states: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []domain.Alert{}
+	for rows.Next() {
+		var alert domain.Alert
+		var labelsJSON sql.NullString
+
+		if err := rows.Scan(
+			&alert.RuleID,
+			&alert.RuleName,
+			&alert.State,
+			&alert.Value,
+			&alert.Threshold,
+			&labelsJSON,
+			&alert.ActiveSince,
+			&alert.FiredAt,
+			&alert.LastEvaluated,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan alert state row: %w", err)
+		}
+		alert.Labels = r.parseLabels(labelsJSON)
+
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alert state rows: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// SaveAlert는 규칙의 현재 알림 상태를 저장합니다.
+func (r *PostgresAlertRepository) SaveAlert(alert domain.Alert) error {
+	_, err := r.db.Execute(
+		`INSERT INTO alert_states(rule_id, state, value, active_since, fired_at, last_evaluated)
+		VALUES($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0), $6)
+		ON CONFLICT (rule_id) DO UPDATE SET
+			state = EXCLUDED.state,
+			value = EXCLUDED.value,
+			active_since = EXCLUDED.active_since,
+			fired_at = EXCLUDED.fired_at,
+			last_evaluated = EXCLUDED.last_evaluated`,
+		alert.RuleID,
+		alert.State,
+		alert.Value,
+		alert.ActiveSince,
+		alert.FiredAt,
+		alert.LastEvaluated,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert alert state: %w", err)
+	}
+
+	return nil
+}
+
+// AddAlertEvent는 알림 상태 전환 이력을 저장합니다.
+func (r *PostgresAlertRepository) AddAlertEvent(event domain.AlertEvent) error {
+	_, err := r.db.Execute(
+		`INSERT INTO alert_history(rule_id, rule_name, state, value, threshold, message, timestamp, tenant_id)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+		event.RuleID,
+		event.RuleName,
+		event.State,
+		event.Value,
+		event.Threshold,
+		event.Message,
+		event.Timestamp,
+		tenant.OrDefault(event.TenantID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert alert event: %w", err)
+	}
+
+	return nil
+}
+
+// GetAlertHistory는 조건에 맞는 알림 상태 전환 이력을 최신순으로 조회합니다.
+func (r *PostgresAlertRepository) GetAlertHistory(filter domain.AlertHistoryFilter) ([]domain.AlertEvent, error) {
+	// 쿼리 파라미터 배열
+	queryParams := []interface{}{filter.TenantID, filter.StartTime, filter.EndTime}
+	paramIndex := 4
+
+	// 기본 WHERE 조건
+	conditions := []string{"tenant_id = $1", "timestamp >= $2", "timestamp <= $3"}
+
+	if filter.RuleID != 0 {
+		conditions = append(conditions, fmt.Sprintf("rule_id = $%d", paramIndex))
+		queryParams = append(queryParams, filter.RuleID)
+		paramIndex++
+	}
+
+	if filter.State != "" {
+		conditions = append(conditions, fmt.Sprintf("state = $%d", paramIndex))
+		queryParams = append(queryParams, filter.State)
+		paramIndex++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, rule_id, rule_name, state, value, threshold, COALESCE(message, ''), timestamp
+		FROM alert_history
+		WHERE %s
+		ORDER BY timestamp DESC, id DESC
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), paramIndex)
+	queryParams = append(queryParams, filter.Limit)
+
+	rows, err := r.db.Query(query, queryParams...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert history: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.AlertEvent{}
+	for rows.Next() {
+		var event domain.AlertEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.RuleID,
+			&event.RuleName,
+			&event.State,
+			&event.Value,
+			&event.Threshold,
+			&event.Message,
+			&event.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan alert history row: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alert history rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// scanner는 *sql.Row와 *sql.Rows의 공통 인터페이스입니다.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRule은 ruleColumns 순서로 조회한 행을 규칙으로 변환합니다.
+func (r *PostgresAlertRepository) scanRule(row scanner) (*domain.Rule, error) {
+	var rule domain.Rule
+	var ruleType string
+	var labelsJSON sql.NullString
+
+	if err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Description,
+		&ruleType,
+		&rule.ServiceName,
+		&rule.Percentile,
+		&rule.Severity,
+		&rule.Query,
+		&rule.Operator,
+		&rule.Threshold,
+		&rule.Window,
+		&rule.For,
+		&labelsJSON,
+		&rule.Enabled,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+		&rule.TenantID,
+	); err != nil {
+		return nil, err
+	}
+	rule.Type = domain.RuleType(ruleType)
+	rule.Labels = r.parseLabels(labelsJSON)
+
+	return &rule, nil
+}
+
+// parseLabels는 JSONB 라벨 컬럼을 맵으로 변환합니다.
+func (r *PostgresAlertRepository) parseLabels(labelsJSON sql.NullString) map[string]string {
+	if !labelsJSON.Valid || labelsJSON.String == "" {
+		return nil
+	}
+
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(labelsJSON.String), &labels); err != nil {
+		r.log.Error().Err(err).Msg("failed to parse alert rule labels")
+		return nil
+	}
+	return labels
+}
+
+// labelsToJSON은 라벨 맵을 JSONB 컬럼 값으로 변환합니다. 라벨이 없으면 NULL을 저장합니다.
+func labelsToJSON(labels map[string]string) (interface{}, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert rule labels: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/repository"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
+	traceService "github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
+)
+
+// Evaluator는 알림 규칙을 주기적으로 평가하는 백그라운드 작업입니다.
+type Evaluator interface {
+	// Start는 평가 작업을 시작합니다.
+	Start(ctx context.Context) error
+	// Stop은 평가 작업을 중지합니다.
+	Stop() error
+}
+
+// Notifier는 알림 상태 전환을 외부 채널로 전달합니다.
+type Notifier interface {
+	Notify(rule domain.Rule, event domain.AlertEvent)
+}
+
+// evaluatorImpl은 Evaluator 인터페이스의 구현체입니다.
+type evaluatorImpl struct {
+	repository   repository.AlertRepository
+	traceService traceService.TraceService
+	logService   logService.LogService
+	notifier     Notifier
+	config       *config.Config
+	log          logger.Logger
+	ticker       *time.Ticker
+	stopChan     chan struct{}
+	wg           sync.WaitGroup
+	isRunning    bool
+}
+
+// NewEvaluator는 새 Evaluator 인스턴스를 생성합니다.
+func NewEvaluator(repo repository.AlertRepository, traceService traceService.TraceService, logService logService.LogService, notifier Notifier, config *config.Config) Evaluator {
+	return &evaluatorImpl{
+		repository:   repo,
+		traceService: traceService,
+		logService:   logService,
+		notifier:     notifier,
+		config:       config,
+		log:          logger.GetLogger(),
+		stopChan:     make(chan struct{}),
+		isRunning:    false,
+	}
+}
+
+// Start는 평가 작업을 시작합니다.
+func (e *evaluatorImpl) Start(ctx context.Context) error {
+	if e.isRunning {
+		e.log.Info().Msg("알림 규칙 평가 작업이 이미 실행 중입니다")
+		return nil
+	}
+
+	if !e.config.Alerting.Enabled {
+		e.log.Info().Msg("알림 규칙 평가 작업이 비활성화되어 있습니다")
+		return nil
+	}
+
+	interval := time.Duration(e.config.Alerting.EvaluationInterval) * time.Second
+	e.ticker = time.NewTicker(interval)
+	e.stopChan = make(chan struct{})
+	e.isRunning = true
+
+	e.log.Info().
+		Int("interval_seconds", e.config.Alerting.EvaluationInterval).
+		Msg("알림 규칙 평가 작업 시작")
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		for {
+			select {
+			case <-e.ticker.C:
+				if err := e.evaluate(ctx); err != nil {
+					e.log.Error().Err(err).Msg("알림 규칙 평가 중 오류 발생")
+				}
+			case <-e.stopChan:
+				e.log.Info().Msg("알림 규칙 평가 작업 루프 종료")
+				return
+			case <-ctx.Done():
+				e.log.Info().Msg("컨텍스트 종료로 인한 알림 규칙 평가 작업 루프 종료")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop은 평가 작업을 중지합니다.
+func (e *evaluatorImpl) Stop() error {
+	if !e.isRunning {
+		return nil
+	}
+
+	e.ticker.Stop()
+	close(e.stopChan)
+	// 진행 중인 평가가 끝날 때까지 기다려 다시 시작한 작업과 겹치지 않도록 함
+	e.wg.Wait()
+	e.isRunning = false
+	e.log.Info().Msg("알림 규칙 평가 작업 중지됨")
+	return nil
+}
+
+// evaluate는 모든 테넌트의 규칙을 평가하고 상태 전환을 저장합니다.
+func (e *evaluatorImpl) evaluate(ctx context.Context) error {
+	rules, err := e.repository.ListRules("")
+	if err != nil {
+		return err
+	}
+
+	alerts, err := e.repository.GetAlerts("")
+	if err != nil {
+		return err
+	}
+	current := make(map[int64]domain.Alert, len(alerts))
+	for _, alert := range alerts {
+		current[alert.RuleID] = alert
+	}
+
+	now := time.Now().UnixMilli()
+	for _, rule := range rules {
+		alert, ok := current[rule.ID]
+		if !ok {
+			alert = domain.Alert{RuleID: rule.ID, State: domain.StateInactive}
+		}
+
+		// 비활성화된 규칙은 평가하지 않고, 진행 중인 알림은 해제합니다
+		if !rule.Enabled {
+			if ok && alert.State != domain.StateInactive {
+				next, events := nextAlert(rule, alert, alert.Value, false, now)
+				e.save(rule, next, events)
+			}
+			continue
+		}
+
+		value, err := e.evaluateRule(ctx, rule, now)
+		if err != nil {
+			e.log.Error().Err(err).Int64("rule_id", rule.ID).Str("name", rule.Name).Msg("알림 규칙 평가 실패")
+			continue
+		}
+
+		next, events := nextAlert(rule, alert, value, rule.Matches(value), now)
+		e.save(rule, next, events)
+	}
+
+	return nil
+}
+
+// save는 알림 상태와 상태 전환 이력을 저장하고, 저장된 전환을 알림 채널로 전달합니다.
+func (e *evaluatorImpl) save(rule domain.Rule, alert domain.Alert, events []domain.AlertEvent) {
+	if err := e.repository.SaveAlert(alert); err != nil {
+		e.log.Error().Err(err).Int64("rule_id", alert.RuleID).Msg("알림 상태 저장 실패")
+		return
+	}
+
+	for _, event := range events {
+		if err := e.repository.AddAlertEvent(event); err != nil {
+			e.log.Error().Err(err).Int64("rule_id", event.RuleID).Msg("알림 이력 저장 실패")
+			continue
+		}
+		e.log.Info().
+			Int64("rule_id", event.RuleID).
+			Str("state", event.State).
+			Float64("value", event.Value).
+			Msg(event.Message)
+
+		if e.notifier != nil {
+			e.notifier.Notify(rule, event)
+		}
+	}
+}
+
+// evaluateRule은 규칙 테넌트의 평가 구간 동안의 규칙 지표 값을 계산합니다.
+// 서비스 지표 규칙은 구간 내 요청이 없으면 0으로 평가합니다.
+func (e *evaluatorImpl) evaluateRule(ctx context.Context, rule domain.Rule, now int64) (float64, error) {
+	startTime := now - rule.Window*1000
+
+	switch rule.Type {
+	case domain.RuleTypeErrorRate, domain.RuleTypeLatency:
+		metrics, err := e.traceService.GetServiceMetrics(ctx, rule.TenantID, startTime, now, rule.ServiceName)
+		if err != nil {
+			return 0, err
+		}
+		for _, metric := range metrics {
+			if metric.Name != rule.ServiceName {
+				continue
+			}
+			if rule.Type == domain.RuleTypeErrorRate {
+				return metric.ErrorRate, nil
+			}
+			if rule.Percentile == 95 {
+				return metric.P95Latency, nil
+			}
+			return metric.P99Latency, nil
+		}
+		return 0, nil
+
+	case domain.RuleTypeLogCount:
+		filter := logDomain.LogFilter{
+			TenantID:  rule.TenantID,
+			StartTime: startTime,
+			EndTime:   now,
+			Limit:     1,
+		}
+		if rule.ServiceName != "" {
+			filter.ServiceNames = []string{rule.ServiceName}
+		}
+		if rule.Severity != "" {
+			filter.Severity = &rule.Severity
+		}
+		if rule.Query != "" {
+			filter.Query = &rule.Query
+		}
+
+		result, err := e.logService.QueryLogs(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		return float64(result.Total), nil
+	}
+
+	return 0, fmt.Errorf("unsupported rule type %q", rule.Type)
+}
+
+// nextAlert는 평가 결과에 따라 다음 알림 상태와 발생한 상태 전환 이력을 계산합니다.
+//
+//	inactive -> pending  : 조건을 처음 만족
+//	pending  -> firing   : 조건이 유지 시간(for) 이상 지속 (for가 0이면 즉시)
+//	pending  -> inactive : 유지 시간 전에 조건 해제 (이력 없음)
+//	firing   -> inactive : 조건 해제 (resolved 이력)
+func nextAlert(rule domain.Rule, current domain.Alert, value float64, matched bool, now int64) (domain.Alert, []domain.AlertEvent) {
+	next := current
+	next.RuleID = rule.ID
+	next.RuleName = rule.Name
+	next.Threshold = rule.Threshold
+	next.Labels = rule.Labels
+	next.Value = value
+	next.LastEvaluated = now
+
+	var events []domain.AlertEvent
+	newEvent := func(state string) domain.AlertEvent {
+		return domain.AlertEvent{
+			TenantID:  rule.TenantID,
+			RuleID:    rule.ID,
+			RuleName:  rule.Name,
+			State:     state,
+			Value:     value,
+			Threshold: rule.Threshold,
+			Message:   describe(rule, state, value),
+			Timestamp: now,
+		}
+	}
+
+	if !matched {
+		if current.State == domain.StateFiring {
+			events = append(events, newEvent(domain.StateResolved))
+		}
+		next.State = domain.StateInactive
+		next.ActiveSince = 0
+		next.FiredAt = 0
+		return next, events
+	}
+
+	if current.State != domain.StatePending && current.State != domain.StateFiring {
+		next.State = domain.StatePending
+		next.ActiveSince = now
+	}
+
+	if next.State == domain.StatePending && now-next.ActiveSince >= rule.For*1000 {
+		next.State = domain.StateFiring
+		next.FiredAt = now
+		events = append(events, newEvent(domain.StateFiring))
+	} else if current.State != domain.StatePending && next.State == domain.StatePending {
+		events = append(events, newEvent(domain.StatePending))
+	}
+
+	return next, events
+}
+
+// describe는 상태 전환 이력에 남길 메시지를 만듭니다.
+func describe(rule domain.Rule, state string, value float64) string {
+	var metric string
+	switch rule.Type {
+	case domain.RuleTypeErrorRate:
+		metric = fmt.Sprintf("%s 오류율(%%)", rule.ServiceName)
+	case domain.RuleTypeLatency:
+		metric = fmt.Sprintf("%s p%d 지연 시간(ms)", rule.ServiceName, rule.Percentile)
+	case domain.RuleTypeLogCount:
+		metric = "로그 수"
+		if rule.ServiceName != "" {
+			metric = rule.ServiceName + " " + metric
+		}
+	default:
+		metric = string(rule.Type)
+	}
+
+	return fmt.Sprintf("[%s] %s: %s %.2f (조건 %s %.2f, 구간 %d초)",
+		state, rule.Name, metric, value, rule.Operator, rule.Threshold, rule.Window)
+}
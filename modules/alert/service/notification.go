@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/notify"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+var (
+	// ErrInvalidChannel은 알림 채널 정의가 올바르지 않을 때 반환됩니다.
+	ErrInvalidChannel = errors.New("invalid notification channel")
+	// ErrInvalidSilence는 사일런스 정의가 올바르지 않을 때 반환됩니다.
+	ErrInvalidSilence = errors.New("invalid silence")
+)
+
+// CreateChannel은 채널을 검증한 뒤 저장합니다.
+func (s *AlertServiceImpl) CreateChannel(tenantID string, channel domain.Channel) (*domain.Channel, error) {
+	if err := validateChannel(&channel, s.config); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	channel.ID = 0
+	channel.TenantID = tenantID
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+
+	if err := s.repository.CreateChannel(&channel); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Int64("channel_id", channel.ID).Str("name", channel.Name).Str("type", string(channel.Type)).Msg("알림 채널 생성")
+	return &channel, nil
+}
+
+// UpdateChannel은 채널 전체를 새 정의로 교체합니다.
+func (s *AlertServiceImpl) UpdateChannel(tenantID string, id int64, channel domain.Channel) (*domain.Channel, error) {
+	existing, err := s.repository.GetChannel(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateChannel(&channel, s.config); err != nil {
+		return nil, err
+	}
+
+	channel.ID = id
+	channel.TenantID = tenantID
+	channel.CreatedAt = existing.CreatedAt
+	channel.UpdatedAt = time.Now().UnixMilli()
+
+	if err := s.repository.UpdateChannel(&channel); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Int64("channel_id", channel.ID).Str("name", channel.Name).Msg("알림 채널 수정")
+	return &channel, nil
+}
+
+// DeleteChannel은 채널을 삭제합니다.
+func (s *AlertServiceImpl) DeleteChannel(tenantID string, id int64) error {
+	if err := s.repository.DeleteChannel(tenantID, id); err != nil {
+		return err
+	}
+
+	s.log.Info().Int64("channel_id", id).Msg("알림 채널 삭제")
+	return nil
+}
+
+// GetChannel은 채널을 조회합니다.
+func (s *AlertServiceImpl) GetChannel(tenantID string, id int64) (*domain.Channel, error) {
+	return s.repository.GetChannel(tenantID, id)
+}
+
+// ListChannels는 테넌트의 모든 채널을 조회합니다.
+func (s *AlertServiceImpl) ListChannels(tenantID string) ([]domain.Channel, error) {
+	return s.repository.ListChannels(tenantID)
+}
+
+// TestChannel은 채널로 예시 알림을 재시도 없이 한 번 전송합니다.
+func (s *AlertServiceImpl) TestChannel(ctx context.Context, tenantID string, id int64) error {
+	channel, err := s.repository.GetChannel(tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := notify.SendTest(ctx, *channel, s.config); err != nil {
+		s.log.Warn().Err(err).Int64("channel_id", id).Msg("알림 채널 테스트 실패")
+		return err
+	}
+
+	s.log.Info().Int64("channel_id", id).Msg("알림 채널 테스트 전송 완료")
+	return nil
+}
+
+// CreateSilence는 사일런스를 검증한 뒤 저장합니다. 시작 시간이 없으면 현재 시간부터 적용합니다.
+func (s *AlertServiceImpl) CreateSilence(tenantID string, silence domain.Silence) (*domain.Silence, error) {
+	now := time.Now().UnixMilli()
+	if silence.StartsAt == 0 {
+		silence.StartsAt = now
+	}
+	if err := silence.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSilence, err)
+	}
+
+	silence.ID = 0
+	silence.TenantID = tenantID
+	silence.CreatedAt = now
+
+	if err := s.repository.CreateSilence(&silence); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Int64("silence_id", silence.ID).Interface("matchers", silence.Matchers).Msg("사일런스 생성")
+	return &silence, nil
+}
+
+// DeleteSilence는 사일런스를 삭제합니다.
+func (s *AlertServiceImpl) DeleteSilence(tenantID string, id int64) error {
+	if err := s.repository.DeleteSilence(tenantID, id); err != nil {
+		return err
+	}
+
+	s.log.Info().Int64("silence_id", id).Msg("사일런스 삭제")
+	return nil
+}
+
+// ListSilences는 만료되지 않은 사일런스를 조회합니다. activeOnly면 이미 시작된 사일런스만 반환합니다.
+func (s *AlertServiceImpl) ListSilences(tenantID string, activeOnly bool) ([]domain.Silence, error) {
+	now := time.Now().UnixMilli()
+	silences, err := s.repository.ListSilences(tenantID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !activeOnly {
+		return silences, nil
+	}
+
+	active := make([]domain.Silence, 0, len(silences))
+	for _, silence := range silences {
+		if silence.Active(now) {
+			active = append(active, silence)
+		}
+	}
+	return active, nil
+}
+
+// validateChannel은 채널 정의와 메시지 템플릿, 웹훅 URL이 허용한 대상인지 검증합니다.
+func validateChannel(channel *domain.Channel, cfg *config.Config) error {
+	if err := channel.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidChannel, err)
+	}
+	if channel.Type == domain.ChannelTypeWebhook || channel.Type == domain.ChannelTypeSlack {
+		if err := notify.CheckURL(channel.URL, cfg); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidChannel, err)
+		}
+	}
+	if channel.Template != "" {
+		if _, err := notify.ParseTemplate(channel.Template); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidChannel, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/alert/repository"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// ErrInvalidRule은 알림 규칙 정의가 올바르지 않을 때 반환됩니다.
+var ErrInvalidRule = errors.New("invalid alert rule")
+
+// 알림 이력 조회 제한
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 1000
+)
+
+// AlertService는 알림 규칙 관리 및 알림 상태 조회 서비스 인터페이스입니다.
+// 모든 메서드는 tenantID 테넌트의 규칙, 채널, 사일런스만 다룹니다.
+type AlertService interface {
+	// 알림 규칙 관리
+	CreateRule(tenantID string, rule domain.Rule) (*domain.Rule, error)
+	UpdateRule(tenantID string, id int64, rule domain.Rule) (*domain.Rule, error)
+	DeleteRule(tenantID string, id int64) error
+	GetRule(tenantID string, id int64) (*domain.Rule, error)
+	ListRules(tenantID string) ([]domain.Rule, error)
+
+	// 현재 알림 상태 조회 (state가 비어 있으면 inactive를 제외한 전체)
+	GetAlerts(tenantID, state string) ([]domain.Alert, error)
+
+	// 알림 상태 전환 이력 조회
+	GetAlertHistory(tenantID string, filter domain.AlertHistoryFilter) ([]domain.AlertEvent, error)
+
+	// 알림 채널 관리
+	CreateChannel(tenantID string, channel domain.Channel) (*domain.Channel, error)
+	UpdateChannel(tenantID string, id int64, channel domain.Channel) (*domain.Channel, error)
+	DeleteChannel(tenantID string, id int64) error
+	GetChannel(tenantID string, id int64) (*domain.Channel, error)
+	ListChannels(tenantID string) ([]domain.Channel, error)
+	// 채널로 예시 알림을 한 번 전송
+	TestChannel(ctx context.Context, tenantID string, id int64) error
+
+	// 사일런스 관리 (activeOnly면 현재 유효한 사일런스만)
+	CreateSilence(tenantID string, silence domain.Silence) (*domain.Silence, error)
+	DeleteSilence(tenantID string, id int64) error
+	ListSilences(tenantID string, activeOnly bool) ([]domain.Silence, error)
+}
+
+// AlertServiceImpl은 알림 서비스 구현체입니다.
+type AlertServiceImpl struct {
+	repository repository.AlertRepository
+	config     *config.Config
+	log        logger.Logger
+}
+
+// NewAlertService는 새 알림 서비스 인스턴스를 생성합니다.
+func NewAlertService(repo repository.AlertRepository) AlertService {
+	return &AlertServiceImpl{
+		repository: repo,
+		config:     config.GetConfig(),
+		log:        logger.GetLogger(),
+	}
+}
+
+// CreateRule은 규칙을 검증한 뒤 저장합니다.
+func (s *AlertServiceImpl) CreateRule(tenantID string, rule domain.Rule) (*domain.Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	now := time.Now().UnixMilli()
+	rule.ID = 0
+	rule.TenantID = tenantID
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if err := s.repository.CreateRule(&rule); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Int64("rule_id", rule.ID).Str("tenant", tenantID).Str("name", rule.Name).Msg("알림 규칙 생성")
+	return &rule, nil
+}
+
+// UpdateRule은 규칙 전체를 새 정의로 교체합니다. 현재 알림 상태는 유지됩니다.
+func (s *AlertServiceImpl) UpdateRule(tenantID string, id int64, rule domain.Rule) (*domain.Rule, error) {
+	existing, err := s.repository.GetRule(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	rule.ID = id
+	rule.TenantID = tenantID
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now().UnixMilli()
+
+	if err := s.repository.UpdateRule(&rule); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Int64("rule_id", rule.ID).Str("name", rule.Name).Msg("알림 규칙 수정")
+	return &rule, nil
+}
+
+// DeleteRule은 규칙을 삭제합니다.
+func (s *AlertServiceImpl) DeleteRule(tenantID string, id int64) error {
+	if err := s.repository.DeleteRule(tenantID, id); err != nil {
+		return err
+	}
+
+	s.log.Info().Int64("rule_id", id).Msg("알림 규칙 삭제")
+	return nil
+}
+
+// GetRule은 규칙을 조회합니다.
+func (s *AlertServiceImpl) GetRule(tenantID string, id int64) (*domain.Rule, error) {
+	return s.repository.GetRule(tenantID, id)
+}
+
+// ListRules는 테넌트의 모든 규칙을 조회합니다.
+func (s *AlertServiceImpl) ListRules(tenantID string) ([]domain.Rule, error) {
+	return s.repository.ListRules(tenantID)
+}
+
+// GetAlerts는 현재 알림 상태를 조회합니다.
+func (s *AlertServiceImpl) GetAlerts(tenantID, state string) ([]domain.Alert, error) {
+	alerts, err := s.repository.GetAlerts(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]domain.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		if state == "" && alert.State == domain.StateInactive {
+			continue
+		}
+		if state != "" && alert.State != state {
+			continue
+		}
+		filtered = append(filtered, alert)
+	}
+
+	return filtered, nil
+}
+
+// GetAlertHistory는 알림 상태 전환 이력을 조회합니다.
+func (s *AlertServiceImpl) GetAlertHistory(tenantID string, filter domain.AlertHistoryFilter) ([]domain.AlertEvent, error) {
+	filter.TenantID = tenantID
+	now := time.Now().UnixMilli()
+	if filter.EndTime == 0 {
+		filter.EndTime = now
+	}
+	if filter.StartTime == 0 {
+		filter.StartTime = filter.EndTime - 24*3600000 // 기본값: 최근 24시간
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultHistoryLimit
+	}
+	if filter.Limit > maxHistoryLimit {
+		filter.Limit = maxHistoryLimit
+	}
+
+	return s.repository.GetAlertHistory(filter)
+}
@@ -2,19 +2,28 @@ package api
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	alertRepository "github.com/seongpil0948/otel-kafka-pg/modules/alert/repository"
+	alertService "github.com/seongpil0948/otel-kafka-pg/modules/alert/service"
 	"github.com/seongpil0948/otel-kafka-pg/modules/api/router"
+	authRepository "github.com/seongpil0948/otel-kafka-pg/modules/auth/repository"
+	authService "github.com/seongpil0948/otel-kafka-pg/modules/auth/service"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/cache"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/redis"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/stream"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
 	"github.com/seongpil0948/otel-kafka-pg/modules/log/repository"
 	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 	traceRepository "github.com/seongpil0948/otel-kafka-pg/modules/trace/repository"
 	traceService "github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
 
@@ -31,17 +40,26 @@ type Server struct {
 	LogService   logService.LogService
 	cacheService cache.CacheService
 	redisClient  redis.Client
+
+	// 모든 요청 컨텍스트의 부모를 취소합니다. Stop에서 호출해 종료 시간 안에 끝나지 않은 쿼리를 중단
+	cancelBase context.CancelFunc
 }
 
 // NewServer는 새 API 서버 인스턴스를 생성합니다
-func NewServer(cfg *config.Config, log logger.Logger, database db.Database) *Server {
+// traceHub, logHub는 Kafka 컨슈머가 저장한 항목을 tail 스트림으로 전달하는 허브입니다. 컨슈머를 실행하지 않으면 nil이며 tail 요청에 501을 반환합니다.
+// probes에는 데이터베이스와 (캐싱 사용 시) Redis 준비 상태 점검을 추가해 /livez, /readyz로 노출합니다.
+func NewServer(cfg *config.Config, log logger.Logger, database db.Database, traceHub *stream.Hub[traceDomain.TraceItem], logHub *stream.Hub[logDomain.LogItem], probes *health.Probes) *Server {
 	// 저장소 생성
 	logRepo := repository.NewLogRepository(database)
 	traceRepo := traceRepository.NewTraceRepository(database)
+	alertRepo := alertRepository.NewAlertRepository(database)
+	authRepo := authRepository.NewAuthRepository(database)
 
 	// 서비스 생성
 	logSvc := logService.NewLogService(logRepo)
 	traceSvc := traceService.NewTraceService(traceRepo)
+	alertSvc := alertService.NewAlertService(alertRepo)
+	authSvc := authService.NewAuthService(authRepo)
 
 	// 캐시 서비스 초기화
 	var cacheService cache.CacheService
@@ -67,8 +85,17 @@ func NewServer(cfg *config.Config, log logger.Logger, database db.Database) *Ser
 		cacheService, _ = cache.NewCacheService()
 	}
 
+	// 준비 상태 점검 등록
+	probes.Ready.Register("database", health.Ping(database.GetDB().PingContext))
+	if redisClient != nil {
+		probes.Ready.Register("redis", health.Ping(redisClient.Ping))
+	}
+
 	// 라우터 설정 (캐시 서비스 전달)
-	ginRouter := router.SetupRouter(cfg, log, traceSvc, logSvc, cacheService)
+	ginRouter := router.SetupRouter(cfg, log, traceSvc, logSvc, cacheService, traceHub, logHub, alertSvc, authSvc, probes)
+
+	// 요청 컨텍스트의 부모 (종료 시 실행 중인 쿼리 취소용)
+	baseCtx, cancelBase := context.WithCancel(context.Background())
 
 	// HTTP 서버 설정
 	httpServer := &http.Server{
@@ -77,8 +104,21 @@ func NewServer(cfg *config.Config, log logger.Logger, database db.Database) *Ser
 		ReadTimeout:  time.Duration(cfg.API.ReadTimeout) * time.Second,
 		WriteTimeout: time.Duration(cfg.API.WriteTimeout) * time.Second,
 		IdleTimeout:  60 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
 
+	// 종료 시 허브를 닫아 열린 tail 스트림이 Shutdown을 막지 않도록 함
+	httpServer.RegisterOnShutdown(func() {
+		if traceHub != nil {
+			traceHub.Close()
+		}
+		if logHub != nil {
+			logHub.Close()
+		}
+	})
+
 	return &Server{
 		Router:       ginRouter,
 		HttpServer:   httpServer,
@@ -88,6 +128,36 @@ func NewServer(cfg *config.Config, log logger.Logger, database db.Database) *Ser
 		LogService:   logSvc,
 		cacheService: cacheService,
 		redisClient:  redisClient,
+		cancelBase:   cancelBase,
+	}
+}
+
+// NewOpsServer는 API를 제공하지 않는 실행 모드(ingest, cleanup)에서 API 포트로 운영용 엔드포인트만 제공하는 서버를 생성합니다.
+// probes에는 데이터베이스 준비 상태 점검을 추가해 /livez, /readyz로 노출합니다.
+func NewOpsServer(cfg *config.Config, log logger.Logger, database db.Database, probes *health.Probes) *Server {
+	probes.Ready.Register("database", health.Ping(database.GetDB().PingContext))
+
+	ginRouter := router.SetupOpsRouter(cfg, log, probes)
+
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+
+	httpServer := &http.Server{
+		Addr:         ":" + strconv.Itoa(cfg.API.Port),
+		Handler:      ginRouter,
+		ReadTimeout:  time.Duration(cfg.API.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(cfg.API.WriteTimeout) * time.Second,
+		IdleTimeout:  60 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+
+	return &Server{
+		Router:     ginRouter,
+		HttpServer: httpServer,
+		Config:     cfg,
+		Log:        log,
+		cancelBase: cancelBase,
 	}
 }
 
@@ -108,6 +178,10 @@ func (s *Server) Start() error {
 func (s *Server) Stop(ctx context.Context) error {
 	s.Log.Info().Msg("API 서버 정상 종료 중...")
 
+	// Shutdown은 진행 중인 요청이 끝나기를 ctx 기한까지 기다림.
+	// 반환된 뒤에도 남아 있는 요청은 부모 컨텍스트를 취소해 실행 중인 쿼리를 중단
+	defer s.cancelBase()
+
 	// 서버 종료
 	if err := s.HttpServer.Shutdown(ctx); err != nil {
 		s.Log.Error().Err(err).Msg("API 서버 정상 종료 실패")
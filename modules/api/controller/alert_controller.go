@@ -0,0 +1,365 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	alertDomain "github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	alertService "github.com/seongpil0948/otel-kafka-pg/modules/alert/service"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// AlertController는 알림 규칙 및 알림 상태 API 핸들러를 관리합니다
+type AlertController struct {
+	alertService alertService.AlertService
+	logger       logger.Logger
+}
+
+// NewAlertController는 새 알림 컨트롤러를 생성합니다
+func NewAlertController(alertService alertService.AlertService, logger logger.Logger) *AlertController {
+	return &AlertController{
+		alertService: alertService,
+		logger:       logger,
+	}
+}
+
+// ListRules godoc
+//
+//	@Summary		알림 규칙 목록 조회
+//	@Description	등록된 모든 알림 규칙을 조회합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Success		200	{object}	dto.Response{data=[]alertDomain.Rule}
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/rules [get]
+func (c *AlertController) ListRules(ctx *gin.Context) {
+	rules, err := c.alertService.ListRules(requestTenant(ctx))
+	if err != nil {
+		c.logger.Error().Err(err).Msg("알림 규칙 목록 조회 실패")
+		ctx.JSON(http.StatusInternalServerError, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusInternalServerError,
+				Message: "알림 규칙 목록을 가져오는 중 오류가 발생했습니다",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    rules,
+	})
+}
+
+// GetRule godoc
+//
+//	@Summary		알림 규칙 조회
+//	@Description	ID로 알림 규칙을 조회합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		int	true	"규칙 ID"
+//	@Success		200	{object}	dto.Response{data=alertDomain.Rule}
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/rules/{id} [get]
+func (c *AlertController) GetRule(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 규칙 ID입니다")
+	if !ok {
+		return
+	}
+
+	rule, err := c.alertService.GetRule(requestTenant(ctx), id)
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 규칙을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    rule,
+	})
+}
+
+// CreateRule godoc
+//
+//	@Summary		알림 규칙 생성
+//	@Description	새 알림 규칙을 생성합니다. type은 error_rate(서비스 오류율 %), latency(서비스 p95/p99 지연 시간 ms), log_count(조건에 맞는 로그 수) 중 하나입니다
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			rule	body		dto.AlertRuleRequest	true	"알림 규칙"
+//	@Success		201		{object}	dto.Response{data=alertDomain.Rule}
+//	@Failure		400		{object}	dto.Response
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts/rules [post]
+func (c *AlertController) CreateRule(ctx *gin.Context) {
+	var request dto.AlertRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 본문: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	rule, err := c.alertService.CreateRule(requestTenant(ctx), newAlertRule(request))
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 규칙을 생성하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.Response{
+		Success: true,
+		Data:    rule,
+	})
+}
+
+// UpdateRule godoc
+//
+//	@Summary		알림 규칙 수정
+//	@Description	알림 규칙 전체를 새 정의로 교체합니다. 현재 알림 상태는 유지됩니다
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"규칙 ID"
+//	@Param			rule	body		dto.AlertRuleRequest	true	"알림 규칙"
+//	@Success		200		{object}	dto.Response{data=alertDomain.Rule}
+//	@Failure		400		{object}	dto.Response
+//	@Failure		404		{object}	dto.Response
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts/rules/{id} [put]
+func (c *AlertController) UpdateRule(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 규칙 ID입니다")
+	if !ok {
+		return
+	}
+
+	var request dto.AlertRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 본문: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	rule, err := c.alertService.UpdateRule(requestTenant(ctx), id, newAlertRule(request))
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 규칙을 수정하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    rule,
+	})
+}
+
+// DeleteRule godoc
+//
+//	@Summary		알림 규칙 삭제
+//	@Description	알림 규칙과 현재 알림 상태를 삭제합니다. 상태 전환 이력은 유지됩니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		int	true	"규칙 ID"
+//	@Success		200	{object}	dto.Response
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/rules/{id} [delete]
+func (c *AlertController) DeleteRule(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 규칙 ID입니다")
+	if !ok {
+		return
+	}
+
+	if err := c.alertService.DeleteRule(requestTenant(ctx), id); err != nil {
+		c.respondAlertError(ctx, err, "알림 규칙을 삭제하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+	})
+}
+
+// GetAlerts godoc
+//
+//	@Summary		현재 알림 조회
+//	@Description	규칙별 현재 알림 상태를 조회합니다. state를 지정하지 않으면 pending, firing 상태만 반환합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			state	query		string	false	"알림 상태 (inactive, pending, firing)"
+//	@Success		200		{object}	dto.Response{data=[]alertDomain.Alert}
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts [get]
+func (c *AlertController) GetAlerts(ctx *gin.Context) {
+	alerts, err := c.alertService.GetAlerts(requestTenant(ctx), ctx.Query("state"))
+	if err != nil {
+		c.logger.Error().Err(err).Msg("알림 상태 조회 실패")
+		ctx.JSON(http.StatusInternalServerError, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusInternalServerError,
+				Message: "알림 상태를 가져오는 중 오류가 발생했습니다",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    alerts,
+	})
+}
+
+// GetAlertHistory godoc
+//
+//	@Summary		알림 이력 조회
+//	@Description	알림 상태 전환(pending, firing, resolved) 이력을 최신순으로 조회합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			ruleId		query		int		false	"규칙 ID"
+//	@Param			state		query		string	false	"상태 (pending, firing, resolved)"
+//	@Param			startTime	query		int		false	"시작 시간 (밀리초 타임스탬프, 기본값: 24시간 전)"
+//	@Param			endTime		query		int		false	"종료 시간 (밀리초 타임스탬프)"
+//	@Param			limit		query		int		false	"최대 항목 수"	default(100)
+//	@Success		200			{object}	dto.Response{data=[]alertDomain.AlertEvent}
+//	@Failure		400			{object}	dto.Response
+//	@Failure		500			{object}	dto.Response
+//	@Router			/alerts/history [get]
+func (c *AlertController) GetAlertHistory(ctx *gin.Context) {
+	var params dto.AlertHistoryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 매개변수: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	events, err := c.alertService.GetAlertHistory(requestTenant(ctx), alertDomain.AlertHistoryFilter{
+		RuleID:    params.RuleID,
+		State:     params.State,
+		StartTime: params.StartTime,
+		EndTime:   params.EndTime,
+		Limit:     params.Limit,
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Msg("알림 이력 조회 실패")
+		ctx.JSON(http.StatusInternalServerError, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusInternalServerError,
+				Message: "알림 이력을 가져오는 중 오류가 발생했습니다",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    events,
+	})
+}
+
+// respondAlertError는 알림 API 오류를 400/404/500 응답으로 변환합니다.
+func (c *AlertController) respondAlertError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, alertService.ErrInvalidRule),
+		errors.Is(err, alertService.ErrInvalidChannel),
+		errors.Is(err, alertService.ErrInvalidSilence):
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, alertDomain.ErrRuleNotFound),
+		errors.Is(err, alertDomain.ErrChannelNotFound),
+		errors.Is(err, alertDomain.ErrSilenceNotFound):
+		ctx.JSON(http.StatusNotFound, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusNotFound,
+				Message: notFoundMessage(err),
+			},
+		})
+	default:
+		c.logger.Error().Err(err).Msg(message)
+		ctx.JSON(http.StatusInternalServerError, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusInternalServerError,
+				Message: message,
+			},
+		})
+	}
+}
+
+// notFoundMessage는 대상별 404 응답 메시지를 반환합니다.
+func notFoundMessage(err error) string {
+	switch {
+	case errors.Is(err, alertDomain.ErrChannelNotFound):
+		return "알림 채널을 찾을 수 없습니다"
+	case errors.Is(err, alertDomain.ErrSilenceNotFound):
+		return "사일런스를 찾을 수 없습니다"
+	default:
+		return "알림 규칙을 찾을 수 없습니다"
+	}
+}
+
+// parseIDParam은 경로의 ID를 파싱합니다. 잘못된 경우 400 응답을 보내고 false를 반환합니다.
+func parseIDParam(ctx *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: message,
+			},
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// newAlertRule은 요청을 알림 규칙 도메인 객체로 변환합니다. enabled를 생략하면 활성화 상태로 생성합니다.
+func newAlertRule(request dto.AlertRuleRequest) alertDomain.Rule {
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+
+	return alertDomain.Rule{
+		Name:        request.Name,
+		Description: request.Description,
+		Type:        alertDomain.RuleType(request.Type),
+		ServiceName: request.ServiceName,
+		Percentile:  request.Percentile,
+		Severity:    request.Severity,
+		Query:       request.Query,
+		Operator:    request.Operator,
+		Threshold:   request.Threshold,
+		Window:      request.Window,
+		For:         request.For,
+		Labels:      request.Labels,
+		Enabled:     enabled,
+	}
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/middleware"
+	authDomain "github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	authService "github.com/seongpil0948/otel-kafka-pg/modules/auth/service"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// AuthController는 요청 주체 조회 및 API 키 관리 API 핸들러를 관리합니다
+type AuthController struct {
+	authService authService.AuthService
+	logger      logger.Logger
+}
+
+// NewAuthController는 새 인증 컨트롤러를 생성합니다
+func NewAuthController(authService authService.AuthService, logger logger.Logger) *AuthController {
+	return &AuthController{
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+// WhoAmI godoc
+//
+//	@Summary		요청 주체 조회
+//	@Description	현재 자격 증명으로 인증된 주체, 테넌트, 역할을 조회합니다
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	dto.Response{data=authDomain.Principal}
+//	@Failure		401	{object}	dto.Response
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/auth/me [get]
+func (c *AuthController) WhoAmI(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    middleware.RequestPrincipal(ctx),
+	})
+}
+
+// ListKeys godoc
+//
+//	@Summary		API 키 목록 조회
+//	@Description	테넌트에 발급된 API 키 메타데이터를 조회합니다. 키 원문은 포함되지 않습니다
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	dto.Response{data=[]authDomain.APIKey}
+//	@Failure		401	{object}	dto.Response
+//	@Failure		403	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/admin/keys [get]
+func (c *AuthController) ListKeys(ctx *gin.Context) {
+	keys, err := c.authService.ListKeys(requestTenant(ctx))
+	if err != nil {
+		c.respondAuthError(ctx, err, "API 키 목록을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    keys,
+	})
+}
+
+// IssueKey godoc
+//
+//	@Summary		API 키 발급
+//	@Description	새 API 키를 발급합니다. 키 원문은 이 응답에서만 확인할 수 있으며 서버에는 해시만 저장됩니다
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			key	body		dto.APIKeyRequest	true	"API 키 발급 요청"
+//	@Success		201	{object}	dto.Response{data=dto.IssuedAPIKeyResponse}
+//	@Failure		400	{object}	dto.Response
+//	@Failure		401	{object}	dto.Response
+//	@Failure		403	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/admin/keys [post]
+func (c *AuthController) IssueKey(ctx *gin.Context) {
+	var request dto.APIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 본문: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	role := authDomain.RoleViewer
+	if request.Role != "" {
+		role = authDomain.Role(request.Role)
+	}
+
+	createdBy := ""
+	if principal := middleware.RequestPrincipal(ctx); principal != nil {
+		createdBy = principal.Subject
+	}
+
+	plaintext, key, err := c.authService.IssueKey(requestTenant(ctx), request.Name, role, time.Duration(request.ExpiresIn)*time.Second, createdBy)
+	if err != nil {
+		c.respondAuthError(ctx, err, "API 키를 발급하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.Response{
+		Success: true,
+		Data: dto.IssuedAPIKeyResponse{
+			Key:       plaintext,
+			ID:        key.ID,
+			Name:      key.Name,
+			Prefix:    key.Prefix,
+			Role:      string(key.Role),
+			CreatedAt: key.CreatedAt,
+			ExpiresAt: key.ExpiresAt,
+		},
+	})
+}
+
+// RevokeKey godoc
+//
+//	@Summary		API 키 폐기
+//	@Description	API 키를 폐기합니다. 폐기된 키는 즉시 인증에 사용할 수 없습니다
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		int	true	"API 키 ID"
+//	@Success		200	{object}	dto.Response
+//	@Failure		400	{object}	dto.Response
+//	@Failure		401	{object}	dto.Response
+//	@Failure		403	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/admin/keys/{id} [delete]
+func (c *AuthController) RevokeKey(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 API 키 ID입니다")
+	if !ok {
+		return
+	}
+
+	if err := c.authService.RevokeKey(requestTenant(ctx), id); err != nil {
+		c.respondAuthError(ctx, err, "API 키를 폐기하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+	})
+}
+
+// respondAuthError는 API 키 관리 오류를 400/404/500 응답으로 변환합니다.
+func (c *AuthController) respondAuthError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, authService.ErrInvalidKey):
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, authDomain.ErrKeyNotFound):
+		ctx.JSON(http.StatusNotFound, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusNotFound,
+				Message: "API 키를 찾을 수 없습니다",
+			},
+		})
+	default:
+		c.logger.Error().Err(err).Msg(message)
+		ctx.JSON(http.StatusInternalServerError, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusInternalServerError,
+				Message: message,
+			},
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// ConfigController는 실행 중인 설정 조회 API 핸들러를 관리합니다
+type ConfigController struct {
+	logger logger.Logger
+}
+
+// NewConfigController는 새 설정 컨트롤러를 생성합니다
+func NewConfigController(logger logger.Logger) *ConfigController {
+	return &ConfigController{
+		logger: logger,
+	}
+}
+
+// GetConfig godoc
+//
+//	@Summary		실행 중인 설정 조회
+//	@Description	기본값, 설정 파일, 환경 변수와 다시 로드한 값을 모두 반영한 현재 설정을 조회합니다. 비밀번호, API 키 등 비밀 값은 가려집니다
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	dto.Response{data=dto.ConfigResponse}
+//	@Failure		401	{object}	dto.Response
+//	@Failure		403	{object}	dto.Response
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/admin/config [get]
+func (c *ConfigController) GetConfig(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data: dto.ConfigResponse{
+			Config:   config.GetConfig().Masked(),
+			Metadata: config.CurrentMetadata(),
+		},
+	})
+}
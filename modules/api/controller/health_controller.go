@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// HealthController는 활성 상태(/livez), 준비 상태(/readyz) 점검 핸들러를 관리합니다
+type HealthController struct {
+	probes *health.Probes
+	logger logger.Logger
+}
+
+// NewHealthController는 새 상태 점검 컨트롤러를 생성합니다
+func NewHealthController(probes *health.Probes, logger logger.Logger) *HealthController {
+	return &HealthController{
+		probes: probes,
+		logger: logger,
+	}
+}
+
+// Livez는 활성 상태 점검 보고서를 반환합니다. down인 점검이 있으면 503입니다.
+func (c *HealthController) Livez(ctx *gin.Context) {
+	c.respond(ctx, "livez", c.probes.Live.Run(ctx.Request.Context()))
+}
+
+// Readyz는 준비 상태 점검 보고서를 반환합니다. down인 점검이 있으면 503입니다.
+func (c *HealthController) Readyz(ctx *gin.Context) {
+	c.respond(ctx, "readyz", c.probes.Ready.Run(ctx.Request.Context()))
+}
+
+// respond는 점검 보고서를 상태에 맞는 응답 코드로 반환하고, 실패한 점검을 기록합니다.
+func (c *HealthController) respond(ctx *gin.Context, probe string, report health.Report) {
+	if report.Ready() {
+		ctx.JSON(http.StatusOK, report)
+		return
+	}
+
+	for name, result := range report.Checks {
+		if result.Status == health.StatusDown {
+			c.logger.Warn().
+				Str("probe", probe).
+				Str("check", name).
+				Str("message", result.Message).
+				Msg("상태 점검 실패")
+		}
+	}
+	ctx.JSON(http.StatusServiceUnavailable, report)
+}
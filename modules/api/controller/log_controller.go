@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -38,6 +39,8 @@ func NewLogController(logService service.LogService, logger logger.Logger) *LogC
 //	@Param			serviceNames	query		[]string	false	"서비스 이름 목록"
 //	@Param			severity	query		string	false	"심각도 (INFO, WARN, ERROR, FATAL 등)"
 //	@Param			hasTrace	query		boolean	false	"트레이스 ID가 있는 로그만 필터링"
+//	@Param			traceId		query		string	false	"트레이스 ID"
+//	@Param			spanId		query		string	false	"스팬 ID"
 //	@Param			query		query		string	false	"검색어"
 //	@Param			limit		query		int		false	"한 페이지당 항목 수"	default(20)
 //	@Param			offset		query		int		false	"오프셋"			default(0)
@@ -77,35 +80,13 @@ func (c *LogController) QueryLogs(ctx *gin.Context) {
 	}
 
 	// 로그 필터 구성
-	filter := domain.LogFilter{
-		StartTime: params.StartTime,
-		EndTime:   params.EndTime,
-		HasTrace:  params.HasTrace,
-		Limit:     params.Limit,
-		Offset:    params.Offset,
-	}
-
-	if len(params.ServiceNames) > 0 {
-		filter.ServiceNames = params.ServiceNames
-	}
-	if params.Severity != "" {
-		filter.Severity = &params.Severity
-	}
-	if params.Query != "" {
-		filter.Query = &params.Query
-	}
+	filter := newLogFilter(requestTenant(ctx), params)
 
 	// 쿼리 실행
-	result, err := c.logService.QueryLogs(filter)
+	result, err := c.logService.QueryLogs(ctx.Request.Context(), filter)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("로그 쿼리 실패")
-		ctx.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Error: &dto.ErrorInfo{
-				Code:    http.StatusInternalServerError,
-				Message: "로그 조회 중 오류가 발생했습니다",
-			},
-		})
+		respondQueryError(ctx, "로그 조회 중 오류가 발생했습니다")
 		return
 	}
 
@@ -154,6 +135,181 @@ func (c *LogController) QueryLogs(ctx *gin.Context) {
 	})
 }
 
+// newLogFilter는 요청 테넌트와 매개변수로부터 로그 필터를 구성합니다.
+func newLogFilter(tenantID string, params dto.LogFilterParams) domain.LogFilter {
+	filter := domain.LogFilter{
+		TenantID:  tenantID,
+		StartTime: params.StartTime,
+		EndTime:   params.EndTime,
+		HasTrace:  params.HasTrace,
+		TraceID:   params.TraceID,
+		SpanID:    params.SpanID,
+		Limit:     params.Limit,
+		Offset:    params.Offset,
+	}
+
+	if len(params.ServiceNames) > 0 {
+		filter.ServiceNames = params.ServiceNames
+	}
+	if params.Severity != "" {
+		filter.Severity = &params.Severity
+	}
+	if params.Query != "" {
+		filter.Query = &params.Query
+	}
+
+	return filter
+}
+
+// GetLogHistogram godoc
+//
+//	@Summary		로그 볼륨 히스토그램 조회
+//	@Description	로그 목록 조회와 같은 필터 조건으로 시간 버킷별, 심각도별 로그 수를 조회합니다. step=auto인 경우 시간 범위에 맞는 버킷 크기를 선택합니다
+//	@Tags			logs
+//	@Accept			json
+//	@Produce		json
+//	@Param			startTime	query		int		false	"시작 시간 (밀리초 타임스탬프)"
+//	@Param			endTime		query		int		false	"종료 시간 (밀리초 타임스탬프)"
+//	@Param			serviceNames	query		[]string	false	"서비스 이름 목록"
+//	@Param			severity	query		string	false	"심각도 (INFO, WARN, ERROR, FATAL 등)"
+//	@Param			hasTrace	query		boolean	false	"트레이스 ID가 있는 로그만 필터링"
+//	@Param			traceId		query		string	false	"트레이스 ID"
+//	@Param			spanId		query		string	false	"스팬 ID"
+//	@Param			query		query		string	false	"검색어"
+//	@Param			step		query		string	false	"버킷 크기 (auto 또는 30s, 1m, 1h 등)"	default(auto)
+//	@Success		200			{object}	dto.Response{data=domain.LogHistogram}
+//	@Failure		400			{object}	dto.Response
+//	@Failure		500			{object}	dto.Response
+//	@Router			/logs/histogram [get]
+func (c *LogController) GetLogHistogram(ctx *gin.Context) {
+	// 요청 매개변수 파싱
+	var params dto.LogFilterParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 매개변수: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	// 기본 시간 범위 설정 (기본값: 최근 1시간)
+	now := time.Now().UnixMilli()
+	if params.EndTime == 0 {
+		params.EndTime = now
+	}
+	if params.StartTime == 0 {
+		params.StartTime = now - 3600000 // 1시간 전
+	}
+
+	if params.StartTime > params.EndTime {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "시작 시간이 종료 시간보다 늦을 수 없습니다",
+			},
+		})
+		return
+	}
+
+	// 버킷 크기 결정
+	stepStr := ctx.DefaultQuery("step", "auto")
+	var step int64
+	if stepStr == "auto" {
+		step = domain.AutoHistogramStep(params.StartTime, params.EndTime)
+	} else {
+		duration, err := time.ParseDuration(stepStr)
+		if err != nil || duration < time.Second {
+			ctx.JSON(http.StatusBadRequest, dto.Response{
+				Success: false,
+				Error: &dto.ErrorInfo{
+					Code:    http.StatusBadRequest,
+					Message: "step은 auto 또는 1s 이상의 기간이어야 합니다 (예: 30s, 1m, 1h)",
+				},
+			})
+			return
+		}
+		step = duration.Milliseconds()
+	}
+
+	if (params.EndTime-params.StartTime)/step+1 > domain.MaxHistogramBuckets {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("버킷 수가 최대값(%d)을 초과합니다. step을 늘리거나 시간 범위를 줄이세요", domain.MaxHistogramBuckets),
+			},
+		})
+		return
+	}
+
+	// 히스토그램 조회
+	histogram, err := c.logService.GetLogHistogram(ctx.Request.Context(), newLogFilter(requestTenant(ctx), params), step)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("로그 히스토그램 조회 실패")
+		respondQueryError(ctx, "로그 히스토그램을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    histogram,
+	})
+}
+
+// GetLogPatterns godoc
+//
+//	@Summary		로그 패턴 조회
+//	@Description	수집 시 Drain 방식으로 묶인 로그 패턴별 개수, 처음/마지막 발견 시간, 샘플 메시지와 바로 이전 같은 길이 구간 대비 추세를 조회합니다
+//	@Tags			logs
+//	@Accept			json
+//	@Produce		json
+//	@Param			startTime		query		int			false	"시작 시간 (밀리초 타임스탬프)"
+//	@Param			endTime			query		int			false	"종료 시간 (밀리초 타임스탬프)"
+//	@Param			serviceName		query		[]string	false	"서비스 이름 목록"
+//	@Param			severity		query		string		false	"심각도 (INFO, WARN, ERROR, FATAL 등)"
+//	@Param			limit			query		int			false	"최대 패턴 수"	default(50)
+//	@Success		200				{object}	dto.Response{data=domain.LogPatternResult}
+//	@Failure		400				{object}	dto.Response
+//	@Failure		500				{object}	dto.Response
+//	@Router			/logs/patterns [get]
+func (c *LogController) GetLogPatterns(ctx *gin.Context) {
+	// 시간 범위 파싱 (기본값: 최근 1시간)
+	startTime, endTime, ok := parseTimeRange(ctx)
+	if !ok {
+		return
+	}
+
+	filter := domain.LogPatternFilter{
+		TenantID:     requestTenant(ctx),
+		StartTime:    startTime,
+		EndTime:      endTime,
+		ServiceNames: ctx.QueryArray("serviceName"),
+	}
+	if severity := ctx.Query("severity"); severity != "" {
+		filter.Severity = &severity
+	}
+	if limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50")); err == nil {
+		filter.Limit = limit
+	}
+
+	// 패턴 조회
+	result, err := c.logService.GetLogPatterns(ctx.Request.Context(), filter)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("로그 패턴 조회 실패")
+		respondQueryError(ctx, "로그 패턴을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    result,
+	})
+}
+
 // GetLogsByTraceID godoc
 //
 //	@Summary		트레이스 ID로 관련 로그 조회
@@ -161,10 +317,15 @@ func (c *LogController) QueryLogs(ctx *gin.Context) {
 //	@Tags			logs
 //	@Accept			json
 //	@Produce		json
-//	@Param			traceId	path		string	true	"Trace ID"
-//	@Success		200		{object}	dto.Response{data=dto.LogsResponse}
-//	@Failure		400		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Param			traceId		path		string	true	"Trace ID"
+//	@Param			spanId		query		string	false	"스팬 ID (특정 스팬의 로그만 조회)"
+//	@Param			startTime	query		int		false	"시작 시간 (밀리초 타임스탬프)"
+//	@Param			endTime		query		int		false	"종료 시간 (밀리초 타임스탬프)"
+//	@Param			limit		query		int		false	"한 페이지당 항목 수"	default(20)
+//	@Param			offset		query		int		false	"오프셋"			default(0)
+//	@Success		200			{object}	dto.Response{data=dto.LogsResponse}
+//	@Failure		400			{object}	dto.Response
+//	@Failure		500			{object}	dto.Response
 //	@Router			/logs/trace/{traceId} [get]
 func (c *LogController) GetLogsByTraceID(ctx *gin.Context) {
 	traceID := ctx.Param("traceId")
@@ -219,25 +380,20 @@ func (c *LogController) GetLogsByTraceID(ctx *gin.Context) {
 
 	// 필터 설정
 	filter := domain.LogFilter{
+		TenantID:  requestTenant(ctx),
 		StartTime: startTime,
 		EndTime:   endTime,
-		HasTrace:  true,
+		TraceID:   traceID,
+		SpanID:    ctx.Query("spanId"),
 		Limit:     limit,
 		Offset:    offset,
-		Query:     &traceID, // 트레이스 ID로 검색
 	}
 
 	// 쿼리 실행
-	result, err := c.logService.QueryLogs(filter)
+	result, err := c.logService.QueryLogs(ctx.Request.Context(), filter)
 	if err != nil {
 		c.logger.Error().Err(err).Str("traceId", traceID).Msg("트레이스 관련 로그 쿼리 실패")
-		ctx.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Error: &dto.ErrorInfo{
-				Code:    http.StatusInternalServerError,
-				Message: "로그 조회 중 오류가 발생했습니다",
-			},
-		})
+		respondQueryError(ctx, "로그 조회 중 오류가 발생했습니다")
 		return
 	}
 
@@ -302,12 +458,12 @@ func (c *LogController) GetLogSummary(ctx *gin.Context) {
 	var severityAggs []domain.SeverityAggregation
 	var err error
 
-	serviceAggs, err = c.logService.GetServiceAggregation(startTime, endTime)
+	serviceAggs, err = c.logService.GetServiceAggregation(ctx.Request.Context(), requestTenant(ctx), startTime, endTime)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("서비스 집계 실패")
 	}
 
-	severityAggs, err = c.logService.GetSeverityAggregation(startTime, endTime)
+	severityAggs, err = c.logService.GetSeverityAggregation(ctx.Request.Context(), requestTenant(ctx), startTime, endTime)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("심각도 집계 실패")
 	}
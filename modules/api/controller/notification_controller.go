@@ -0,0 +1,370 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	alertDomain "github.com/seongpil0948/otel-kafka-pg/modules/alert/domain"
+	alertNotify "github.com/seongpil0948/otel-kafka-pg/modules/alert/notify"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/middleware"
+	authDomain "github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+)
+
+// ListChannels godoc
+//
+//	@Summary		알림 채널 목록 조회
+//	@Description	등록된 모든 알림 채널을 조회합니다. editor 미만 역할에는 URL과 헤더 값을 가려서 반환합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Success		200	{object}	dto.Response{data=[]alertDomain.Channel}
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/channels [get]
+func (c *AlertController) ListChannels(ctx *gin.Context) {
+	channels, err := c.alertService.ListChannels(requestTenant(ctx))
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 채널 목록을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	if !canViewChannelSecrets(ctx) {
+		for i := range channels {
+			channels[i] = channels[i].Masked()
+		}
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    channels,
+	})
+}
+
+// GetChannel godoc
+//
+//	@Summary		알림 채널 조회
+//	@Description	ID로 알림 채널을 조회합니다. editor 미만 역할에는 URL과 헤더 값을 가려서 반환합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		int	true	"채널 ID"
+//	@Success		200	{object}	dto.Response{data=alertDomain.Channel}
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/channels/{id} [get]
+func (c *AlertController) GetChannel(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 채널 ID입니다")
+	if !ok {
+		return
+	}
+
+	channel, err := c.alertService.GetChannel(requestTenant(ctx), id)
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 채널을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	if !canViewChannelSecrets(ctx) {
+		masked := channel.Masked()
+		channel = &masked
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    channel,
+	})
+}
+
+// CreateChannel godoc
+//
+//	@Summary		알림 채널 생성
+//	@Description	새 알림 채널을 생성합니다. type은 webhook(알림 JSON 또는 템플릿 결과 POST), slack(Slack 호환 웹훅), email(SMTP) 중 하나입니다
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			channel	body		dto.NotificationChannelRequest	true	"알림 채널"
+//	@Success		201		{object}	dto.Response{data=alertDomain.Channel}
+//	@Failure		400		{object}	dto.Response
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts/channels [post]
+func (c *AlertController) CreateChannel(ctx *gin.Context) {
+	var request dto.NotificationChannelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 본문: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	channel, err := c.alertService.CreateChannel(requestTenant(ctx), newNotificationChannel(request))
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 채널을 생성하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.Response{
+		Success: true,
+		Data:    channel,
+	})
+}
+
+// UpdateChannel godoc
+//
+//	@Summary		알림 채널 수정
+//	@Description	알림 채널 전체를 새 정의로 교체합니다
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"채널 ID"
+//	@Param			channel	body		dto.NotificationChannelRequest	true	"알림 채널"
+//	@Success		200		{object}	dto.Response{data=alertDomain.Channel}
+//	@Failure		400		{object}	dto.Response
+//	@Failure		404		{object}	dto.Response
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts/channels/{id} [put]
+func (c *AlertController) UpdateChannel(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 채널 ID입니다")
+	if !ok {
+		return
+	}
+
+	var request dto.NotificationChannelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 본문: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	channel, err := c.alertService.UpdateChannel(requestTenant(ctx), id, newNotificationChannel(request))
+	if err != nil {
+		c.respondAlertError(ctx, err, "알림 채널을 수정하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    channel,
+	})
+}
+
+// DeleteChannel godoc
+//
+//	@Summary		알림 채널 삭제
+//	@Description	알림 채널을 삭제합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		int	true	"채널 ID"
+//	@Success		200	{object}	dto.Response
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/channels/{id} [delete]
+func (c *AlertController) DeleteChannel(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 채널 ID입니다")
+	if !ok {
+		return
+	}
+
+	if err := c.alertService.DeleteChannel(requestTenant(ctx), id); err != nil {
+		c.respondAlertError(ctx, err, "알림 채널을 삭제하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+	})
+}
+
+// TestChannel godoc
+//
+//	@Summary		알림 채널 테스트
+//	@Description	채널로 예시 알림을 재시도 없이 한 번 전송합니다. 전송에 실패하면 502를 반환하며, 응답에는 채널이 반환한 상태 코드만 담습니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		int	true	"채널 ID"
+//	@Success		200	{object}	dto.Response
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		502	{object}	dto.Response
+//	@Router			/alerts/channels/{id}/test [post]
+func (c *AlertController) TestChannel(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 채널 ID입니다")
+	if !ok {
+		return
+	}
+
+	if _, err := c.alertService.GetChannel(requestTenant(ctx), id); err != nil {
+		c.respondAlertError(ctx, err, "알림 채널을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	if err := c.alertService.TestChannel(ctx.Request.Context(), requestTenant(ctx), id); err != nil {
+		// 내부 주소 탐색에 쓰이지 않도록 응답 본문이나 연결 오류 내용은 돌려주지 않음 (서버 로그에만 남김)
+		var statusErr *alertNotify.StatusError
+		switch {
+		case errors.Is(err, alertNotify.ErrTargetNotAllowed):
+			ctx.JSON(http.StatusBadRequest, dto.Response{
+				Success: false,
+				Error: &dto.ErrorInfo{
+					Code:    http.StatusBadRequest,
+					Message: "알림 전송 실패: 허용하지 않은 전송 대상입니다",
+				},
+			})
+		case errors.As(err, &statusErr):
+			ctx.JSON(http.StatusBadGateway, dto.Response{
+				Success: false,
+				Error: &dto.ErrorInfo{
+					Code:    http.StatusBadGateway,
+					Message: fmt.Sprintf("알림 전송 실패: 채널이 상태 코드 %d를 반환했습니다", statusErr.StatusCode),
+				},
+			})
+		default:
+			ctx.JSON(http.StatusBadGateway, dto.Response{
+				Success: false,
+				Error: &dto.ErrorInfo{
+					Code:    http.StatusBadGateway,
+					Message: "알림 전송 실패",
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+	})
+}
+
+// ListSilences godoc
+//
+//	@Summary		사일런스 목록 조회
+//	@Description	만료되지 않은 사일런스를 조회합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			active	query		bool	false	"현재 유효한 사일런스만 조회"
+//	@Success		200		{object}	dto.Response{data=[]alertDomain.Silence}
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts/silences [get]
+func (c *AlertController) ListSilences(ctx *gin.Context) {
+	activeOnly, _ := strconv.ParseBool(ctx.Query("active"))
+
+	silences, err := c.alertService.ListSilences(requestTenant(ctx), activeOnly)
+	if err != nil {
+		c.respondAlertError(ctx, err, "사일런스 목록을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    silences,
+	})
+}
+
+// CreateSilence godoc
+//
+//	@Summary		사일런스 생성
+//	@Description	지정한 기간 동안 matchers가 모두 일치하는 알림의 전송을 막습니다. 알림 상태와 이력은 그대로 기록됩니다
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			silence	body		dto.SilenceRequest	true	"사일런스"
+//	@Success		201		{object}	dto.Response{data=alertDomain.Silence}
+//	@Failure		400		{object}	dto.Response
+//	@Failure		500		{object}	dto.Response
+//	@Router			/alerts/silences [post]
+func (c *AlertController) CreateSilence(ctx *gin.Context) {
+	var request dto.SilenceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "잘못된 요청 본문: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	silence, err := c.alertService.CreateSilence(requestTenant(ctx), alertDomain.Silence{
+		Matchers:  request.Matchers,
+		StartsAt:  request.StartsAt,
+		EndsAt:    request.EndsAt,
+		Comment:   request.Comment,
+		CreatedBy: request.CreatedBy,
+	})
+	if err != nil {
+		c.respondAlertError(ctx, err, "사일런스를 생성하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.Response{
+		Success: true,
+		Data:    silence,
+	})
+}
+
+// DeleteSilence godoc
+//
+//	@Summary		사일런스 삭제
+//	@Description	사일런스를 삭제해 알림 전송을 즉시 재개합니다
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		int	true	"사일런스 ID"
+//	@Success		200	{object}	dto.Response
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Router			/alerts/silences/{id} [delete]
+func (c *AlertController) DeleteSilence(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "잘못된 사일런스 ID입니다")
+	if !ok {
+		return
+	}
+
+	if err := c.alertService.DeleteSilence(requestTenant(ctx), id); err != nil {
+		c.respondAlertError(ctx, err, "사일런스를 삭제하는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+	})
+}
+
+// newNotificationChannel은 요청을 알림 채널 도메인 객체로 변환합니다. enabled를 생략하면 활성화 상태로 생성합니다.
+func newNotificationChannel(request dto.NotificationChannelRequest) alertDomain.Channel {
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+
+	return alertDomain.Channel{
+		Name:       request.Name,
+		Type:       alertDomain.ChannelType(request.Type),
+		URL:        request.URL,
+		Headers:    request.Headers,
+		Recipients: request.Recipients,
+		Matchers:   request.Matchers,
+		GroupBy:    request.GroupBy,
+		Template:   request.Template,
+		Enabled:    enabled,
+	}
+}
+
+// canViewChannelSecrets는 요청 주체가 채널 URL과 헤더 값을 볼 수 있는지 확인합니다.
+// 채널을 수정할 수 있는 editor 이상 역할만 원래 값을 봅니다.
+func canViewChannelSecrets(ctx *gin.Context) bool {
+	principal := middleware.RequestPrincipal(ctx)
+	return principal != nil && principal.Role.Allows(authDomain.RoleEditor)
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+)
+
+// defaultTimeRange는 startTime을 지정하지 않았을 때 endTime부터 거슬러 올라가 조회하는 기본 구간(밀리초)입니다.
+const defaultTimeRange = int64(time.Hour / time.Millisecond)
+
+// statusClientClosedRequest는 응답 전에 클라이언트가 연결을 끊은 요청의 상태 코드입니다(nginx 관례).
+const statusClientClosedRequest = 499
+
+// respondQueryError는 조회 실패를 오류 응답으로 변환합니다.
+// 요청 deadline을 넘겨 쿼리가 취소되었으면 504, 클라이언트가 연결을 끊었으면 본문 없이 중단하고,
+// 그 외에는 message와 함께 500을 반환합니다.
+func respondQueryError(ctx *gin.Context, message string) {
+	switch err := ctx.Request.Context().Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		ctx.JSON(http.StatusGatewayTimeout, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusGatewayTimeout,
+				Message: "조회 시간이 제한을 초과했습니다. 조회 구간을 줄여 다시 시도하세요",
+			},
+		})
+	case errors.Is(err, context.Canceled):
+		ctx.AbortWithStatus(statusClientClosedRequest)
+	default:
+		ctx.JSON(http.StatusInternalServerError, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusInternalServerError,
+				Message: message,
+			},
+		})
+	}
+}
+
+// parseTimeRange는 startTime, endTime 쿼리 매개변수(밀리초 타임스탬프)를 읽습니다.
+// endTime이 없으면 현재 시간, startTime이 없으면 endTime 1시간 전입니다.
+// 정수가 아니거나 시작 시간이 종료 시간보다 늦으면 400으로 응답하고 ok=false를 반환합니다.
+func parseTimeRange(ctx *gin.Context) (startTime, endTime int64, ok bool) {
+	endTime = time.Now().UnixMilli()
+	if value := ctx.Query("endTime"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			respondBadQuery(ctx, "endTime은 밀리초 타임스탬프여야 합니다")
+			return 0, 0, false
+		}
+		endTime = parsed
+	}
+
+	startTime = endTime - defaultTimeRange
+	if value := ctx.Query("startTime"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			respondBadQuery(ctx, "startTime은 밀리초 타임스탬프여야 합니다")
+			return 0, 0, false
+		}
+		startTime = parsed
+	}
+
+	if startTime > endTime {
+		respondBadQuery(ctx, "시작 시간이 종료 시간보다 늦을 수 없습니다")
+		return 0, 0, false
+	}
+	return startTime, endTime, true
+}
+
+// parseLimit은 limit 쿼리 매개변수를 읽습니다. 없으면 0(서비스 기본값)입니다.
+// 정수가 아니거나 음수면 400으로 응답하고 ok=false를 반환합니다.
+func parseLimit(ctx *gin.Context) (limit int, ok bool) {
+	value := ctx.Query("limit")
+	if value == "" {
+		return 0, true
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		respondBadQuery(ctx, "limit은 0 이상의 정수여야 합니다")
+		return 0, false
+	}
+	return limit, true
+}
+
+// respondBadQuery는 잘못된 조회 매개변수에 400으로 응답합니다.
+func respondBadQuery(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusBadRequest, dto.Response{
+		Success: false,
+		Error: &dto.ErrorInfo{
+			Code:    http.StatusBadRequest,
+			Message: message,
+		},
+	})
+}
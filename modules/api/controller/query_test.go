@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantOK    bool
+		wantStart int64
+		wantEnd   int64
+	}{
+		{name: "explicit range", query: "?startTime=1000&endTime=2000", wantOK: true, wantStart: 1000, wantEnd: 2000},
+		{name: "default start", query: "?endTime=7200000", wantOK: true, wantStart: 3600000, wantEnd: 7200000},
+		{name: "invalid start", query: "?startTime=abc&endTime=2000"},
+		{name: "invalid end", query: "?startTime=1000&endTime=yesterday"},
+		{name: "start after end", query: "?startTime=3000&endTime=2000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			start, end, ok := parseTimeRange(ctx)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if w.Code != http.StatusBadRequest {
+					t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				return
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("range = %d-%d, want %d-%d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantOK    bool
+		wantLimit int
+	}{
+		{name: "missing", query: "", wantOK: true},
+		{name: "valid", query: "?limit=50", wantOK: true, wantLimit: 50},
+		{name: "zero", query: "?limit=0", wantOK: true},
+		{name: "negative", query: "?limit=-1"},
+		{name: "not a number", query: "?limit=ten"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			limit, ok := parseLimit(ctx)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok && w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			if ok && limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", limit, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/stream"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// tail 스트림 연결 유지를 위한 heartbeat 주기
+const tailHeartbeatInterval = 15 * time.Second
+
+// TailController는 새로 수집된 로그/트레이스를 Server-Sent Events로 전달하는 핸들러를 관리합니다
+type TailController struct {
+	traceHub  *stream.Hub[traceDomain.TraceItem]
+	logHub    *stream.Hub[logDomain.LogItem]
+	rateLimit int
+	logger    logger.Logger
+}
+
+// NewTailController는 새 tail 컨트롤러를 생성합니다
+// Kafka 컨슈머를 실행하지 않는 인스턴스는 허브를 nil로 전달하며, 이때 tail 요청에는 501을 반환합니다
+func NewTailController(traceHub *stream.Hub[traceDomain.TraceItem], logHub *stream.Hub[logDomain.LogItem], rateLimit int, logger logger.Logger) *TailController {
+	return &TailController{
+		traceHub:  traceHub,
+		logHub:    logHub,
+		rateLimit: rateLimit,
+		logger:    logger,
+	}
+}
+
+// TailLogs godoc
+//
+//	@Summary		로그 실시간 tail
+//	@Description	새로 수집된 로그 중 필터 조건에 맞는 로그를 Server-Sent Events(event: log)로 전달합니다. 속도 제한이나 버퍼 초과로 버려진 항목 수는 event: dropped로 알립니다
+//	@Tags			logs
+//	@Produce		text/event-stream
+//	@Param			serviceName	query		[]string	false	"서비스 이름 목록"
+//	@Param			severity	query		string		false	"심각도 (INFO, WARN, ERROR, FATAL 등)"
+//	@Param			traceId		query		string		false	"트레이스 ID"
+//	@Param			query		query		string		false	"메시지 검색어"
+//	@Param			rate		query		int			false	"초당 최대 전송 로그 수 (서버 설정값 이하)"
+//	@Success		200			{object}	logDomain.LogItem
+//	@Failure		400			{object}	dto.Response
+//	@Failure		501			{object}	dto.Response
+//	@Failure		503			{object}	dto.Response
+//	@Router			/logs/tail [get]
+func (c *TailController) TailLogs(ctx *gin.Context) {
+	if c.logHub == nil {
+		tailNotImplemented(ctx)
+		return
+	}
+
+	rate, ok := c.parseRate(ctx)
+	if !ok {
+		return
+	}
+
+	services := toSet(ctx.QueryArray("serviceName"))
+	severity := strings.ToUpper(ctx.Query("severity"))
+	traceID := ctx.Query("traceId")
+	query := strings.ToLower(ctx.Query("query"))
+	tenantID := requestTenant(ctx)
+
+	match := func(item logDomain.LogItem) bool {
+		if tenant.OrDefault(item.TenantID) != tenantID {
+			return false
+		}
+		if len(services) > 0 && !services[item.ServiceName] {
+			return false
+		}
+		if severity != "" && strings.ToUpper(item.Severity) != severity {
+			return false
+		}
+		if traceID != "" && item.TraceID != traceID {
+			return false
+		}
+		if query != "" && !strings.Contains(strings.ToLower(item.Message), query) {
+			return false
+		}
+		return true
+	}
+
+	streamTail(ctx, c.logger, c.logHub, match, rate, "log")
+}
+
+// TailTraces godoc
+//
+//	@Summary		트레이스 실시간 tail
+//	@Description	새로 수집된 스팬 중 필터 조건에 맞는 스팬을 Server-Sent Events(event: span)로 전달합니다. 속도 제한이나 버퍼 초과로 버려진 항목 수는 event: dropped로 알립니다
+//	@Tags			traces
+//	@Produce		text/event-stream
+//	@Param			serviceName	query		[]string	false	"서비스 이름 목록"
+//	@Param			name		query		string		false	"스팬 이름"
+//	@Param			status		query		string		false	"상태 (OK, ERROR, UNSET)"
+//	@Param			minDuration	query		number		false	"최소 지속 시간 (밀리초)"
+//	@Param			rate		query		int			false	"초당 최대 전송 스팬 수 (서버 설정값 이하)"
+//	@Success		200			{object}	traceDomain.TraceItem
+//	@Failure		400			{object}	dto.Response
+//	@Failure		501			{object}	dto.Response
+//	@Failure		503			{object}	dto.Response
+//	@Router			/traces/tail [get]
+func (c *TailController) TailTraces(ctx *gin.Context) {
+	if c.traceHub == nil {
+		tailNotImplemented(ctx)
+		return
+	}
+
+	rate, ok := c.parseRate(ctx)
+	if !ok {
+		return
+	}
+
+	services := toSet(ctx.QueryArray("serviceName"))
+	name := ctx.Query("name")
+	status := strings.ToUpper(ctx.Query("status"))
+
+	var minDuration float64
+	if minDurationStr := ctx.Query("minDuration"); minDurationStr != "" {
+		parsed, err := strconv.ParseFloat(minDurationStr, 64)
+		if err != nil {
+			badTailRequest(ctx, "잘못된 minDuration 값입니다")
+			return
+		}
+		minDuration = parsed
+	}
+
+	tenantID := requestTenant(ctx)
+	match := func(item traceDomain.TraceItem) bool {
+		if tenant.OrDefault(item.TenantID) != tenantID {
+			return false
+		}
+		if len(services) > 0 && !services[item.ServiceName] {
+			return false
+		}
+		if name != "" && item.Name != name {
+			return false
+		}
+		if status != "" && strings.ToUpper(item.Status) != status {
+			return false
+		}
+		return item.Duration >= minDuration
+	}
+
+	streamTail(ctx, c.logger, c.traceHub, match, rate, "span")
+}
+
+// parseRate는 클라이언트가 요청한 전송 속도를 서버 설정값 이하로 제한해 반환합니다.
+func (c *TailController) parseRate(ctx *gin.Context) (int, bool) {
+	rate := c.rateLimit
+	if rateStr := ctx.Query("rate"); rateStr != "" {
+		parsed, err := strconv.Atoi(rateStr)
+		if err != nil || parsed <= 0 {
+			badTailRequest(ctx, "잘못된 rate 값입니다")
+			return 0, false
+		}
+		if rate <= 0 || parsed < rate {
+			rate = parsed
+		}
+	}
+	return rate, true
+}
+
+// streamTail은 허브를 구독해 조건에 맞는 항목을 클라이언트 연결이 끊길 때까지 SSE로 전송합니다.
+func streamTail[T any](ctx *gin.Context, log logger.Logger, hub *stream.Hub[T], match func(T) bool, rate int, event string) {
+	sub, err := hub.Subscribe(match, rate)
+	if err != nil {
+		message := "tail 스트림을 시작할 수 없습니다"
+		if errors.Is(err, stream.ErrTooManySubscribers) {
+			message = "tail 구독자 수가 최대값에 도달했습니다"
+		}
+		log.Warn().Err(err).Int("subscribers", hub.Subscribers()).Msg("tail 구독 실패")
+		ctx.JSON(http.StatusServiceUnavailable, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusServiceUnavailable,
+				Message: message,
+			},
+		})
+		return
+	}
+	defer sub.Close()
+
+	// 서버의 WriteTimeout이 장시간 스트림을 끊지 않도록 쓰기 마감 시간을 해제
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Debug().Err(err).Msg("tail 스트림 쓰기 마감 시간 해제 실패")
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	log.Info().Str("event", event).Int("rate", rate).Int("subscribers", hub.Subscribers()).Msg("tail 스트림 시작")
+
+	heartbeat := time.NewTicker(tailHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	var reportedDropped uint64
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			log.Info().Str("event", event).Uint64("dropped", sub.Dropped()).Msg("tail 스트림 종료")
+			return
+		case item, ok := <-sub.C():
+			if !ok {
+				// 서버 종료로 허브가 닫힌 경우
+				return
+			}
+			ctx.SSEvent(event, item)
+		case <-heartbeat.C:
+			ctx.SSEvent("heartbeat", gin.H{"timestamp": time.Now().UnixMilli()})
+		}
+
+		if dropped := sub.Dropped(); dropped != reportedDropped {
+			ctx.SSEvent("dropped", gin.H{"dropped": dropped})
+			reportedDropped = dropped
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+// badTailRequest는 잘못된 tail 요청에 대한 400 응답을 보냅니다.
+func badTailRequest(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusBadRequest, dto.Response{
+		Success: false,
+		Error: &dto.ErrorInfo{
+			Code:    http.StatusBadRequest,
+			Message: message,
+		},
+	})
+}
+
+// tailNotImplemented는 Kafka 컨슈머를 실행하지 않아 tail 스트림을 제공하지 않는 인스턴스의 응답을 보냅니다.
+// 허브는 프로세스 안에서만 항목을 전달하므로 이런 인스턴스에서 구독하면 새 항목을 영영 받지 못함
+func tailNotImplemented(ctx *gin.Context) {
+	ctx.JSON(http.StatusNotImplemented, dto.Response{
+		Success: false,
+		Error: &dto.ErrorInfo{
+			Code:    http.StatusNotImplemented,
+			Message: "이 인스턴스는 수집(ingest)을 실행하지 않아 실시간 tail 스트림을 제공하지 않습니다",
+		},
+	})
+}
+
+// toSet은 문자열 목록을 집합으로 변환합니다.
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value != "" {
+			set[value] = true
+		}
+	}
+	return set
+}
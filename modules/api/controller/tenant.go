@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+// requestTenant는 Auth 미들웨어가 요청에 설정한 테넌트 ID를 반환합니다. 설정되지 않았으면 기본 테넌트입니다.
+func requestTenant(ctx *gin.Context) string {
+	return tenant.OrDefault(ctx.GetString(tenant.ContextKey))
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +11,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
 	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 	"github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
 )
@@ -16,13 +20,15 @@ import (
 // TraceController는 트레이스 관련 API 핸들러를 관리합니다
 type TraceController struct {
 	traceService service.TraceService
+	logService   logService.LogService
 	logger       logger.Logger
 }
 
 // NewTraceController는 새 트레이스 컨트롤러를 생성합니다
-func NewTraceController(traceService service.TraceService, logger logger.Logger) *TraceController {
+func NewTraceController(traceService service.TraceService, logService logService.LogService, logger logger.Logger) *TraceController {
 	return &TraceController{
 		traceService: traceService,
+		logService:   logService,
 		logger:       logger,
 	}
 }
@@ -30,12 +36,13 @@ func NewTraceController(traceService service.TraceService, logger logger.Logger)
 // GetTraceByID godoc
 //
 //	@Summary		트레이스 ID로 상세 정보 조회
-//	@Description	특정 트레이스 ID에 대한 상세 정보를 조회합니다
+//	@Description	특정 트레이스 ID에 대한 상세 정보와 연결된 로그(스팬별 그룹 포함)를 조회합니다. analysis=true인 경우 스팬 트리, 셀프 타임, 임계 경로, 고아 스팬 및 시계 오차 분석 결과를 함께 반환합니다
 //	@Tags			traces
 //	@Accept			json
 //	@Produce		json
-//	@Param			traceId	path		string	true	"Trace ID"
-//	@Success		200		{object}	dto.Response{data=dto.TraceDetailResponse}
+//	@Param			traceId		path		string	true	"Trace ID"
+//	@Param			analysis	query		boolean	false	"스팬 트리 분석 결과 포함 여부"
+//	@Success		200			{object}	dto.Response{data=dto.TraceDetailResponse}
 //	@Failure		400		{object}	dto.Response
 //	@Failure		404		{object}	dto.Response
 //	@Failure		500		{object}	dto.Response
@@ -53,36 +60,70 @@ func (c *TraceController) GetTraceByID(ctx *gin.Context) {
 		return
 	}
 
-	trace, err := c.traceService.GetTraceByID(traceID)
+	trace, err := c.traceService.GetTraceByID(ctx.Request.Context(), requestTenant(ctx), traceID)
+	if errors.Is(err, service.ErrTraceNotFound) {
+		ctx.JSON(http.StatusNotFound, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusNotFound,
+				Message: "트레이스를 찾을 수 없습니다",
+			},
+		})
+		return
+	}
 	if err != nil {
 		c.logger.Error().Err(err).Str("traceId", traceID).Msg("트레이스 조회 실패")
+		respondQueryError(ctx, "트레이스 조회 중 오류가 발생했습니다")
+		return
+	}
 
-		status := http.StatusInternalServerError
-		message := "트레이스 조회 중 오류가 발생했습니다"
-
-		if err.Error() == "sql: no rows in result set" {
-			status = http.StatusNotFound
-			message = "트레이스를 찾을 수 없습니다"
-		}
-
-		ctx.JSON(status, dto.Response{
+	if trace == nil {
+		ctx.JSON(http.StatusNotFound, dto.Response{
 			Success: false,
 			Error: &dto.ErrorInfo{
-				Code:    status,
-				Message: message,
+				Code:    http.StatusNotFound,
+				Message: "트레이스를 찾을 수 없습니다",
 			},
 		})
 		return
 	}
 
+	response := dto.TraceDetailResponse{
+		Trace: trace,
+	}
+
+	// 트레이스에 연결된 로그 조회 (실패해도 트레이스 상세는 반환)
+	relatedLogs, err := c.logService.GetLogsByTraceID(ctx.Request.Context(), requestTenant(ctx), traceID)
+	if err != nil {
+		c.logger.Warn().Err(err).Str("traceId", traceID).Msg("트레이스 관련 로그 조회 실패")
+	} else {
+		response.RelatedLogs = relatedLogs
+		response.SpanLogs = groupLogsBySpan(relatedLogs)
+	}
+
+	// 스팬 트리 분석 (선택적)
+	if analysis, _ := strconv.ParseBool(ctx.Query("analysis")); analysis {
+		response.Analysis = traceDomain.AnalyzeTrace(trace)
+	}
+
 	ctx.JSON(http.StatusOK, dto.Response{
 		Success: true,
-		Data: dto.TraceDetailResponse{
-			Trace: trace,
-		},
+		Data:    response,
 	})
 }
 
+// groupLogsBySpan은 로그를 스팬 ID별로 묶습니다. 스팬 ID가 없는 로그는 제외됩니다.
+func groupLogsBySpan(logs []logDomain.LogItem) map[string][]logDomain.LogItem {
+	spanLogs := make(map[string][]logDomain.LogItem)
+	for _, log := range logs {
+		if log.SpanID == "" {
+			continue
+		}
+		spanLogs[log.SpanID] = append(spanLogs[log.SpanID], log)
+	}
+	return spanLogs
+}
+
 // QueryTraces godoc
 //
 //	@Summary		트레이스 목록 조회
@@ -119,6 +160,7 @@ func (c *TraceController) QueryTraces(ctx *gin.Context) {
 
 	// 트레이스 필터 구성
 	filter := traceDomain.TraceFilter{
+		TenantID:      requestTenant(ctx),
 		StartTime:     params.StartTime,
 		EndTime:       params.EndTime,
 		Limit:         params.Limit,
@@ -158,16 +200,10 @@ func (c *TraceController) QueryTraces(ctx *gin.Context) {
 	}
 
 	// 쿼리 실행
-	result, err := c.traceService.QueryTraces(filter)
+	result, err := c.traceService.QueryTraces(ctx.Request.Context(), filter)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("트레이스 쿼리 실패")
-		ctx.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Error: &dto.ErrorInfo{
-				Code:    http.StatusInternalServerError,
-				Message: "트레이스 조회 중 오류가 발생했습니다",
-			},
-		})
+		respondQueryError(ctx, "트레이스 조회 중 오류가 발생했습니다")
 		return
 	}
 
@@ -248,16 +284,10 @@ func (c *TraceController) GetServiceMetrics(ctx *gin.Context) {
 	}
 
 	// 서비스 메트릭 조회
-	metrics, err := c.traceService.GetServiceMetrics(startTime, endTime, serviceName)
+	metrics, err := c.traceService.GetServiceMetrics(ctx.Request.Context(), requestTenant(ctx), startTime, endTime, serviceName)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("서비스 메트릭 조회 실패")
-		ctx.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Error: &dto.ErrorInfo{
-				Code:    http.StatusInternalServerError,
-				Message: "서비스 메트릭을 가져오는 중 오류가 발생했습니다",
-			},
-		})
+		respondQueryError(ctx, "서비스 메트릭을 가져오는 중 오류가 발생했습니다")
 		return
 	}
 
@@ -300,6 +330,69 @@ func (c *TraceController) GetServiceMetrics(ctx *gin.Context) {
 	})
 }
 
+// GetServiceTimeSeries godoc
+//
+//	@Summary		서비스 RED 지표 시계열 조회
+//	@Description	서비스의 요청률, 오류율, 지연 시간 백분위를 step 단위 버킷으로 조회합니다. 요청이 없는 구간도 0 값으로 채워집니다
+//	@Tags			metrics
+//	@Accept			json
+//	@Produce		json
+//	@Param			service		path		string	true	"서비스 이름"
+//	@Param			startTime	query		int		false	"시작 시간 (밀리초 타임스탬프)"
+//	@Param			endTime		query		int		false	"종료 시간 (밀리초 타임스탬프)"
+//	@Param			step		query		string	false	"버킷 크기 (예: 30s, 1m, 5m, 1h)"	default(1m)
+//	@Success		200			{object}	dto.Response{data=traceDomain.ServiceTimeSeries}
+//	@Failure		400			{object}	dto.Response
+//	@Failure		500			{object}	dto.Response
+//	@Router			/metrics/services/{service}/timeseries [get]
+func (c *TraceController) GetServiceTimeSeries(ctx *gin.Context) {
+	serviceName := ctx.Param("service")
+
+	// 시간 범위 파싱 (기본값: 최근 1시간)
+	startTime, endTime, ok := parseTimeRange(ctx)
+	if !ok {
+		return
+	}
+
+	// 버킷 크기 파싱 (최소 1초)
+	step, err := time.ParseDuration(ctx.DefaultQuery("step", "1m"))
+	if err != nil || step < time.Second {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "step은 1s 이상의 기간이어야 합니다 (예: 30s, 1m, 1h)",
+			},
+		})
+		return
+	}
+	stepMillis := step.Milliseconds()
+
+	if (endTime-startTime)/stepMillis+1 > traceDomain.MaxTimeSeriesPoints {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("버킷 수가 최대값(%d)을 초과합니다. step을 늘리거나 시간 범위를 줄이세요", traceDomain.MaxTimeSeriesPoints),
+			},
+		})
+		return
+	}
+
+	// 시계열 조회
+	series, err := c.traceService.GetServiceTimeSeries(ctx.Request.Context(), requestTenant(ctx), serviceName, startTime, endTime, stepMillis)
+	if err != nil {
+		c.logger.Error().Err(err).Str("service", serviceName).Msg("서비스 시계열 조회 실패")
+		respondQueryError(ctx, "서비스 시계열을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    series,
+	})
+}
+
 // GetServices godoc
 //
 //	@Summary		서비스 목록 조회
@@ -338,21 +431,168 @@ func (c *TraceController) GetServices(ctx *gin.Context) {
 	}
 
 	// 서비스 목록 조회
-	result, err := c.traceService.GetServices(startTime, endTime, filter)
+	result, err := c.traceService.GetServices(ctx.Request.Context(), requestTenant(ctx), startTime, endTime, filter)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("서비스 목록 조회 실패")
-		ctx.JSON(http.StatusInternalServerError, dto.Response{
+		respondQueryError(ctx, "서비스 목록을 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetServiceGraph godoc
+//
+//	@Summary		서비스 의존성 그래프 조회
+//	@Description	부모/자식 스팬 관계로부터 서비스 간 호출 그래프(노드, 엣지)와 호출 수, 오류율, 지연 시간 백분위를 조회합니다
+//	@Tags			services
+//	@Accept			json
+//	@Produce		json
+//	@Param			startTime	query		int	false	"시작 시간 (밀리초 타임스탬프)"
+//	@Param			endTime		query		int	false	"종료 시간 (밀리초 타임스탬프)"
+//	@Success		200			{object}	dto.Response{data=traceDomain.ServiceGraph}
+//	@Failure		400			{object}	dto.Response
+//	@Failure		500			{object}	dto.Response
+//	@Router			/services/graph [get]
+func (c *TraceController) GetServiceGraph(ctx *gin.Context) {
+	// 시간 범위 파싱 (기본값: 최근 1시간)
+	startTime, endTime, ok := parseTimeRange(ctx)
+	if !ok {
+		return
+	}
+
+	// 서비스 의존성 그래프 조회
+	graph, err := c.traceService.GetServiceGraph(ctx.Request.Context(), requestTenant(ctx), startTime, endTime)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("서비스 의존성 그래프 조회 실패")
+		respondQueryError(ctx, "서비스 의존성 그래프를 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    graph,
+	})
+}
+
+// GetServiceOperations godoc
+//
+//	@Summary		서비스 오퍼레이션별 통계 조회
+//	@Description	서비스 내 오퍼레이션(스팬 이름)과 스팬 종류별 요청 수, 오류율, 평균/p50/p95/p99 지연 시간을 조회합니다
+//	@Tags			services
+//	@Accept			json
+//	@Produce		json
+//	@Param			service			path		string	true	"서비스 이름"
+//	@Param			startTime		query		int		false	"시작 시간 (밀리초 타임스탬프)"
+//	@Param			endTime			query		int		false	"종료 시간 (밀리초 타임스탬프)"
+//	@Param			spanKind		query		string	false	"스팬 종류 (SERVER, CLIENT, INTERNAL, PRODUCER, CONSUMER)"
+//	@Param			sortField		query		string	false	"정렬 필드 (name, requestCount, errorCount, errorRate, avgLatency, p50Latency, p95Latency, p99Latency)"	default(requestCount)
+//	@Param			sortDirection	query		string	false	"정렬 방향 (asc, desc)"	default(desc)
+//	@Param			limit			query		int		false	"최대 오퍼레이션 수"	default(100)
+//	@Success		200				{object}	dto.Response{data=traceDomain.OperationListResult}
+//	@Failure		400				{object}	dto.Response
+//	@Failure		500				{object}	dto.Response
+//	@Router			/services/{service}/operations [get]
+func (c *TraceController) GetServiceOperations(ctx *gin.Context) {
+	serviceName := ctx.Param("service")
+	if serviceName == "" {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
 			Success: false,
 			Error: &dto.ErrorInfo{
-				Code:    http.StatusInternalServerError,
-				Message: "서비스 목록을 가져오는 중 오류가 발생했습니다",
+				Code:    http.StatusBadRequest,
+				Message: "서비스 이름이 필요합니다",
 			},
 		})
 		return
 	}
 
+	// 시간 범위 파싱 (기본값: 최근 1시간)
+	startTime, endTime, ok := parseTimeRange(ctx)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimit(ctx)
+	if !ok {
+		return
+	}
+
+	filter := traceDomain.OperationFilter{
+		TenantID:      requestTenant(ctx),
+		ServiceName:   serviceName,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		SpanKind:      ctx.Query("spanKind"),
+		SortField:     ctx.Query("sortField"),
+		SortDirection: ctx.Query("sortDirection"),
+		Limit:         limit,
+	}
+
+	// 오퍼레이션별 통계 조회
+	result, err := c.traceService.GetOperationStats(ctx.Request.Context(), filter)
+	if err != nil {
+		c.logger.Error().Err(err).Str("service", serviceName).Msg("오퍼레이션 통계 조회 실패")
+		respondQueryError(ctx, "오퍼레이션 통계를 가져오는 중 오류가 발생했습니다")
+		return
+	}
+
 	ctx.JSON(http.StatusOK, dto.Response{
 		Success: true,
 		Data:    result,
 	})
 }
+
+// CompareTraces godoc
+//
+//	@Summary		두 트레이스 비교
+//	@Description	두 트레이스의 스팬 트리를 서비스+오퍼레이션 경로로 정렬하여 추가/제거된 스팬, 스팬별 지속 시간 변화 및 속성 차이를 조회합니다
+//	@Tags			traces
+//	@Accept			json
+//	@Produce		json
+//	@Param			a	query		string	true	"기준 트레이스 ID (예: 빠른 트레이스)"
+//	@Param			b	query		string	true	"비교 대상 트레이스 ID (예: 느린 트레이스)"
+//	@Success		200	{object}	dto.Response{data=traceDomain.TraceComparison}
+//	@Failure		400	{object}	dto.Response
+//	@Failure		404	{object}	dto.Response
+//	@Failure		500	{object}	dto.Response
+//	@Router			/traces/compare [get]
+func (c *TraceController) CompareTraces(ctx *gin.Context) {
+	traceIDA := strings.TrimSpace(ctx.Query("a"))
+	traceIDB := strings.TrimSpace(ctx.Query("b"))
+	if traceIDA == "" || traceIDB == "" {
+		ctx.JSON(http.StatusBadRequest, dto.Response{
+			Success: false,
+			Error: &dto.ErrorInfo{
+				Code:    http.StatusBadRequest,
+				Message: "비교할 두 트레이스 ID(a, b)가 필요합니다",
+			},
+		})
+		return
+	}
+
+	comparison, err := c.traceService.CompareTraces(ctx.Request.Context(), requestTenant(ctx), traceIDA, traceIDB)
+	if err != nil {
+		c.logger.Error().Err(err).Str("a", traceIDA).Str("b", traceIDB).Msg("트레이스 비교 실패")
+
+		if errors.Is(err, service.ErrTraceNotFound) {
+			ctx.JSON(http.StatusNotFound, dto.Response{
+				Success: false,
+				Error: &dto.ErrorInfo{
+					Code:    http.StatusNotFound,
+					Message: "트레이스를 찾을 수 없습니다",
+				},
+			})
+			return
+		}
+
+		respondQueryError(ctx, "트레이스 비교 중 오류가 발생했습니다")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Response{
+		Success: true,
+		Data:    comparison,
+	})
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
 	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 )
@@ -44,8 +45,10 @@ type TracesResponse struct {
 
 // TraceDetailResponse 트레이스 상세 응답
 type TraceDetailResponse struct {
-	Trace       *traceDomain.Trace `json:"trace"`
-	RelatedLogs []domain.LogItem   `json:"relatedLogs,omitempty"`
+	Trace       *traceDomain.Trace          `json:"trace"`
+	RelatedLogs []domain.LogItem            `json:"relatedLogs,omitempty"`
+	SpanLogs    map[string][]domain.LogItem `json:"spanLogs,omitempty"` // 스팬 ID별 로그 (스팬 ID가 없는 로그는 relatedLogs에만 포함)
+	Analysis    *traceDomain.TraceAnalysis  `json:"analysis,omitempty"`
 }
 
 // LogsResponse 로그 목록 응답
@@ -85,6 +88,8 @@ type LogFilterParams struct {
 	ServiceNames  []string `form:"serviceName"`
 	Severity      string   `form:"severity"`
 	HasTrace      bool     `form:"hasTrace"`
+	TraceID       string   `form:"traceId"`
+	SpanID        string   `form:"spanId"`
 	Query         string   `form:"query"`
 	Limit         int      `form:"limit,default=20"`
 	Offset        int      `form:"offset,default=0"`
@@ -106,3 +111,75 @@ type TraceFilterParams struct {
 	SortField     string   `form:"sortField"`
 	SortDirection string   `form:"sortDirection"`
 }
+
+// AlertRuleRequest 알림 규칙 생성/수정 요청
+type AlertRuleRequest struct {
+	Name        string            `json:"name" binding:"required"`
+	Description string            `json:"description"`
+	Type        string            `json:"type" binding:"required"` // error_rate, latency, log_count
+	ServiceName string            `json:"serviceName"`
+	Percentile  int               `json:"percentile"` // latency 규칙: 95 또는 99 (기본값 99)
+	Severity    string            `json:"severity"`   // log_count 규칙: 로그 심각도
+	Query       string            `json:"query"`      // log_count 규칙: 메시지 검색어
+	Operator    string            `json:"operator"`   // >, >=, <, <= (기본값 >)
+	Threshold   float64           `json:"threshold"`
+	Window      int64             `json:"window"` // 평가 구간 (초, 기본값 300)
+	For         int64             `json:"for"`    // firing 전환까지 조건 유지 시간 (초)
+	Labels      map[string]string `json:"labels"`
+	Enabled     *bool             `json:"enabled"` // 기본값 true
+}
+
+// AlertHistoryParams 알림 이력 조회 매개변수
+type AlertHistoryParams struct {
+	RuleID    int64  `form:"ruleId"`
+	State     string `form:"state"`
+	StartTime int64  `form:"startTime"`
+	EndTime   int64  `form:"endTime"`
+	Limit     int    `form:"limit,default=100"`
+}
+
+// NotificationChannelRequest 알림 채널 생성/수정 요청
+type NotificationChannelRequest struct {
+	Name       string            `json:"name" binding:"required"`
+	Type       string            `json:"type" binding:"required"` // webhook, slack, email
+	URL        string            `json:"url"`                     // webhook, slack
+	Headers    map[string]string `json:"headers"`                 // webhook 요청 헤더
+	Recipients []string          `json:"recipients"`              // email 수신자
+	Matchers   map[string]string `json:"matchers"`                // 모두 일치하는 알림만 전송
+	GroupBy    []string          `json:"groupBy"`                 // 같은 라벨 값의 알림을 한 메시지로 묶음
+	Template   string            `json:"template"`                // Go text/template, 비어 있으면 기본 템플릿
+	Enabled    *bool             `json:"enabled"`                 // 기본값 true
+}
+
+// SilenceRequest 사일런스 생성 요청
+type SilenceRequest struct {
+	Matchers  map[string]string `json:"matchers" binding:"required"`
+	StartsAt  int64             `json:"startsAt"` // 밀리초 타임스탬프, 기본값: 현재 시간
+	EndsAt    int64             `json:"endsAt" binding:"required"`
+	Comment   string            `json:"comment"`
+	CreatedBy string            `json:"createdBy"`
+}
+
+// APIKeyRequest API 키 발급 요청
+type APIKeyRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Role      string `json:"role"`      // viewer, editor, admin (기본값 viewer)
+	ExpiresIn int64  `json:"expiresIn"` // 유효 기간 (초), 0이면 만료 없음
+}
+
+// IssuedAPIKeyResponse API 키 발급 응답. Key는 이 응답에서만 확인할 수 있습니다
+type IssuedAPIKeyResponse struct {
+	Key       string `json:"key"`
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Prefix    string `json:"prefix"`
+	Role      string `json:"role"`
+	CreatedAt int64  `json:"createdAt"`
+	ExpiresAt int64  `json:"expiresAt,omitempty"`
+}
+
+// ConfigResponse 실행 중인 설정 조회 응답. 비밀 값은 가려집니다
+type ConfigResponse struct {
+	Config   *config.Config  `json:"config"`
+	Metadata config.Metadata `json:"metadata"`
+}
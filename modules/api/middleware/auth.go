@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	authService "github.com/seongpil0948/otel-kafka-pg/modules/auth/service"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+// APIKeyHeader는 API 키를 전달하는 요청 헤더입니다.
+const APIKeyHeader = "X-API-Key"
+
+// PrincipalKey는 gin 컨텍스트에 인증된 요청 주체(*domain.Principal)를 저장하는 키입니다.
+const PrincipalKey = "principal"
+
+// bearerPrefix는 Authorization 헤더의 Bearer 토큰 접두사입니다.
+const bearerPrefix = "Bearer "
+
+// apiKeyQuery는 헤더를 보낼 수 없는 EventSource 클라이언트가 API 키를 전달하는 쿼리 파라미터입니다.
+const apiKeyQuery = "apiKey"
+
+// Auth 미들웨어는 /api 요청의 자격 증명을 확인하고 요청 주체와 테넌트를 컨텍스트에 저장합니다.
+// 인증이 필요하지 않으면 모든 요청을 기본 테넌트의 editor로 처리하므로, API 키 발급 같은 관리 API는 인증을 켜야 사용할 수 있습니다.
+// 자격 증명은 Authorization: Bearer 토큰(JWT 또는 발급 API 키), X-API-Key 헤더,
+// EventSource처럼 헤더를 보낼 수 없는 실시간 tail 요청만 apiKey 쿼리 순으로 찾으며 없거나 유효하지 않으면 401을 반환합니다.
+// apiKey 쿼리는 로그, 캐시 키, 핸들러에 남지 않도록 인증 전에 요청 URL에서 제거합니다.
+func Auth(service authService.AuthService, log logger.Logger) gin.HandlerFunc {
+	anonymous := &domain.Principal{
+		Subject:  domain.MethodAnonymous,
+		TenantID: tenant.Default,
+		Role:     domain.RoleEditor,
+		Method:   domain.MethodAnonymous,
+	}
+
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/api") {
+			c.Next()
+			return
+		}
+
+		queryKey := takeQueryAPIKey(c)
+
+		if !service.Required() {
+			setPrincipal(c, anonymous)
+			c.Next()
+			return
+		}
+
+		principal, err := authenticate(c, service, queryKey)
+		if err != nil {
+			if !errors.Is(err, authService.ErrUnauthenticated) {
+				log.Error().Err(err).Str("path", c.Request.URL.Path).Msg("API 인증 처리 중 오류 발생")
+			} else {
+				log.Warn().
+					Str("path", c.Request.URL.Path).
+					Str("client_ip", c.ClientIP()).
+					Msg("API 인증 실패")
+			}
+
+			abortWithError(c, http.StatusUnauthorized, "유효한 API 키 또는 토큰이 필요합니다")
+			return
+		}
+
+		setPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+// RequireRole 미들웨어는 요청 주체의 역할이 required 이상인지 확인하고 아니면 403을 반환합니다.
+func RequireRole(required domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := RequestPrincipal(c)
+		if principal != nil && principal.Method == domain.MethodAnonymous && !principal.Role.Allows(required) {
+			abortWithError(c, http.StatusForbidden, "인증이 비활성화되어 있어 '"+string(required)+"' 역할이 필요한 API를 사용할 수 없습니다 (AUTH_ENABLED=true 필요)")
+			return
+		}
+		if principal == nil || !principal.Role.Allows(required) {
+			abortWithError(c, http.StatusForbidden, "'"+string(required)+"' 이상의 역할이 필요합니다")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequestPrincipal은 Auth 미들웨어가 저장한 요청 주체를 반환합니다. 없으면 nil입니다.
+func RequestPrincipal(c *gin.Context) *domain.Principal {
+	value, ok := c.Get(PrincipalKey)
+	if !ok {
+		return nil
+	}
+	principal, _ := value.(*domain.Principal)
+	return principal
+}
+
+// authenticate는 요청에서 자격 증명을 찾아 검증합니다. queryKey는 takeQueryAPIKey가 꺼낸 apiKey 쿼리 값입니다.
+func authenticate(c *gin.Context, service authService.AuthService, queryKey string) (*domain.Principal, error) {
+	if header := c.GetHeader("Authorization"); strings.HasPrefix(header, bearerPrefix) {
+		token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+		// 세 구간으로 된 토큰만 JWT로 검증하고, 그 외에는 API 키로 취급
+		if strings.Count(token, ".") == 2 && service.TokenEnabled() {
+			return service.AuthenticateToken(token)
+		}
+		return service.AuthenticateAPIKey(token)
+	}
+
+	key := c.GetHeader(APIKeyHeader)
+	if key == "" && strings.HasSuffix(c.Request.URL.Path, "/tail") {
+		key = queryKey
+	}
+	return service.AuthenticateAPIKey(key)
+}
+
+// takeQueryAPIKey는 요청 URL에서 apiKey 쿼리를 제거하고 그 값을 반환합니다.
+func takeQueryAPIKey(c *gin.Context) string {
+	query := c.Request.URL.Query()
+	if !query.Has(apiKeyQuery) {
+		return ""
+	}
+	key := query.Get(apiKeyQuery)
+	query.Del(apiKeyQuery)
+	c.Request.URL.RawQuery = query.Encode()
+	return key
+}
+
+// setPrincipal은 요청 주체와 테넌트를 컨텍스트에 저장합니다.
+func setPrincipal(c *gin.Context, principal *domain.Principal) {
+	c.Set(PrincipalKey, principal)
+	c.Set(tenant.ContextKey, principal.TenantID)
+}
+
+// abortWithError는 API 공통 오류 응답 형식으로 요청을 중단합니다.
+func abortWithError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    status,
+			"message": message,
+		},
+	})
+}
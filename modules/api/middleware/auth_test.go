@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	authService "github.com/seongpil0948/otel-kafka-pg/modules/auth/service"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// noAuthService는 인증이 비활성화된 AuthService입니다.
+type noAuthService struct{}
+
+func (noAuthService) Required() bool     { return false }
+func (noAuthService) TokenEnabled() bool { return false }
+func (noAuthService) AuthenticateAPIKey(string) (*domain.Principal, error) {
+	return nil, authService.ErrUnauthenticated
+}
+func (noAuthService) AuthenticateToken(string) (*domain.Principal, error) {
+	return nil, authService.ErrUnauthenticated
+}
+func (noAuthService) IssueKey(string, string, domain.Role, time.Duration, string) (string, *domain.APIKey, error) {
+	return "", nil, authService.ErrInvalidKey
+}
+func (noAuthService) ListKeys(string) ([]domain.APIKey, error) { return nil, nil }
+func (noAuthService) RevokeKey(string, int64) error            { return nil }
+
+// 인증이 꺼져 있으면 조회, 변경 API는 허용하고 관리 API는 거부해야 함
+func TestAuthAnonymousRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Auth(noAuthService{}, logger.GetLogger()))
+	api := router.Group("/api", RequireRole(domain.RoleViewer))
+	api.GET("/traces", func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.POST("/alerts/rules", RequireRole(domain.RoleEditor), func(c *gin.Context) { c.Status(http.StatusOK) })
+	admin := api.Group("/admin", RequireRole(domain.RoleAdmin))
+	admin.POST("/keys", func(c *gin.Context) { c.Status(http.StatusOK) })
+	admin.GET("/config", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{method: http.MethodGet, path: "/api/traces", wantStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/alerts/rules", wantStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/admin/keys", wantStatus: http.StatusForbidden},
+		{method: http.MethodGet, path: "/api/admin/config", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/cache"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
 )
 
 // responseBodyWriter는 응답 본문을 캡처하는 구조체입니다.
@@ -33,7 +35,28 @@ func (r responseBodyWriter) WriteString(s string) (int, error) {
 	return r.ResponseWriter.WriteString(s)
 }
 
-// CachingMiddleware는 API 응답을 캐싱하는 미들웨어입니다.
+// 응답을 캐싱하지 않는 경로 접두사
+// (백그라운드 작업이나 설정 다시 로드로 상태가 바뀌는 API, 요청 주체마다 응답이 다른 인증 API)
+var nonCacheablePrefixes = []string{"/api/alerts", "/api/admin", "/api/auth"}
+
+// isCacheable은 요청 응답을 캐싱할 수 있는지 확인합니다.
+// /api 조회 응답만 캐싱하며, 실시간 스트리밍(tail) 요청과 nonCacheablePrefixes 경로는 캐싱하지 않습니다.
+func isCacheable(c *gin.Context) bool {
+	path := c.Request.URL.Path
+	if !strings.HasPrefix(path, "/api") {
+		return false
+	}
+	if strings.HasSuffix(path, "/tail") || c.GetHeader("Accept") == "text/event-stream" {
+		return false
+	}
+	for _, prefix := range nonCacheablePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
 // CachingMiddleware는 API 응답을 캐싱하는 미들웨어입니다.
 func CachingMiddleware(cacheService cache.CacheService, log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -43,9 +66,15 @@ func CachingMiddleware(cacheService cache.CacheService, log logger.Logger) gin.H
 			return
 		}
 
+		// 캐싱 대상이 아닌 요청
+		if !isCacheable(c) {
+			c.Next()
+			return
+		}
+
 		// 캐싱이 비활성화된 경우
 		if !cacheService.IsEnabled() {
-			log.Debug().Str("url", c.Request.URL.String()).Msg("캐싱 비활성화 상태")
+			log.Debug().Str("path", c.Request.URL.Path).Msg("캐싱 비활성화 상태")
 			c.Next()
 			return
 		}
@@ -58,8 +87,8 @@ func CachingMiddleware(cacheService cache.CacheService, log logger.Logger) gin.H
 		}
 
 		// 캐시 키 생성
-		cacheKey := generateCacheKey(c.Request, requestBody)
-		log.Debug().Str("cache_key", cacheKey).Str("url", c.Request.URL.String()).Msg("캐시 키 생성")
+		cacheKey := generateCacheKey(c.Request, requestBody, c.GetString(tenant.ContextKey), RequestPrincipal(c))
+		log.Debug().Str("cache_key", cacheKey).Str("path", c.Request.URL.Path).Msg("캐시 키 생성")
 
 		// 캐시에서 응답 확인
 		var cachedResponse struct {
@@ -71,7 +100,7 @@ func CachingMiddleware(cacheService cache.CacheService, log logger.Logger) gin.H
 		err := cacheService.Get(c.Request.Context(), cacheKey, &cachedResponse)
 		if err == nil {
 			// 캐시 히트: 캐시된 응답 반환
-			log.Info().Str("url", c.Request.URL.String()).Str("cache_key", cacheKey).Msg("캐시 히트")
+			log.Info().Str("path", c.Request.URL.Path).Str("cache_key", cacheKey).Msg("캐시 히트")
 			c.Header("X-Cache", "HIT")
 
 			// 캐시된 헤더 설정
@@ -88,7 +117,7 @@ func CachingMiddleware(cacheService cache.CacheService, log logger.Logger) gin.H
 			return
 		}
 
-		log.Info().Str("url", c.Request.URL.String()).Str("cache_key", cacheKey).Err(err).Msg("캐시 미스")
+		log.Info().Str("path", c.Request.URL.Path).Str("cache_key", cacheKey).Err(err).Msg("캐시 미스")
 		c.Header("X-Cache", "MISS")
 		responseBody := &bytes.Buffer{}
 		rbw := &responseBodyWriter{ResponseWriter: c.Writer, body: responseBody}
@@ -109,18 +138,21 @@ func CachingMiddleware(cacheService cache.CacheService, log logger.Logger) gin.H
 			}
 
 			if err := cacheService.Set(c.Request.Context(), cacheKey, response); err != nil {
-				log.Error().Err(err).Str("url", c.Request.URL.String()).Str("cache_key", cacheKey).Msg("응답 캐싱 실패")
+				log.Error().Err(err).Str("path", c.Request.URL.Path).Str("cache_key", cacheKey).Msg("응답 캐싱 실패")
 			} else {
-				log.Info().Str("url", c.Request.URL.String()).Str("cache_key", cacheKey).Msg("응답 캐싱 성공")
+				log.Info().Str("path", c.Request.URL.Path).Str("cache_key", cacheKey).Msg("응답 캐싱 성공")
 			}
 		} else {
-			log.Debug().Str("url", c.Request.URL.String()).Int("status", c.Writer.Status()).Msg("응답 캐싱 건너뜀: 성공 상태 코드가 아님")
+			log.Debug().Str("path", c.Request.URL.Path).Int("status", c.Writer.Status()).Msg("응답 캐싱 건너뜀: 성공 상태 코드가 아님")
 		}
 	}
 }
 
 // generateCacheKey는 요청에 대한 고유한 캐시 키를 생성합니다.
-func generateCacheKey(req *http.Request, body []byte) string {
+// 같은 요청이라도 테넌트가 다르면 다른 키가 되도록 테넌트 ID를 포함합니다.
+// 요청 주체와 역할도 포함해, 캐시 히트로 라우트 그룹의 역할 검사를 건너뛰더라도
+// 이미 같은 주체, 같은 역할로 권한 검사를 통과해 저장한 응답만 돌려주도록 합니다.
+func generateCacheKey(req *http.Request, body []byte, tenantID string, principal *domain.Principal) string {
 	// URI 포함
 	path := req.URL.Path
 
@@ -146,6 +178,11 @@ func generateCacheKey(req *http.Request, body []byte) string {
 
 	// 해시 생성
 	h := sha256.New()
+	h.Write([]byte(tenantID))
+	if principal != nil {
+		// 구분자가 이름에 들어갈 수 없도록 NUL로 구분
+		h.Write([]byte("\x00" + principal.Method + "\x00" + principal.Subject + "\x00" + string(principal.Role) + "\x00"))
+	}
 	h.Write([]byte(path))
 
 	// 쿼리 파라미터 해시에 추가
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// Deadline 미들웨어는 endpoint 그룹 요청의 컨텍스트에 최대 처리 시간을 설정합니다.
+// 핸들러가 이 컨텍스트로 실행하는 DB 쿼리는 시간이 지나면 취소됩니다.
+// 엔드포인트별 설정이 없으면 전체 기본값을 사용하며, 0 이하이면 제한하지 않습니다.
+// SSE 스트림처럼 오래 유지되는 요청에는 적용하지 않아야 합니다.
+func Deadline(cfg *config.Config, endpoint string) gin.HandlerFunc {
+	timeoutMillis := cfg.QueryGuard.Timeout
+	if millis, ok := cfg.QueryGuard.Timeouts[endpoint]; ok {
+		timeoutMillis = millis
+	}
+	timeout := time.Duration(timeoutMillis) * time.Millisecond
+
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		requestCtx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(requestCtx)
+		c.Next()
+	}
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/ratelimit"
+)
+
+// 제한에 걸린 요청 응답의 guard 값
+const (
+	GuardRateLimit = "rate_limit" // 클라이언트별 요청 속도 제한
+	GuardTimeRange = "time_range" // 조회 구간 길이 제한
+	GuardLimit     = "limit"      // limit 매개변수 제한
+)
+
+// 클라이언트 버킷을 메모리에서 제거하기 전까지 유지하는 시간
+const rateLimitIdle = 10 * time.Minute
+
+// RateLimit 미들웨어는 /api 요청을 클라이언트별 토큰 버킷으로 제한하고 초과하면 429를 반환합니다.
+// 클라이언트는 인증된 API 키, JWT 주체 순으로 구분하며 인증 정보가 없으면 IP로 구분합니다.
+// IP는 API.TrustedProxies로 지정한 프록시를 거친 요청만 X-Forwarded-For에서 읽고, 그 밖에는 연결 주소를 사용합니다.
+// Auth 미들웨어 다음에 등록해야 합니다.
+func RateLimit(cfg *config.Config, log logger.Logger) gin.HandlerFunc {
+	limiter := ratelimit.NewLimiter(cfg.QueryGuard.RateLimit, cfg.QueryGuard.RateBurst, rateLimitIdle)
+
+	return func(c *gin.Context) {
+		if limiter == nil || !strings.HasPrefix(c.Request.URL.Path, "/api") {
+			c.Next()
+			return
+		}
+
+		client := clientKey(c)
+		allowed, remaining, retryAfter := limiter.Allow(client)
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limiter.Limit()))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			retrySeconds := int(math.Ceil(retryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retrySeconds))
+
+			log.Warn().
+				Str("client", client).
+				Str("path", c.Request.URL.Path).
+				Msg("API 요청 속도 제한 초과")
+
+			abortWithGuard(c, http.StatusTooManyRequests, GuardRateLimit,
+				fmt.Sprintf("요청이 너무 많습니다. %d초 후 다시 시도하세요", retrySeconds),
+				gin.H{"limit": cfg.QueryGuard.RateLimit, "burst": limiter.Limit(), "retryAfter": retrySeconds})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// QueryGuard 미들웨어는 endpoint 그룹 요청의 조회 구간(startTime~endTime, 밀리초)과 limit 매개변수가
+// 설정된 최댓값을 넘으면 400을 반환합니다. 엔드포인트별 설정이 없으면 전체 기본값을 사용합니다.
+// startTime이 없거나 0 이하이면 처음부터 조회하지 않도록 endTime에서 최대 구간을 뺀 값으로 바꿉니다.
+// 숫자가 아닌 값은 컨트롤러의 매개변수 검증에 맡깁니다.
+func QueryGuard(cfg *config.Config, endpoint string) gin.HandlerFunc {
+	maxRangeHours := cfg.QueryGuard.MaxTimeRange
+	if hours, ok := cfg.QueryGuard.TimeRanges[endpoint]; ok {
+		maxRangeHours = hours
+	}
+	maxLimit := cfg.QueryGuard.MaxLimit
+	if limit, ok := cfg.QueryGuard.Limits[endpoint]; ok {
+		maxLimit = limit
+	}
+	maxRange := int64(maxRangeHours) * time.Hour.Milliseconds()
+
+	return func(c *gin.Context) {
+		// gin은 처음 읽은 쿼리를 캐시하므로 startTime을 바꾸기 전에는 c.Query 대신 URL에서 직접 읽음
+		query := c.Request.URL.Query()
+
+		if maxRange > 0 {
+			endTime, err := strconv.ParseInt(query.Get("endTime"), 10, 64)
+			if err != nil || endTime <= 0 {
+				endTime = time.Now().UnixMilli()
+			}
+
+			startValue := query.Get("startTime")
+			startTime, err := strconv.ParseInt(startValue, 10, 64)
+			switch {
+			case startValue == "" || (err == nil && startTime <= 0):
+				query.Set("startTime", strconv.FormatInt(endTime-maxRange, 10))
+				c.Request.URL.RawQuery = query.Encode()
+			case err == nil && endTime-startTime > maxRange:
+				abortWithGuard(c, http.StatusBadRequest, GuardTimeRange,
+					fmt.Sprintf("조회 구간은 최대 %d시간입니다", maxRangeHours),
+					gin.H{"maxTimeRangeHours": maxRangeHours, "requestedMillis": endTime - startTime})
+				return
+			}
+		}
+
+		if maxLimit > 0 {
+			if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > maxLimit {
+				abortWithGuard(c, http.StatusBadRequest, GuardLimit,
+					fmt.Sprintf("limit은 최대 %d입니다", maxLimit),
+					gin.H{"maxLimit": maxLimit, "requested": limit})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// clientKey는 속도 제한에 사용할 클라이언트 식별자를 반환합니다.
+func clientKey(c *gin.Context) string {
+	if principal := RequestPrincipal(c); principal != nil {
+		switch principal.Method {
+		case domain.MethodAPIKey:
+			return "key:" + strconv.FormatInt(principal.KeyID, 10)
+		case domain.MethodStaticKey, domain.MethodJWT:
+			return principal.Method + ":" + principal.TenantID + ":" + principal.Subject
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// abortWithGuard는 어떤 제한에 걸렸는지(guard)와 제한 값(details)을 포함한 오류 응답으로 요청을 중단합니다.
+func abortWithGuard(c *gin.Context, status int, guard, message string, details gin.H) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    status,
+			"message": message,
+			"guard":   guard,
+			"details": details,
+		},
+	})
+}
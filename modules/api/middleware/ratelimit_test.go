@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+func TestQueryGuardTimeRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const hour = int64(time.Hour / time.Millisecond)
+	cfg := &config.Config{}
+	cfg.QueryGuard.MaxTimeRange = 24
+
+	end := time.Now().UnixMilli()
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantStart  string // 컨트롤러가 받는 startTime
+	}{
+		{name: "within range", query: "?startTime=" + strconv.FormatInt(end-hour, 10) + "&endTime=" + strconv.FormatInt(end, 10), wantStatus: http.StatusOK, wantStart: strconv.FormatInt(end-hour, 10)},
+		{name: "too long", query: "?startTime=" + strconv.FormatInt(end-25*hour, 10) + "&endTime=" + strconv.FormatInt(end, 10), wantStatus: http.StatusBadRequest},
+		{name: "missing start", query: "?endTime=" + strconv.FormatInt(end, 10), wantStatus: http.StatusOK, wantStart: strconv.FormatInt(end-24*hour, 10)},
+		{name: "zero start", query: "?startTime=0&endTime=" + strconv.FormatInt(end, 10), wantStatus: http.StatusOK, wantStart: strconv.FormatInt(end-24*hour, 10)},
+		{name: "negative start", query: "?startTime=-5&endTime=" + strconv.FormatInt(end, 10), wantStatus: http.StatusOK, wantStart: strconv.FormatInt(end-24*hour, 10)},
+		{name: "invalid start is left to the controller", query: "?startTime=abc", wantStatus: http.StatusOK, wantStart: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotStart string
+			router := gin.New()
+			router.GET("/", QueryGuard(cfg, "traces"), func(c *gin.Context) {
+				gotStart = c.Query("startTime")
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && gotStart != tt.wantStart {
+				t.Errorf("startTime = %q, want %q", gotStart, tt.wantStart)
+			}
+		})
+	}
+}
+
+// 신뢰하는 프록시를 거치지 않은 요청은 X-Forwarded-For를 바꿔도 같은 클라이언트로 제한되어야 함
+func TestRateLimitIgnoresForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		wantLimited    bool
+	}{
+		{name: "no trusted proxies", wantLimited: true},
+		{name: "trusted proxy", trustedProxies: []string{"192.0.2.1"}, wantLimited: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.QueryGuard.RateLimit = 1
+			cfg.QueryGuard.RateBurst = 1
+
+			router := gin.New()
+			if err := router.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+			router.Use(RateLimit(cfg, logger.GetLogger()))
+			router.GET("/api/x", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			var last int
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/api/x", nil)
+				req.RemoteAddr = "192.0.2.1:1234"
+				req.Header.Set("X-Forwarded-For", "203.0.113."+strconv.Itoa(i+1))
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				last = w.Code
+			}
+
+			if limited := last == http.StatusTooManyRequests; limited != tt.wantLimited {
+				t.Errorf("second request status = %d, want limited %v", last, tt.wantLimited)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 자체 텔레메트리 계측 이름
+const instrumentationName = "github.com/seongpil0948/otel-kafka-pg/modules/api/middleware"
+
+// Tracing 미들웨어는 API 요청마다 서버 스팬을 생성하고 처리 시간을 메트릭으로 기록합니다.
+// 요청의 traceparent 헤더가 있으면 호출자의 트레이스를 이어받으며, 요청 컨텍스트에 스팬을 넣으므로
+// 핸들러에서 실행하는 SQL, 캐시 호출이 이 스팬의 하위 스팬이 됩니다. 다른 미들웨어보다 먼저 등록해야 합니다.
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer(instrumentationName)
+	requestDuration, _ := otel.Meter(instrumentationName).Float64Histogram("http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("API 요청 처리 시간"))
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		// 경로 매개변수 값 대신 라우트 패턴으로 스팬 이름을 지어 카디널리티를 제한
+		route := c.FullPath()
+		spanName := c.Request.Method
+		if route != "" {
+			spanName += " " + route
+		}
+
+		ctx, span := tracer.Start(ctx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(c.Request.URL.Path),
+				semconv.ClientAddress(c.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		))
+	}
+}
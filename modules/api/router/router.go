@@ -5,19 +5,27 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	alertService "github.com/seongpil0948/otel-kafka-pg/modules/alert/service"
 	"github.com/seongpil0948/otel-kafka-pg/modules/api/controller"
 	"github.com/seongpil0948/otel-kafka-pg/modules/api/middleware"
+	authDomain "github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	authService "github.com/seongpil0948/otel-kafka-pg/modules/auth/service"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/cache"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/stream"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
 	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 	traceService "github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // SetupRouter는 API 라우터 및 미들웨어를 설정합니다
-func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceService.TraceService, logService logService.LogService, cacheService cache.CacheService) *gin.Engine {
+func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceService.TraceService, logService logService.LogService, cacheService cache.CacheService, traceHub *stream.Hub[traceDomain.TraceItem], logHub *stream.Hub[logDomain.LogItem], alertService alertService.AlertService, authService authService.AuthService, probes *health.Probes) *gin.Engine {
 	// 환경에 따른 Gin 모드 설정
 	if cfg.Logger.IsDev {
 		gin.SetMode(gin.DebugMode)
@@ -28,7 +36,13 @@ func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceServic
 	// 라우터 생성
 	router := gin.New()
 
-	// 미들웨어 설정
+	// 신뢰하는 프록시가 보낸 X-Forwarded-For만 클라이언트 IP로 사용 (속도 제한이 위조한 헤더로 우회되지 않도록)
+	if err := router.SetTrustedProxies(cfg.API.TrustedProxies); err != nil {
+		log.Error().Err(err).Strs("trusted_proxies", cfg.API.TrustedProxies).Msg("신뢰하는 프록시 설정 실패")
+	}
+
+	// 미들웨어 설정 (자체 텔레메트리 스팬이 패닉 복구 후 상태 코드까지 기록하도록 가장 먼저 등록)
+	router.Use(middleware.Tracing())
 	router.Use(middleware.ErrorHandler(log))
 	router.Use(middleware.RequestLogger(log))
 	router.Use(gin.Recovery())
@@ -37,10 +51,29 @@ func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceServic
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = cfg.API.AllowedOrigins
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "traceparent", "tracestate", middleware.APIKeyHeader}
+	corsConfig.ExposeHeaders = []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining"}
 	corsConfig.AllowCredentials = cfg.API.AllowCredentials
 	router.Use(cors.New(corsConfig))
 
+	// 인증 미들웨어 (요청 테넌트를 결정하므로 캐시 키에 테넌트가 포함되도록 캐싱 미들웨어보다 먼저 등록)
+	log.Info().
+		Bool("required", authService.Required()).
+		Bool("jwt", authService.TokenEnabled()).
+		Int("static_api_keys", len(cfg.Tenancy.APIKeys)).
+		Msg("인증 미들웨어 활성화")
+	router.Use(middleware.Auth(authService, log))
+
+	// 클라이언트별 요청 속도 제한 (요청 주체로 클라이언트를 구분하므로 인증 미들웨어 다음에 등록)
+	log.Info().
+		Int("rate_limit", cfg.QueryGuard.RateLimit).
+		Int("rate_burst", cfg.QueryGuard.RateBurst).
+		Int("max_time_range_hours", cfg.QueryGuard.MaxTimeRange).
+		Int("max_limit", cfg.QueryGuard.MaxLimit).
+		Int("timeout_ms", cfg.QueryGuard.Timeout).
+		Msg("요청 속도 제한 미들웨어 활성화")
+	router.Use(middleware.RateLimit(cfg, log))
+
 	if cfg.Redis.EnableCache && cacheService.IsEnabled() {
 		log.Info().Int("ttl_seconds", cfg.Redis.TTL).Msg("캐싱 미들웨어 활성화")
 		router.Use(middleware.CachingMiddleware(cacheService, log))
@@ -49,8 +82,12 @@ func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceServic
 		log.Info().Msg("캐싱 미들웨어 비활성화")
 	}
 	// 컨트롤러 생성
-	traceController := controller.NewTraceController(traceService, log)
+	traceController := controller.NewTraceController(traceService, logService, log)
 	logController := controller.NewLogController(logService, log)
+	tailController := controller.NewTailController(traceHub, logHub, cfg.Tail.RateLimit, log)
+	alertController := controller.NewAlertController(alertService, log)
+	authController := controller.NewAuthController(authService, log)
+	configController := controller.NewConfigController(log)
 
 	// 기본 경로 설정
 	router.GET("/", func(c *gin.Context) {
@@ -59,42 +96,87 @@ func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceServic
 		})
 	})
 
-	// 헬스 체크 엔드포인트
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "healthy",
-			"version": "1.0.0",
-		})
-	})
+	// 헬스 체크, 상태 점검, 메트릭 엔드포인트
+	setupOpsRoutes(router, cfg, log, probes)
+
+	// 역할별 권한 검사
+	requireEditor := middleware.RequireRole(authDomain.RoleEditor)
 
-	// API 그룹
-	api := router.Group("/api")
+	// API 그룹 (모든 엔드포인트는 viewer 이상)
+	api := router.Group("/api", middleware.RequireRole(authDomain.RoleViewer))
 	{
+		api.GET("/auth/me", authController.WhoAmI)
+
 		// 텔레메트리 API 그룹
 		telemetry := api.Group("/telemetry")
 		{
-			// 트레이스 관련 엔드포인트
-			traces := telemetry.Group("/traces")
+			// 실시간 스트림 엔드포인트 (연결이 오래 유지되므로 요청 처리 시간 제한 대상에서 제외)
+			telemetry.GET("/traces/tail", tailController.TailTraces)
+			telemetry.GET("/logs/tail", tailController.TailLogs)
+
+			// 트레이스 관련 엔드포인트 (엔드포인트 그룹별 조회 구간, limit, 처리 시간 제한)
+			traces := telemetry.Group("/traces", middleware.QueryGuard(cfg, "traces"), middleware.Deadline(cfg, "traces"))
 			{
 				traces.GET("", traceController.QueryTraces)
+				traces.GET("/compare", traceController.CompareTraces)
 				traces.GET("/:traceId", traceController.GetTraceByID)
 				traces.GET("/services", traceController.GetServices)
 			}
 
 			// 로그 관련 엔드포인트
-			logs := telemetry.Group("/logs")
+			logs := telemetry.Group("/logs", middleware.QueryGuard(cfg, "logs"), middleware.Deadline(cfg, "logs"))
 			{
 				logs.GET("", logController.QueryLogs)
 				logs.GET("/trace/:traceId", logController.GetLogsByTraceID)
 				logs.GET("/summary", logController.GetLogSummary)
+				logs.GET("/histogram", logController.GetLogHistogram)
+				logs.GET("/patterns", logController.GetLogPatterns)
+			}
+
+			// 서비스 관련 엔드포인트
+			services := telemetry.Group("/services", middleware.QueryGuard(cfg, "services"), middleware.Deadline(cfg, "services"))
+			{
+				services.GET("/graph", traceController.GetServiceGraph)
+				services.GET("/:service/operations", traceController.GetServiceOperations)
 			}
 
 			// 메트릭 관련 엔드포인트
-			metrics := telemetry.Group("/metrics")
+			metrics := telemetry.Group("/metrics", middleware.QueryGuard(cfg, "metrics"), middleware.Deadline(cfg, "metrics"))
 			{
 				metrics.GET("/services", traceController.GetServiceMetrics)
+				metrics.GET("/services/:service/timeseries", traceController.GetServiceTimeSeries)
 			}
 		}
+
+		// 알림 API 그룹 (변경은 editor 이상)
+		alerts := api.Group("/alerts", middleware.QueryGuard(cfg, "alerts"))
+		{
+			alerts.GET("", alertController.GetAlerts)
+			alerts.GET("/history", alertController.GetAlertHistory)
+			alerts.GET("/rules", alertController.ListRules)
+			alerts.POST("/rules", requireEditor, alertController.CreateRule)
+			alerts.GET("/rules/:id", alertController.GetRule)
+			alerts.PUT("/rules/:id", requireEditor, alertController.UpdateRule)
+			alerts.DELETE("/rules/:id", requireEditor, alertController.DeleteRule)
+			alerts.GET("/channels", alertController.ListChannels)
+			alerts.POST("/channels", requireEditor, alertController.CreateChannel)
+			alerts.GET("/channels/:id", alertController.GetChannel)
+			alerts.PUT("/channels/:id", requireEditor, alertController.UpdateChannel)
+			alerts.DELETE("/channels/:id", requireEditor, alertController.DeleteChannel)
+			alerts.POST("/channels/:id/test", requireEditor, alertController.TestChannel)
+			alerts.GET("/silences", alertController.ListSilences)
+			alerts.POST("/silences", requireEditor, alertController.CreateSilence)
+			alerts.DELETE("/silences/:id", requireEditor, alertController.DeleteSilence)
+		}
+
+		// 관리 API 그룹 (admin 전용)
+		admin := api.Group("/admin", middleware.RequireRole(authDomain.RoleAdmin))
+		{
+			admin.GET("/keys", authController.ListKeys)
+			admin.POST("/keys", authController.IssueKey)
+			admin.DELETE("/keys/:id", authController.RevokeKey)
+			admin.GET("/config", configController.GetConfig)
+		}
 	}
 
 	// Swagger 문서화 설정
@@ -106,6 +188,46 @@ func SetupRouter(cfg *config.Config, log logger.Logger, traceService traceServic
 	return router
 }
 
+// SetupOpsRouter는 API를 제공하지 않는 실행 모드(ingest, cleanup)에서 사용할 운영용 라우터를 설정합니다.
+// 헬스 체크, /livez, /readyz, 메트릭 엔드포인트만 제공합니다.
+func SetupOpsRouter(cfg *config.Config, log logger.Logger, probes *health.Probes) *gin.Engine {
+	if cfg.Logger.IsDev {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	setupOpsRoutes(router, cfg, log, probes)
+
+	return router
+}
+
+// setupOpsRoutes는 헬스 체크, 상태 점검, Prometheus 메트릭 엔드포인트를 등록합니다.
+func setupOpsRoutes(router *gin.Engine, cfg *config.Config, log logger.Logger, probes *health.Probes) {
+	healthController := controller.NewHealthController(probes, log)
+
+	// 헬스 체크 엔드포인트
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"status":  "healthy",
+			"version": "1.0.0",
+		})
+	})
+
+	// 활성 상태, 준비 상태 점검 엔드포인트 (구성 요소별 점검 보고서, 실패 시 503)
+	router.GET("/livez", healthController.Livez)
+	router.GET("/readyz", healthController.Readyz)
+
+	// Prometheus 메트릭 엔드포인트 (/api 밖에 두어 인증과 속도 제한을 적용하지 않음)
+	if cfg.Metrics.Enabled {
+		log.Info().Str("path", cfg.Metrics.Path).Msg("Prometheus 메트릭 엔드포인트 활성화")
+		router.GET(cfg.Metrics.Path, gin.WrapH(promhttp.Handler()))
+	}
+}
+
 // StartServer는 API 서버를 시작합니다
 func StartServer(cfg *config.Config, router *gin.Engine, log logger.Logger) error {
 	addr := fmt.Sprintf("%s:%d", cfg.API.Host, cfg.API.Port)
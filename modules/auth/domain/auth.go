@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Role은 API 접근 역할입니다. 상위 역할은 하위 역할의 권한을 모두 포함합니다.
+type Role string
+
+const (
+	RoleViewer Role = "viewer" // 텔레메트리, 알림 조회
+	RoleEditor Role = "editor" // 알림 규칙, 채널, 사일런스 변경
+	RoleAdmin  Role = "admin"  // API 키 발급/폐기 등 관리 작업
+)
+
+// 인증 방식
+const (
+	MethodAnonymous = "anonymous" // 인증이 비활성화된 경우
+	MethodStaticKey = "static"    // 설정 파일의 API 키
+	MethodAPIKey    = "api_key"   // 데이터베이스에 저장된 API 키
+	MethodJWT       = "jwt"       // Bearer JWT
+)
+
+// ErrKeyNotFound는 요청한 API 키가 존재하지 않을 때 반환됩니다.
+var ErrKeyNotFound = errors.New("api key not found")
+
+// rank는 역할 간 포함 관계를 비교하기 위한 순위입니다. 알 수 없는 역할은 0입니다.
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Valid는 알려진 역할인지 확인합니다.
+func (r Role) Valid() bool {
+	return r.rank() > 0
+}
+
+// Allows는 이 역할이 required 역할의 권한을 가지는지 확인합니다.
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && r.rank() >= required.rank()
+}
+
+// ParseRole은 대소문자를 무시하고 역할 이름을 해석합니다. 알 수 없는 이름이면 빈 역할입니다.
+func ParseRole(name string) Role {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	if !role.Valid() {
+		return ""
+	}
+	return role
+}
+
+// Principal은 인증된 요청 주체입니다.
+type Principal struct {
+	Subject  string `json:"subject"`         // API 키 이름 또는 JWT sub 클레임
+	TenantID string `json:"tenantId"`        // 요청이 접근할 수 있는 테넌트
+	Role     Role   `json:"role"`            // 부여된 역할
+	Method   string `json:"method"`          // 인증 방식
+	KeyID    int64  `json:"keyId,omitempty"` // 데이터베이스 API 키 ID
+}
+
+// APIKey는 발급된 API 키의 메타데이터입니다. 평문 키는 발급 시 한 번만 반환하고 저장하지 않습니다.
+type APIKey struct {
+	ID         int64  `json:"id"`
+	TenantID   string `json:"-"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"` // 키 식별용 앞부분
+	Role       Role   `json:"role"`
+	CreatedBy  string `json:"createdBy,omitempty"`
+	CreatedAt  int64  `json:"createdAt"`
+	ExpiresAt  int64  `json:"expiresAt,omitempty"`  // 0이면 만료 없음
+	LastUsedAt int64  `json:"lastUsedAt,omitempty"` // 마지막 인증 시각
+	RevokedAt  int64  `json:"revokedAt,omitempty"`  // 0이면 유효
+}
+
+// Active는 now(Unix 밀리초) 기준으로 폐기되지 않았고 만료되지 않은 키인지 확인합니다.
+func (k *APIKey) Active(now int64) bool {
+	if k.RevokedAt != 0 {
+		return false
+	}
+	return k.ExpiresAt == 0 || now < k.ExpiresAt
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+// AuthRepository는 API 키 저장소 인터페이스입니다.
+type AuthRepository interface {
+	// API 키 관리 (keyHash는 평문 키의 SHA-256 16진수 문자열)
+	CreateKey(key *domain.APIKey, keyHash string) error
+	GetKeyByHash(keyHash string) (*domain.APIKey, error)
+	ListKeys(tenantID string) ([]domain.APIKey, error)
+	RevokeKey(tenantID string, id int64, revokedAt int64) error
+
+	// 마지막 사용 시각 갱신
+	TouchKey(id int64, usedAt int64) error
+}
+
+// PostgresAuthRepository는 PostgreSQL API 키 저장소 구현체입니다.
+type PostgresAuthRepository struct {
+	db  db.Database
+	log logger.Logger
+}
+
+// NewAuthRepository는 새 API 키 저장소 인스턴스를 생성합니다.
+func NewAuthRepository(database db.Database) AuthRepository {
+	return &PostgresAuthRepository{
+		db:  database,
+		log: logger.GetLogger(),
+	}
+}
+
+// API 키 조회 컬럼 (scanKey와 순서를 맞춰야 함)
+const keyColumns = `id, tenant_id, name, prefix, role, COALESCE(created_by, ''), created_at,
+	COALESCE(expires_at, 0), COALESCE(last_used_at, 0), COALESCE(revoked_at, 0)`
+
+// CreateKey는 API 키 해시와 메타데이터를 저장하고 생성된 ID를 key에 채웁니다.
+func (r *PostgresAuthRepository) CreateKey(key *domain.APIKey, keyHash string) error {
+	err := r.db.QueryRow(
+		`INSERT INTO api_keys(tenant_id, name, key_hash, prefix, role, created_by, created_at, expires_at)
+		VALUES($1, $2, $3, $4, $5, NULLIF($6, ''), $7, NULLIF($8, 0))
+		RETURNING id`,
+		tenant.OrDefault(key.TenantID),
+		key.Name,
+		keyHash,
+		key.Prefix,
+		string(key.Role),
+		key.CreatedBy,
+		key.CreatedAt,
+		key.ExpiresAt,
+	).Scan(&key.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetKeyByHash는 해시로 API 키를 조회합니다. 키가 없으면 domain.ErrKeyNotFound를 반환합니다.
+// 폐기되거나 만료된 키도 반환하므로 호출자가 Active로 확인해야 합니다.
+func (r *PostgresAuthRepository) GetKeyByHash(keyHash string) (*domain.APIKey, error) {
+	row := r.db.QueryRow(`SELECT `+keyColumns+` FROM api_keys WHERE key_hash = $1`, keyHash)
+
+	key, err := scanKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListKeys는 테넌트의 API 키를 최근 발급 순으로 조회합니다.
+func (r *PostgresAuthRepository) ListKeys(tenantID string) ([]domain.APIKey, error) {
+	rows, err := r.db.Query(
+		`SELECT `+keyColumns+` FROM api_keys WHERE tenant_id = $1 ORDER BY created_at DESC, id DESC`,
+		tenant.OrDefault(tenantID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []domain.APIKey{}
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key row: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api key rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeKey는 API 키를 폐기합니다. 키가 없거나 이미 폐기되었으면 domain.ErrKeyNotFound를 반환합니다.
+func (r *PostgresAuthRepository) RevokeKey(tenantID string, id int64, revokedAt int64) error {
+	result, err := r.db.Execute(
+		`UPDATE api_keys SET revoked_at = $3 WHERE id = $1 AND tenant_id = $2 AND revoked_at IS NULL`,
+		id,
+		tenant.OrDefault(tenantID),
+		revokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchKey는 API 키의 마지막 사용 시각을 갱신합니다.
+func (r *PostgresAuthRepository) TouchKey(id int64, usedAt int64) error {
+	if _, err := r.db.Execute(`UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, id, usedAt); err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanKey는 keyColumns 순서의 행을 API 키로 변환합니다.
+func scanKey(row scanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var role string
+
+	if err := row.Scan(
+		&key.ID,
+		&key.TenantID,
+		&key.Name,
+		&key.Prefix,
+		&role,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	key.Role = domain.Role(role)
+	return &key, nil
+}
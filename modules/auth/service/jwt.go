@@ -0,0 +1,406 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// JWT 검증 제한
+const (
+	clockLeeway        = 60 * time.Second // exp, nbf 비교 시 허용하는 시계 오차
+	minForcedRefresh   = 30 * time.Second // 모르는 kid로 JWKS를 다시 가져오는 최소 간격
+	jwksRequestTimeout = 10 * time.Second
+	maxJWKSSize        = 1 << 20
+	minRSAKeyBits      = 2048 // 이보다 짧은 RSA 키의 서명은 거부
+)
+
+// jwtVerifier는 JWKS 공개 키로 JWT 서명과 표준 클레임을 검증합니다.
+// JWKS는 파일 경로 또는 http(s) URL에서 읽으며 refresh 주기마다, 그리고 모르는 kid를 만나면 다시 읽습니다.
+type jwtVerifier struct {
+	source   string
+	refresh  time.Duration
+	issuer   string
+	audience string
+	client   *http.Client
+	now      func() time.Time // 현재 시각 (테스트에서 고정)
+
+	keys        map[string]crypto.PublicKey // kid -> 공개 키
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	mu          sync.Mutex
+}
+
+// newJWTVerifier는 설정의 JWKS로 jwtVerifier를 생성합니다. JWKS가 없으면 nil입니다.
+func newJWTVerifier(cfg *config.Config) *jwtVerifier {
+	if cfg.Auth.JWKS == "" {
+		return nil
+	}
+
+	refresh := time.Duration(cfg.Auth.JWKSRefresh) * time.Second
+	if refresh <= 0 {
+		refresh = time.Hour
+	}
+
+	return &jwtVerifier{
+		source:   cfg.Auth.JWKS,
+		refresh:  refresh,
+		issuer:   cfg.Auth.JWTIssuer,
+		audience: cfg.Auth.JWTAudience,
+		client:   &http.Client{Timeout: jwksRequestTimeout},
+		now:      time.Now,
+	}
+}
+
+// jwtHeader는 검증에 필요한 JWT 헤더 필드입니다.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// verify는 토큰 서명과 exp, nbf, iss, aud 클레임을 검증하고 클레임을 반환합니다. exp가 없는 토큰은 거부합니다.
+func (v *jwtVerifier) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid token header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature encoding: %w", err)
+	}
+
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+
+	if err := v.validateClaims(claims, v.now()); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// validateClaims는 만료, 활성 시각, 발급자, 대상 클레임을 확인합니다.
+// 만료되지 않는 토큰은 유출되면 막을 방법이 없으므로 exp 클레임이 반드시 있어야 합니다.
+func (v *jwtVerifier) validateClaims(claims map[string]interface{}, now time.Time) error {
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("token has no exp claim")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(clockLeeway)) {
+		return errors.New("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if now.Add(clockLeeway).Before(time.Unix(int64(nbf), 0)) {
+			return errors.New("token not yet valid")
+		}
+	}
+
+	if v.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.issuer {
+			return fmt.Errorf("unexpected issuer %q", iss)
+		}
+	}
+
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return errors.New("token audience mismatch")
+	}
+
+	return nil
+}
+
+// key는 kid에 해당하는 공개 키를 반환합니다.
+// JWKS가 오래되었거나 kid를 모르면 다시 읽습니다. kid가 없는 토큰은 JWKS에 키가 하나일 때만 허용합니다.
+func (v *jwtVerifier) key(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	if v.keys == nil || now.Sub(v.fetchedAt) >= v.refresh {
+		if err := v.load(now); err != nil && v.keys == nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+
+	if now.Sub(v.attemptedAt) >= minForcedRefresh {
+		if err := v.load(now); err != nil {
+			return nil, err
+		}
+		if key, ok := v.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup은 현재 JWKS에서 kid의 공개 키를 찾습니다. 호출자가 mu를 잡고 있어야 합니다.
+func (v *jwtVerifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// load는 JWKS를 읽어 공개 키 목록을 교체합니다. 실패하면 기존 키를 유지합니다. 호출자가 mu를 잡고 있어야 합니다.
+func (v *jwtVerifier) load(now time.Time) error {
+	v.attemptedAt = now
+
+	data, err := v.read()
+	if err != nil {
+		return fmt.Errorf("failed to read jwks: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	v.keys = keys
+	v.fetchedAt = now
+	return nil
+}
+
+// read는 JWKS 원문을 파일 또는 URL에서 읽습니다.
+func (v *jwtVerifier) read() ([]byte, error) {
+	if !strings.HasPrefix(v.source, "http://") && !strings.HasPrefix(v.source, "https://") {
+		return os.ReadFile(v.source)
+	}
+
+	resp, err := v.client.Get(v.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+// jsonWebKey는 JWKS의 키 항목입니다 (RSA, EC 공개 키만 지원).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS는 JWKS 문서에서 서명용 RSA, EC 공개 키를 읽습니다. 지원하지 않는 키는 건너뜁니다.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key crypto.PublicKey
+		var err error
+		switch jwk.Kty {
+		case "RSA":
+			key, err = jwk.rsaKey()
+		case "EC":
+			key, err = jwk.ecKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable signing keys")
+	}
+	return keys, nil
+}
+
+// rsaKey는 n, e 값으로 RSA 공개 키를 만듭니다.
+func (k jsonWebKey) rsaKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid rsa exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// ecKey는 crv, x, y 값으로 ECDSA 공개 키를 만듭니다.
+func (k jsonWebKey) ecKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// verifySignature는 alg에 맞는 방식으로 서명을 검증합니다. 대칭 키(HS*)와 none은 허용하지 않습니다.
+// 키 종류가 alg와 맞아야 하며, ES*는 alg가 정한 곡선(ES256: P-256, ES384: P-384, ES512: P-521)의 키만 허용합니다.
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	hasher := hash.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %q", alg)
+		}
+		if rsaKey.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("rsa key is shorter than %d bits", minRSAKeyBits)
+		}
+		if alg[0] == 'P' {
+			// RFC 7518 3.5: salt 길이는 해시 길이와 같아야 함
+			return rsa.VerifyPSS(rsaKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+	default:
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %q", alg)
+		}
+		if ecKey.Curve.Params().Name != ecCurves[alg] {
+			return fmt.Errorf("curve %s does not match algorithm %q", ecKey.Curve.Params().Name, alg)
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid ecdsa signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errors.New("invalid ecdsa signature")
+		}
+		return nil
+	}
+}
+
+// ecCurves는 ECDSA 알고리즘별로 허용하는 곡선 이름입니다.
+var ecCurves = map[string]string{
+	"ES256": "P-256",
+	"ES384": "P-384",
+	"ES512": "P-521",
+}
+
+// hasAudience는 aud 클레임(문자열 또는 배열)에 audience가 있는지 확인합니다.
+func hasAudience(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodeSegment는 base64url로 인코딩된 JSON 구간을 해석합니다.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodeBigInt는 base64url로 인코딩된 big-endian 정수를 해석합니다.
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+// 테스트 기준 시각
+var testNow = time.Unix(1_700_000_000, 0)
+
+// loadPrivateKey는 testdata의 PKCS#8 개인 키를 읽습니다.
+func loadPrivateKey(t *testing.T, name string) crypto.Signer {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.(crypto.Signer)
+}
+
+// signToken은 헤더와 클레임으로 JWT를 만들어 alg에 맞게 서명합니다.
+func signToken(t *testing.T, key crypto.Signer, header map[string]interface{}, claims map[string]interface{}, pssSaltLength int) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(header) + "." + encode(claims)
+
+	alg, _ := header["alg"].(string)
+	hash := crypto.SHA256
+	switch {
+	case strings.HasSuffix(alg, "384"):
+		hash = crypto.SHA384
+	case strings.HasSuffix(alg, "512"):
+		hash = crypto.SHA512
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if strings.HasPrefix(alg, "PS") {
+			signature, err = rsa.SignPSS(rand.Reader, k, hash, digest, &rsa.PSSOptions{SaltLength: pssSaltLength})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		}
+	case *ecdsa.PrivateKey:
+		var r, s []byte
+		size := (k.Curve.Params().BitSize + 7) / 8
+		rInt, sInt, signErr := ecdsa.Sign(rand.Reader, k, digest)
+		err = signErr
+		if err == nil {
+			r, s = make([]byte, size), make([]byte, size)
+			rInt.FillBytes(r)
+			sInt.FillBytes(s)
+			signature = append(r, s...)
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// newTestVerifier는 testdata JWKS 파일과 고정 시각으로 검증기를 만듭니다.
+func newTestVerifier(jwks, issuer, audience string) *jwtVerifier {
+	cfg := &config.Config{}
+	cfg.Auth.JWKS = "testdata/" + jwks
+	cfg.Auth.JWTIssuer = issuer
+	cfg.Auth.JWTAudience = audience
+
+	v := newJWTVerifier(cfg)
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestJWTVerify(t *testing.T) {
+	rsaKey := loadPrivateKey(t, "rsa.pem")
+	weakRSAKey := loadPrivateKey(t, "rsa1024.pem")
+	ec256Key := loadPrivateKey(t, "ec256.pem")
+	ec384Key := loadPrivateKey(t, "ec384.pem")
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "alice",
+			"iss": "https://issuer.example",
+			"aud": "otel-api",
+			"exp": testNow.Add(time.Hour).Unix(),
+		}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		jwks    string
+		key     crypto.Signer
+		header  map[string]interface{}
+		claims  map[string]interface{}
+		salt    int
+		tamper  bool
+		wantErr string
+	}{
+		{name: "RS256", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: valid()},
+		{name: "RS512", key: rsaKey, header: map[string]interface{}{"alg": "RS512", "kid": "rsa"}, claims: valid()},
+		{name: "PS256", key: rsaKey, header: map[string]interface{}{"alg": "PS256", "kid": "rsa"}, claims: valid(), salt: rsa.PSSSaltLengthEqualsHash},
+		{name: "PS256 salt length mismatch", key: rsaKey, header: map[string]interface{}{"alg": "PS256", "kid": "rsa"}, claims: valid(), salt: 20, wantErr: "verification error"},
+		{name: "ES256", key: ec256Key, header: map[string]interface{}{"alg": "ES256", "kid": "ec-256"}, claims: valid()},
+		{name: "ES384", key: ec384Key, header: map[string]interface{}{"alg": "ES384", "kid": "ec-384"}, claims: valid()},
+		{name: "ES256 with P-384 key", key: ec384Key, header: map[string]interface{}{"alg": "ES256", "kid": "ec-384"}, claims: valid(), wantErr: "does not match algorithm"},
+		{name: "RS256 with EC key", key: ec256Key, header: map[string]interface{}{"alg": "RS256", "kid": "ec-256"}, claims: valid(), wantErr: "key type does not match"},
+		{name: "HS256", key: rsaKey, header: map[string]interface{}{"alg": "HS256", "kid": "rsa"}, claims: valid(), wantErr: "unsupported signing algorithm"},
+		{name: "none", key: rsaKey, header: map[string]interface{}{"alg": "none", "kid": "rsa"}, claims: valid(), wantErr: "unsupported signing algorithm"},
+		{name: "weak RSA key", key: weakRSAKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa-1024"}, claims: valid(), wantErr: "shorter than"},
+		{name: "tampered claims", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: valid(), tamper: true, wantErr: "verification error"},
+		{name: "unknown kid", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "other"}, claims: valid(), wantErr: "unknown signing key"},
+		{name: "no kid with several keys", key: rsaKey, header: map[string]interface{}{"alg": "RS256"}, claims: valid(), wantErr: "unknown signing key"},
+		{name: "no kid with single key", jwks: "jwks-single.json", key: rsaKey, header: map[string]interface{}{"alg": "RS256"}, claims: valid()},
+		{name: "missing exp", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("exp", nil), wantErr: "no exp claim"},
+		{name: "expired", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("exp", testNow.Add(-2*clockLeeway).Unix()), wantErr: "token expired"},
+		{name: "expired within leeway", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("exp", testNow.Add(-clockLeeway/2).Unix())},
+		{name: "not yet valid", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("nbf", testNow.Add(2*clockLeeway).Unix()), wantErr: "not yet valid"},
+		{name: "wrong issuer", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("iss", "https://evil.example"), wantErr: "unexpected issuer"},
+		{name: "wrong audience", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("aud", "other-api"), wantErr: "audience mismatch"},
+		{name: "audience array", key: rsaKey, header: map[string]interface{}{"alg": "RS256", "kid": "rsa"}, claims: with("aud", []string{"other-api", "otel-api"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwks := tt.jwks
+			if jwks == "" {
+				jwks = "jwks.json"
+			}
+			v := newTestVerifier(jwks, "https://issuer.example", "otel-api")
+
+			token := signToken(t, tt.key, tt.header, tt.claims, tt.salt)
+			if tt.tamper {
+				parts := strings.Split(token, ".")
+				claims := valid()
+				claims["sub"] = "mallory"
+				data, _ := json.Marshal(claims)
+				parts[1] = base64.RawURLEncoding.EncodeToString(data)
+				token = strings.Join(parts, ".")
+			}
+
+			claims, err := v.verify(token)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("verify() error = %v", err)
+				}
+				if claims["sub"] != "alice" {
+					t.Fatalf("verify() sub = %v", claims["sub"])
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("verify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseJWKSErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		jwks    string
+		wantErr string
+	}{
+		{name: "invalid json", jwks: `{`, wantErr: "failed to parse jwks"},
+		{name: "no keys", jwks: `{"keys":[]}`, wantErr: "no usable signing keys"},
+		{name: "encryption key only", jwks: `{"keys":[{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"}]}`, wantErr: "no usable signing keys"},
+		{name: "unsupported curve", jwks: `{"keys":[{"kty":"EC","kid":"ec","crv":"P-192","x":"AQ","y":"AQ"}]}`, wantErr: "unsupported curve"},
+		{name: "point not on curve", jwks: `{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"AQ","y":"AQ"}]}`, wantErr: "not on curve"},
+		{name: "invalid exponent", jwks: `{"keys":[{"kty":"RSA","kid":"rsa","n":"AQAB","e":"AQ"}]}`, wantErr: "invalid rsa exponent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJWKS([]byte(tt.jwks))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("parseJWKS() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticateTokenTenant(t *testing.T) {
+	rsaKey := loadPrivateKey(t, "rsa.pem")
+	header := map[string]interface{}{"alg": "RS256", "kid": "rsa"}
+
+	tests := []struct {
+		name        string
+		multiTenant bool
+		claims      map[string]interface{}
+		wantTenant  string
+		wantRole    domain.Role
+		wantErr     bool
+	}{
+		{name: "tenant claim", claims: map[string]interface{}{"tenant": "acme", "role": "editor"}, wantTenant: "acme", wantRole: domain.RoleEditor},
+		{name: "no tenant claim single tenant", claims: map[string]interface{}{}, wantTenant: tenant.Default, wantRole: domain.RoleViewer},
+		{name: "no tenant claim multi tenant", multiTenant: true, claims: map[string]interface{}{}, wantErr: true},
+		{name: "tenant claim multi tenant", multiTenant: true, claims: map[string]interface{}{"tenant": "acme"}, wantTenant: "acme", wantRole: domain.RoleViewer},
+		{name: "invalid tenant claim", claims: map[string]interface{}{"tenant": "acme/foo"}, wantErr: true},
+		{name: "highest role from list", claims: map[string]interface{}{"role": []string{"viewer", "admin"}}, wantTenant: tenant.Default, wantRole: domain.RoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Auth.RoleClaim = "role"
+			cfg.Auth.TenantClaim = "tenant"
+			cfg.Auth.DefaultRole = "viewer"
+			if tt.multiTenant {
+				cfg.Tenancy.APIKeys = map[string]string{"key": "acme"}
+			}
+
+			s := &AuthServiceImpl{
+				config: cfg,
+				log:    logger.GetLogger(),
+				jwt:    newTestVerifier("jwks.json", "", ""),
+			}
+
+			claims := map[string]interface{}{"sub": "alice", "exp": testNow.Add(time.Hour).Unix()}
+			for k, v := range tt.claims {
+				claims[k] = v
+			}
+
+			principal, err := s.AuthenticateToken(signToken(t, rsaKey, header, claims, 0))
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("AuthenticateToken() error = %v, want ErrUnauthenticated", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AuthenticateToken() error = %v", err)
+			}
+			if principal.TenantID != tt.wantTenant || principal.Role != tt.wantRole || principal.Subject != "alice" {
+				t.Fatalf("AuthenticateToken() = %+v, want tenant %q role %q", principal, tt.wantTenant, tt.wantRole)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/auth/repository"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+)
+
+var (
+	// ErrUnauthenticated는 자격 증명이 없거나 유효하지 않을 때 반환됩니다.
+	ErrUnauthenticated = errors.New("invalid credentials")
+	// ErrInvalidKey는 API 키 발급 요청이 올바르지 않을 때 반환됩니다.
+	ErrInvalidKey = errors.New("invalid api key request")
+)
+
+// API 키 형식
+const (
+	keyPrefix      = "okp_" // 발급 키 접두사 (로그나 설정에서 키를 식별하기 위함)
+	keyRandomBytes = 32
+	keyPrefixLen   = len(keyPrefix) + 8 // 메타데이터로 보관하는 키 앞부분 길이
+	maxKeyNameLen  = 255
+)
+
+// AuthService는 API 요청 인증 및 API 키 관리 서비스 인터페이스입니다.
+type AuthService interface {
+	// Required는 API 요청에 인증이 필요한지 반환합니다.
+	// 인증이 비활성화되고 설정 API 키도 없으면 모든 요청을 기본 테넌트의 editor로 처리하고 관리 API는 거부합니다.
+	Required() bool
+	// TokenEnabled는 Bearer JWT 인증이 설정되었는지 반환합니다.
+	TokenEnabled() bool
+
+	// 자격 증명 검증 (실패 시 ErrUnauthenticated)
+	AuthenticateAPIKey(key string) (*domain.Principal, error)
+	AuthenticateToken(token string) (*domain.Principal, error)
+
+	// API 키 관리 (평문 키는 IssueKey 결과로 한 번만 반환)
+	IssueKey(tenantID, name string, role domain.Role, expiresIn time.Duration, createdBy string) (string, *domain.APIKey, error)
+	ListKeys(tenantID string) ([]domain.APIKey, error)
+	RevokeKey(tenantID string, id int64) error
+}
+
+// cachedKey는 검증된 API 키 캐시 항목입니다.
+type cachedKey struct {
+	key     *domain.APIKey
+	expires time.Time
+}
+
+// AuthServiceImpl은 인증 서비스 구현체입니다.
+type AuthServiceImpl struct {
+	repository repository.AuthRepository
+	config     *config.Config
+	log        logger.Logger
+	jwt        *jwtVerifier
+
+	cacheTTL time.Duration
+	cache    map[string]cachedKey // 키 해시 -> 검증된 키
+	mu       sync.Mutex
+}
+
+// NewAuthService는 새 인증 서비스 인스턴스를 생성합니다.
+func NewAuthService(repo repository.AuthRepository) AuthService {
+	cfg := config.GetConfig()
+
+	return &AuthServiceImpl{
+		repository: repo,
+		config:     cfg,
+		log:        logger.GetLogger(),
+		jwt:        newJWTVerifier(cfg),
+		cacheTTL:   time.Duration(cfg.Auth.KeyCacheTTL) * time.Second,
+		cache:      make(map[string]cachedKey),
+	}
+}
+
+// Required는 인증이 활성화되었거나 설정 API 키가 있으면 true를 반환합니다.
+func (s *AuthServiceImpl) Required() bool {
+	return s.config.Auth.Enabled || len(s.config.Tenancy.APIKeys) > 0
+}
+
+// TokenEnabled는 JWKS가 설정되었으면 true를 반환합니다.
+func (s *AuthServiceImpl) TokenEnabled() bool {
+	return s.jwt != nil
+}
+
+// AuthenticateAPIKey는 설정 API 키, 발급된 API 키 순으로 키를 확인합니다.
+// 설정 API 키는 해당 테넌트의 관리자 권한을 가집니다.
+func (s *AuthServiceImpl) AuthenticateAPIKey(key string) (*domain.Principal, error) {
+	if key == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	if tenantID, ok := lookupStaticKey(s.config.Tenancy.APIKeys, key); ok {
+		return &domain.Principal{
+			Subject:  "static:" + keyIdentifier(key),
+			TenantID: tenantID,
+			Role:     domain.RoleAdmin,
+			Method:   domain.MethodStaticKey,
+		}, nil
+	}
+
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, ErrUnauthenticated
+	}
+
+	apiKey, err := s.lookupKey(hashKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		Subject:  apiKey.Name,
+		TenantID: tenant.OrDefault(apiKey.TenantID),
+		Role:     apiKey.Role,
+		Method:   domain.MethodAPIKey,
+		KeyID:    apiKey.ID,
+	}, nil
+}
+
+// lookupKey는 캐시 또는 저장소에서 유효한 API 키를 찾습니다.
+// 저장소에서 조회한 경우에만 마지막 사용 시각을 갱신하므로 갱신은 캐시 TTL마다 최대 한 번입니다.
+func (s *AuthServiceImpl) lookupKey(keyHash string) (*domain.APIKey, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.cache[keyHash]
+	s.mu.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		if !entry.key.Active(now.UnixMilli()) {
+			return nil, ErrUnauthenticated
+		}
+		return entry.key, nil
+	}
+
+	apiKey, err := s.repository.GetKeyByHash(keyHash)
+	if errors.Is(err, domain.ErrKeyNotFound) {
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiKey.Active(now.UnixMilli()) || !apiKey.Role.Valid() {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := s.repository.TouchKey(apiKey.ID, now.UnixMilli()); err != nil {
+		s.log.Warn().Err(err).Int64("key_id", apiKey.ID).Msg("API 키 사용 시각 갱신 실패")
+	}
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		s.cache[keyHash] = cachedKey{key: apiKey, expires: now.Add(s.cacheTTL)}
+		s.mu.Unlock()
+	}
+
+	return apiKey, nil
+}
+
+// AuthenticateToken은 Bearer JWT의 서명과 클레임을 검증하고 역할과 테넌트를 결정합니다.
+func (s *AuthServiceImpl) AuthenticateToken(token string) (*domain.Principal, error) {
+	if s.jwt == nil || token == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	claims, err := s.jwt.verify(token)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("JWT 검증 실패")
+		return nil, ErrUnauthenticated
+	}
+
+	role := highestRole(claimValue(claims, s.config.Auth.RoleClaim))
+	if role == "" {
+		role = domain.ParseRole(s.config.Auth.DefaultRole)
+	}
+	if role == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	// 다중 테넌트로 운영하면 테넌트 클레임이 없는 토큰을 기본 테넌트로 처리하지 않고 거부
+	tenantID := tenant.Default
+	if value, ok := claimValue(claims, s.config.Auth.TenantClaim).(string); ok {
+		if tenantID = tenant.Normalize(value); tenantID == "" {
+			return nil, ErrUnauthenticated
+		}
+	} else if s.config.MultiTenant() {
+		s.log.Debug().Str("claim", s.config.Auth.TenantClaim).Msg("JWT에 테넌트 클레임이 없음")
+		return nil, ErrUnauthenticated
+	}
+
+	subject, _ := claims["sub"].(string)
+	return &domain.Principal{
+		Subject:  subject,
+		TenantID: tenantID,
+		Role:     role,
+		Method:   domain.MethodJWT,
+	}, nil
+}
+
+// IssueKey는 새 API 키를 생성해 해시만 저장하고 평문 키를 반환합니다.
+func (s *AuthServiceImpl) IssueKey(tenantID, name string, role domain.Role, expiresIn time.Duration, createdBy string) (string, *domain.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxKeyNameLen {
+		return "", nil, fmt.Errorf("%w: name is required and must be at most %d characters", ErrInvalidKey, maxKeyNameLen)
+	}
+	if !role.Valid() {
+		return "", nil, fmt.Errorf("%w: unknown role %q", ErrInvalidKey, role)
+	}
+	if expiresIn < 0 {
+		return "", nil, fmt.Errorf("%w: expiresIn must not be negative", ErrInvalidKey)
+	}
+
+	plaintext, err := generateKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	key := &domain.APIKey{
+		TenantID:  tenant.OrDefault(tenantID),
+		Name:      name,
+		Prefix:    plaintext[:keyPrefixLen],
+		Role:      role,
+		CreatedBy: createdBy,
+		CreatedAt: now.UnixMilli(),
+	}
+	if expiresIn > 0 {
+		key.ExpiresAt = now.Add(expiresIn).UnixMilli()
+	}
+
+	if err := s.repository.CreateKey(key, hashKey(plaintext)); err != nil {
+		return "", nil, err
+	}
+
+	s.log.Info().Int64("key_id", key.ID).Str("name", key.Name).Str("role", string(key.Role)).Str("created_by", createdBy).Msg("API 키 발급")
+	return plaintext, key, nil
+}
+
+// ListKeys는 테넌트의 API 키 메타데이터를 조회합니다.
+func (s *AuthServiceImpl) ListKeys(tenantID string) ([]domain.APIKey, error) {
+	return s.repository.ListKeys(tenantID)
+}
+
+// RevokeKey는 API 키를 폐기하고 이 인스턴스의 캐시에서 제거해 즉시 인증되지 않도록 합니다.
+// 다른 API 인스턴스는 캐시 항목이 만료될 때까지(Auth.KeyCacheTTL, 기본 30초) 폐기한 키를 계속 인증합니다.
+func (s *AuthServiceImpl) RevokeKey(tenantID string, id int64) error {
+	if err := s.repository.RevokeKey(tenantID, id, time.Now().UnixMilli()); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	for keyHash, entry := range s.cache {
+		if entry.key.ID == id {
+			delete(s.cache, keyHash)
+		}
+	}
+	s.mu.Unlock()
+
+	s.log.Info().Int64("key_id", id).Msg("API 키 폐기")
+	return nil
+}
+
+// generateKey는 접두사와 32바이트 난수로 평문 API 키를 생성합니다.
+func generateKey() (string, error) {
+	buf := make([]byte, keyRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashKey는 API 키의 SHA-256 해시를 16진수 문자열로 반환합니다.
+// 발급 키는 충분한 엔트로피를 가지므로 솔트 없는 해시로도 역산할 수 없습니다.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// keyIdentifier는 로그와 주체 이름에 사용할 키 해시 앞부분을 반환합니다.
+func keyIdentifier(key string) string {
+	return hashKey(key)[:8]
+}
+
+// lookupStaticKey는 설정 API 키에 해당하는 테넌트를 찾습니다.
+// 키 비교 시간으로 키를 추측할 수 없도록 모든 키를 상수 시간 비교합니다.
+func lookupStaticKey(keys map[string]string, key string) (string, bool) {
+	var tenantID string
+	found := false
+	for candidate, id := range keys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			tenantID = id
+			found = true
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	tenantID = tenant.Normalize(tenantID)
+	return tenantID, tenantID != ""
+}
+
+// highestRole은 문자열 또는 문자열 배열 클레임에서 가장 높은 역할을 반환합니다.
+func highestRole(value interface{}) domain.Role {
+	var names []string
+	switch v := value.(type) {
+	case string:
+		names = strings.FieldsFunc(v, func(r rune) bool { return r == ' ' || r == ',' })
+	case []interface{}:
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var best domain.Role
+	for _, name := range names {
+		if role := domain.ParseRole(name); role != "" && (best == "" || role.Allows(best)) {
+			best = role
+		}
+	}
+	return best
+}
+
+// claimValue는 클레임 값을 찾습니다. 이름이 그대로 있으면 그 값을, 없으면
+// '.'으로 구분된 경로(예: realm_access.roles)로 중첩 클레임 값을 찾습니다.
+func claimValue(claims map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+	if value, ok := claims[path]; ok {
+		return value
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
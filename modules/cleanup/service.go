@@ -2,14 +2,24 @@ package cleanup
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
+// 정리 작업에서 삭제한 행 수 (table=logs|traces|rollups|log_patterns|alert_history|alert_silences|metrics)
+var rowsDeleted, _ = otel.Meter("github.com/seongpil0948/otel-kafka-pg/modules/cleanup").Int64Counter("cleanup.rows.deleted",
+	metric.WithDescription("데이터 정리로 삭제한 행 수"))
+
 // CleanupService는 오래된 텔레메트리 데이터를 정리하는 서비스입니다.
 type CleanupService interface {
 	// Start는 데이터 정리 서비스를 시작합니다.
@@ -23,20 +33,35 @@ type cleanupServiceImpl struct {
 	db            db.Database
 	config        *config.Config
 	log           logger.Logger
-	ticker        *time.Ticker
+	interval      chan time.Duration // 다시 로드한 정리 주기를 타이머를 가진 루프 고루틴으로 전달
 	stopChan      chan struct{}
+	wg            sync.WaitGroup
 	isRunning     bool
 }
 
 // NewCleanupService는 새 CleanupService 인스턴스를 생성합니다.
-func NewCleanupService(database db.Database, config *config.Config) CleanupService {
-	return &cleanupServiceImpl{
+func NewCleanupService(database db.Database, cfg *config.Config) CleanupService {
+	c := &cleanupServiceImpl{
 		db:            database,
-		config:        config,
+		config:        cfg,
 		log:           logger.GetLogger(),
+		interval:      make(chan time.Duration, 1),
 		stopChan:      make(chan struct{}),
 		isRunning:     false,
 	}
+
+	// 설정을 다시 로드해 정리 주기가 바뀌면 실행 중인 타이머에 반영
+	// 타이머는 루프 고루틴만 다루므로 새 주기를 채널로 보내고, 이전 값이 남아 있으면 최신 값으로 바꿈
+	config.OnReload(func(cfg *config.Config) {
+		interval := time.Duration(cfg.DataRetention.CleanupInterval) * time.Minute
+		select {
+		case <-c.interval:
+		default:
+		}
+		c.interval <- interval
+	})
+
+	return c
 }
 
 // Start는 데이터 정리 서비스를 시작합니다.
@@ -51,25 +76,33 @@ func (c *cleanupServiceImpl) Start(ctx context.Context) error {
 		return nil
 	}
 
-	interval := time.Duration(c.config.DataRetention.CleanupInterval) * time.Minute
-	c.ticker = time.NewTicker(interval)
+	// 정리 주기는 설정을 다시 로드하면 바뀔 수 있으므로 현재 설정에서 읽음
+	intervalMinutes := config.GetConfig().DataRetention.CleanupInterval
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	c.stopChan = make(chan struct{})
 	c.isRunning = true
 
 	c.log.Info().
-		Int("interval_minutes", c.config.DataRetention.CleanupInterval).
+		Int("interval_minutes", intervalMinutes).
 		Int("retention_days", c.config.DataRetention.RetentionPeriod).
 		Msg("데이터 정리 서비스 시작")
 
-	// 시작 시 즉시 한 번 실행
-	if err := c.cleanupOldData(); err != nil {
-		c.log.Error().Err(err).Msg("초기 데이터 정리 중 오류 발생")
-	}
-
+	c.wg.Add(1)
 	go func() {
+		defer c.wg.Done()
+		defer ticker.Stop()
+
+		// 시작 시 즉시 한 번 실행 (리더 자격을 잃어 ctx가 취소되면 중단되도록 루프 고루틴에서 실행)
+		if err := c.cleanupOldData(ctx); err != nil {
+			c.log.Error().Err(err).Msg("초기 데이터 정리 중 오류 발생")
+		}
+
 		for {
 			select {
-			case <-c.ticker.C:
-				if err := c.cleanupOldData(); err != nil {
+			case interval := <-c.interval:
+				ticker.Reset(interval)
+			case <-ticker.C:
+				if err := c.cleanupOldData(ctx); err != nil {
 					c.log.Error().Err(err).Msg("데이터 정리 중 오류 발생")
 				}
 			case <-c.stopChan:
@@ -91,26 +124,38 @@ func (c *cleanupServiceImpl) Stop() error {
 		return nil
 	}
 
-	c.ticker.Stop()
-	c.stopChan <- struct{}{}
+	// 루프가 컨텍스트 종료로 먼저 끝났어도 막히지 않도록 채널을 닫고, 진행 중인 정리가 끝날 때까지 대기
+	close(c.stopChan)
+	c.wg.Wait()
 	c.isRunning = false
 	c.log.Info().Msg("데이터 정리 서비스 중지됨")
 	return nil
 }
 
-func (c *cleanupServiceImpl) cleanupOldData() error {
-	retentionDays := c.config.DataRetention.RetentionPeriod
+// cleanupOldData는 보존 기간이 지난 데이터를 한 트랜잭션으로 삭제합니다.
+// ctx가 취소되면(리더 자격을 잃거나 종료하면) 진행 중인 삭제를 중단하고 트랜잭션을 롤백합니다.
+func (c *cleanupServiceImpl) cleanupOldData(ctx context.Context) error {
+	// 보존 기간은 설정을 다시 로드하면 바뀔 수 있으므로 매번 현재 설정에서 읽음
+	cfg := config.GetConfig()
+	retentionDays := cfg.DataRetention.RetentionPeriod
 	cutoffTime := time.Now().AddDate(0, 0, -retentionDays).UnixNano() / 1000000 // 밀리초 단위로 변환
 
+	// 보존 기간을 따로 설정한 테넌트별 기준 시간
+	cutoffs := make(map[string]int64, len(cfg.Tenancy.Retention))
+	for tenantID, days := range cfg.Tenancy.Retention {
+		cutoffs[tenantID] = time.Now().AddDate(0, 0, -days).UnixNano() / 1000000
+	}
+
 	c.log.Info().
 		Int("retention_days", retentionDays).
 		Int64("cutoff_time_ms", cutoffTime).
+		Interface("tenant_retention_days", cfg.Tenancy.Retention).
 		Msg("오래된 데이터 정리 시작")
 
 	startTime := time.Now()
 
 	// 트랜잭션 시작
-	tx, err := c.db.Begin()
+	tx, err := c.db.BeginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("데이터 정리 트랜잭션 시작 실패: %w", err)
 	}
@@ -124,30 +169,64 @@ func (c *cleanupServiceImpl) cleanupOldData() error {
 	}
 	defer rollbackOnError()
 
+	// 대량 삭제가 API 조회용 SQL 실행 시간 제한에 걸리지 않도록 해제
+	if err = db.DisableStatementTimeout(tx); err != nil {
+		return err
+	}
+
 	// 로그 삭제
-	logResult, err := tx.Exec("DELETE FROM logs WHERE timestamp < $1", cutoffTime)
+	logCount, err := c.deleteExpired(ctx, tx, "logs", "timestamp", cutoffTime, cutoffs)
 	if err != nil {
 		return fmt.Errorf("로그 정리 실패: %w", err)
 	}
 
-	logCount, err := logResult.RowsAffected()
+	// 트레이스 삭제
+	traceCount, err := c.deleteExpired(ctx, tx, "traces", "start_time", cutoffTime, cutoffs)
 	if err != nil {
-		c.log.Warn().Err(err).Msg("삭제된 로그 행 수를 가져올 수 없습니다")
+		return fmt.Errorf("트레이스 정리 실패: %w", err)
 	}
 
-	// 트레이스 삭제
-	traceResult, err := tx.Exec("DELETE FROM traces WHERE start_time < $1", cutoffTime)
+	// 트레이스 집계 데이터 삭제
+	rollupCount, err := c.deleteExpired(ctx, tx, "service_metrics", "time_bucket", cutoffTime, cutoffs)
 	if err != nil {
-		return fmt.Errorf("트레이스 정리 실패: %w", err)
+		return fmt.Errorf("서비스 메트릭 집계 정리 실패: %w", err)
+	}
+
+	edgeCount, err := c.deleteExpired(ctx, tx, "service_graph_edges", "time_bucket", cutoffTime, cutoffs)
+	if err != nil {
+		return fmt.Errorf("서비스 그래프 집계 정리 실패: %w", err)
+	}
+	rollupCount += edgeCount
+
+	operationCount, err := c.deleteExpired(ctx, tx, "operation_metrics", "time_bucket", cutoffTime, cutoffs)
+	if err != nil {
+		return fmt.Errorf("오퍼레이션 메트릭 집계 정리 실패: %w", err)
+	}
+	rollupCount += operationCount
+
+	// 보존 기간 동안 발견되지 않은 로그 패턴 삭제
+	patternCount, err := c.deleteExpired(ctx, tx, "log_patterns", "last_seen", cutoffTime, cutoffs)
+	if err != nil {
+		return fmt.Errorf("로그 패턴 정리 실패: %w", err)
+	}
+
+	// 알림 상태 전환 이력 삭제
+	alertCount, err := c.deleteExpired(ctx, tx, "alert_history", "timestamp", cutoffTime, cutoffs)
+	if err != nil {
+		return fmt.Errorf("알림 이력 정리 실패: %w", err)
 	}
 
-	traceCount, err := traceResult.RowsAffected()
+	// 만료된 사일런스 삭제
+	silenceCount, err := c.deleteExpired(ctx, tx, "alert_silences", "ends_at", cutoffTime, cutoffs)
 	if err != nil {
-		c.log.Warn().Err(err).Msg("삭제된 트레이스 행 수를 가져올 수 없습니다")
+		return fmt.Errorf("사일런스 정리 실패: %w", err)
 	}
 
 	// 메트릭 삭제 (메트릭 테이블이 있는 경우)
-	metricResult, err := tx.Exec("DELETE FROM metrics WHERE timestamp < $1", cutoffTime)
+	metricResult, err := tx.ExecContext(ctx, "DELETE FROM metrics WHERE timestamp < $1", cutoffTime)
+	if ctx.Err() != nil {
+		return fmt.Errorf("메트릭 정리 중단: %w", ctx.Err())
+	}
 	if err != nil {
 		// 메트릭 테이블이 없을 수 있으므로 오류를 무시하고 로그만 남깁니다
 		c.log.Debug().Err(err).Msg("메트릭 테이블이 없거나 정리 중 오류 발생")
@@ -167,13 +246,72 @@ func (c *cleanupServiceImpl) cleanupOldData() error {
 		return fmt.Errorf("데이터 정리 트랜잭션 커밋 실패: %w", err)
 	}
 
+	// 커밋된 삭제만 메트릭에 반영
+	for table, count := range map[string]int64{
+		"logs":           logCount,
+		"traces":         traceCount,
+		"metrics":        metricCount,
+		"rollups":        rollupCount,
+		"log_patterns":   patternCount,
+		"alert_history":  alertCount,
+		"alert_silences": silenceCount,
+	} {
+		rowsDeleted.Add(context.Background(), count, metric.WithAttributes(attribute.String("table", table)))
+	}
+
 	duration := time.Since(startTime)
 	c.log.Info().
 		Int64("logs_deleted", logCount).
 		Int64("traces_deleted", traceCount).
 		Int64("metrics_deleted", metricCount).
+		Int64("rollups_deleted", rollupCount).
+		Int64("patterns_deleted", patternCount).
+		Int64("alert_events_deleted", alertCount).
+		Int64("silences_deleted", silenceCount).
 		Dur("duration", duration).
 		Msg("데이터 정리 완료")
 
 	return nil
-}
\ No newline at end of file
+}
+// deleteExpired는 table에서 column 값이 보존 기준 시간보다 오래된 행을 삭제하고 삭제된 행 수를 반환합니다.
+// 보존 기간을 따로 설정한 테넌트는 각자의 기준 시간을, 나머지 테넌트는 기본 기준 시간을 사용합니다.
+func (c *cleanupServiceImpl) deleteExpired(ctx context.Context, tx *sql.Tx, table, column string, cutoffTime int64, cutoffs map[string]int64) (int64, error) {
+	var total int64
+	tenantIDs := make([]interface{}, 0, len(cutoffs))
+
+	for tenantID, tenantCutoff := range cutoffs {
+		query := fmt.Sprintf("DELETE FROM %s WHERE tenant_id = $2 AND %s < $1", table, column)
+		result, err := tx.ExecContext(ctx, query, tenantCutoff, tenantID)
+		if err != nil {
+			return total, err
+		}
+		total += c.rowsAffected(result, table)
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s < $1", table, column)
+	if len(tenantIDs) > 0 {
+		placeholders := make([]string, len(tenantIDs))
+		for i := range tenantIDs {
+			placeholders[i] = fmt.Sprintf("$%d", i+2)
+		}
+		query += fmt.Sprintf(" AND tenant_id NOT IN (%s)", strings.Join(placeholders, ", "))
+	}
+	result, err := tx.ExecContext(ctx, query, append([]interface{}{cutoffTime}, tenantIDs...)...)
+	if err != nil {
+		return total, err
+	}
+	total += c.rowsAffected(result, table)
+
+	return total, nil
+}
+
+// rowsAffected는 삭제된 행 수를 반환합니다. 행 수를 가져올 수 없으면 경고를 남기고 0을 반환합니다.
+func (c *cleanupServiceImpl) rowsAffected(result sql.Result, table string) int64 {
+	count, err := result.RowsAffected()
+	if err != nil {
+		c.log.Warn().Err(err).Str("table", table).Msg("삭제된 행 수를 가져올 수 없습니다")
+		return 0
+	}
+	return count
+}
@@ -10,12 +10,29 @@ import (
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/redis"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
 	ErrCacheMiss = errors.New("cache miss")
 )
 
+// 자체 텔레메트리 계측 이름
+const instrumentationName = "github.com/seongpil0948/otel-kafka-pg/modules/common/cache"
+
+var (
+	tracer = otel.Tracer(instrumentationName)
+
+	// 캐시 조회 결과(hit/miss)별 횟수
+	cacheLookups, _ = otel.Meter(instrumentationName).Int64Counter("cache.lookups",
+		metric.WithDescription("캐시 조회 횟수 (result=hit|miss)"))
+)
+
 // CacheService는 캐시 서비스 인터페이스입니다.
 type CacheService interface {
 	// Get은 캐시에서 데이터를 가져옵니다.
@@ -34,7 +51,6 @@ type CacheService interface {
 // RedisCacheService는 Redis를 사용한 캐시 서비스 구현체입니다.
 type RedisCacheService struct {
 	redisClient redis.Client
-	ttl         time.Duration
 	isEnabled   bool
 	log         logger.Logger
 }
@@ -59,7 +75,6 @@ func NewCacheService() (CacheService, error) {
 
 	return &RedisCacheService{
 		redisClient: redisClient,
-		ttl:         time.Duration(cfg.Redis.TTL) * time.Second,
 		isEnabled:   true,
 		log:         log,
 	}, nil
@@ -72,20 +87,27 @@ func (r *RedisCacheService) Get(ctx context.Context, key string, dest interface{
 		return ErrCacheMiss
 	}
 
+	ctx, span := startSpan(ctx, "GET")
+	defer span.End()
+
 	// Redis에서 데이터 가져오기
 	data, err := r.redisClient.Get(ctx, key)
 	if err != nil {
 		r.log.Debug().Err(err).Str("key", key).Msg("캐시 미스")
+		recordLookup(ctx, span, false)
 		return ErrCacheMiss
 	}
 
 	// JSON 디코딩
 	if err := json.Unmarshal([]byte(data), dest); err != nil {
 		r.log.Error().Err(err).Str("key", key).Msg("캐시 데이터 언마샬링 실패")
+		recordError(span, err)
+		recordLookup(ctx, span, false)
 		return err
 	}
 
 	r.log.Debug().Str("key", key).Msg("캐시 히트")
+	recordLookup(ctx, span, true)
 	return nil
 }
 
@@ -103,13 +125,19 @@ func (r *RedisCacheService) Set(ctx context.Context, key string, value interface
 		return err
 	}
 
+	ctx, span := startSpan(ctx, "SET")
+	defer span.End()
+
 	// Redis에 데이터 저장
-	if err := r.redisClient.Set(ctx, key, data, r.ttl); err != nil {
+	// 설정을 다시 로드하면 바뀐 TTL을 바로 적용하도록 저장할 때마다 읽음
+	ttl := time.Duration(config.GetConfig().Redis.TTL) * time.Second
+	if err := r.redisClient.Set(ctx, key, data, ttl); err != nil {
 		r.log.Error().Err(err).Str("key", key).Msg("캐시 저장 실패")
+		recordError(span, err)
 		return err
 	}
 
-	r.log.Debug().Str("key", key).Int("ttl_seconds", int(r.ttl.Seconds())).Msg("캐시 저장 성공")
+	r.log.Debug().Str("key", key).Int("ttl_seconds", int(ttl.Seconds())).Msg("캐시 저장 성공")
 	return nil
 }
 
@@ -120,9 +148,13 @@ func (r *RedisCacheService) Delete(ctx context.Context, key string) error {
 		return nil
 	}
 
+	ctx, span := startSpan(ctx, "DEL")
+	defer span.End()
+
 	// Redis에서 키 삭제
 	if err := r.redisClient.Delete(ctx, key); err != nil {
 		r.log.Error().Err(err).Str("key", key).Msg("캐시 삭제 실패")
+		recordError(span, err)
 		return err
 	}
 
@@ -135,6 +167,33 @@ func (r *RedisCacheService) IsEnabled() bool {
 	return r.isEnabled
 }
 
+// startSpan은 Redis 명령 하나에 대한 클라이언트 스팬을 시작합니다.
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "cache "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// recordLookup은 캐시 조회 결과를 스팬 속성과 메트릭에 기록합니다.
+func recordLookup(ctx context.Context, span trace.Span, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", hit))
+	cacheLookups.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+// recordError는 스팬에 오류를 기록합니다.
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
 // DummyCacheService는 캐싱을 수행하지 않는 더미 서비스입니다.
 type DummyCacheService struct{}
 
@@ -2,11 +2,14 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
 	"github.com/spf13/viper"
 )
 
@@ -19,6 +22,17 @@ type Config struct {
 		Password string
 		DBName   string
 		MaxConns int
+
+		// TLS 설정 (lib/pq 연결 매개변수와 같은 의미)
+		SSLMode     string // disable, require, verify-ca, verify-full
+		SSLRootCert string // 서버 인증서를 검증할 CA 인증서 파일 경로
+		SSLCert     string // 클라이언트 인증서 파일 경로 (상호 TLS)
+		SSLKey      string // 클라이언트 개인 키 파일 경로 (상호 TLS, 권한 0600)
+
+		// 읽기 전용 복제본 설정 (사용자, 비밀번호, 데이터베이스 이름, TLS 설정은 주 데이터베이스와 같음)
+		Replicas             []string // 조회 API가 사용할 복제본 주소 (host 또는 host:port), 비어 있으면 모든 쿼리를 주 데이터베이스로
+		ReplicaCheckInterval int      // 복제본 상태 점검 주기(초)
+		ReplicaMaxLag        int      // 허용하는 최대 복제 지연(초, 0이면 검사하지 않음)
 	}
 
 	Kafka struct {
@@ -29,6 +43,17 @@ type Config struct {
 		LogsTopic     string
 		BatchSize     int
 		FlushInterval int
+		StatsInterval int // librdkafka 통계(컨슈머 랙 등) 수집 주기(밀리초, 0이면 수집 안 함)
+
+		// 보안 설정 (librdkafka security.protocol, sasl.*, ssl.* 설정과 같은 의미)
+		SecurityProtocol string // plaintext, ssl, sasl_plaintext, sasl_ssl
+		SASLMechanism    string // PLAIN, SCRAM-SHA-256, SCRAM-SHA-512
+		SASLUsername     string
+		SASLPassword     string
+		SSLCALocation    string // 브로커 인증서를 검증할 CA 인증서 파일 경로
+		SSLCertLocation  string // 클라이언트 인증서 파일 경로 (상호 TLS)
+		SSLKeyLocation   string // 클라이언트 개인 키 파일 경로 (상호 TLS)
+		SSLKeyPassword   string // 클라이언트 개인 키가 암호화되어 있으면 그 비밀번호
 	}
 
 	Logger struct {
@@ -44,6 +69,12 @@ type Config struct {
 		PoolSize    int
 		TTL         int // 캐시 TTL (초)
 		EnableCache bool
+
+		// TLS 설정
+		TLSEnabled bool
+		TLSCACert  string // 서버 인증서를 검증할 CA 인증서 파일 경로 (비어 있으면 시스템 CA)
+		TLSCert    string // 클라이언트 인증서 파일 경로 (상호 TLS)
+		TLSKey     string // 클라이언트 개인 키 파일 경로 (상호 TLS)
 	}
 
 	DataRetention struct {
@@ -51,11 +82,149 @@ type Config struct {
 		CleanupInterval int // 정리 작업 주기(분)
 		RetentionPeriod int // 데이터 보존 기간(일)
 	}
+
+	// 트레이스 집계(rollup) 작업 설정
+	Rollup struct {
+		Enabled    bool
+		Interval   int // 집계 작업 주기(초)
+		BucketSize int // 집계 시간대 크기(초)
+		Lag        int // 늦게 도착하는 스팬을 기다리는 유예 시간(초)
+		Lookback   int // 유예 시간 뒤에 도착한 스팬을 반영하도록 매번 다시 집계하는 최근 구간(초)
+	}
+
+	// 로그 패턴 마이닝(Drain) 설정
+	LogPattern struct {
+		Enabled      bool
+		Depth        int     // 접두사 트리 깊이
+		SimThreshold float64 // 같은 패턴으로 묶는 최소 토큰 유사도 (0~1)
+		MaxChildren  int     // 트리 노드당 최대 자식 수
+		MaxClusters  int     // 메모리에 유지하는 최대 패턴 수 (가득 차면 가장 오래 발견되지 않은 패턴을 제거)
+	}
+
+	// 실시간 tail 스트리밍 설정
+	Tail struct {
+		MaxSubscribers int // 스트림(로그/트레이스)별 최대 동시 구독자 수
+		RateLimit      int // 구독자별 초당 최대 전송 항목 수
+		BufferSize     int // 구독자별 전송 대기 버퍼 크기
+	}
+
+	// 수집 정책(드롭 규칙, 테일 샘플링) 설정
+	Ingest struct {
+		PolicyFile       string  // 드롭 규칙 YAML 파일 경로 (비어 있으면 드롭 규칙 없음)
+		SamplingEnabled  bool    // 트레이스 테일 샘플링 사용 여부
+		SamplingRate     float64 // 오류/지연이 없는 정상 트레이스 보존 비율 (0~1)
+		DecisionWait     int     // 트레이스별 샘플링 결정 전 스팬을 모으는 시간(밀리초)
+		LatencyThreshold float64 // 이 시간(밀리초) 이상 걸린 스팬이 있는 트레이스는 항상 보존
+		MaxPendingTraces int     // 결정 대기 중 메모리에 유지하는 최대 트레이스 수
+		HashSalt         string  // 비식별화 hash 규칙에 사용하는 솔트 (hash 규칙이 있으면 필수)
+	}
+
+	// 멀티 테넌시 설정
+	Tenancy struct {
+		HeaderName   string            // 공유 토픽에서 테넌트 ID를 담는 Kafka 메시지 헤더 이름 (전용 토픽에서는 무시)
+		AttributeKey string            // 공유 토픽에서 헤더가 없을 때 테넌트 ID로 사용하는 리소스 속성 키
+		TraceTopics  map[string]string // 추가로 구독할 테넌트 전용 트레이스 토픽 (토픽=테넌트)
+		LogTopics    map[string]string // 추가로 구독할 테넌트 전용 로그 토픽 (토픽=테넌트)
+		APIKeys      map[string]string // API 키별 테넌트 (키=테넌트), 비어 있으면 모든 API 요청을 기본 테넌트로 처리
+		Retention    map[string]int    // 테넌트별 데이터 보존 기간(일), 없으면 DataRetention.RetentionPeriod
+		Quotas       map[string]int    // 수집 인스턴스별로 적용하는 테넌트별 분당 최대 수집 항목 수(스팬+로그)
+		DefaultQuota int               // Quotas에 없는 테넌트의 인스턴스별 분당 최대 수집 항목 수 (0이면 제한 없음)
+	}
+
+	// API 인증 및 권한 설정
+	Auth struct {
+		Enabled     bool   // API 인증 사용 여부 (Tenancy.APIKeys가 있으면 항상 인증)
+		JWKS        string // JWT 서명 검증용 JWKS 파일 경로 또는 http(s) URL, 비어 있으면 Bearer 토큰 인증 비활성화
+		JWKSRefresh int    // JWKS URL을 다시 가져오는 주기(초)
+		JWTIssuer   string // 허용하는 JWT iss 클레임, 비어 있으면 검사하지 않음
+		JWTAudience string // 허용하는 JWT aud 클레임, 비어 있으면 검사하지 않음
+		RoleClaim   string // 역할을 담는 JWT 클레임 이름
+		TenantClaim string // 테넌트 ID를 담는 JWT 클레임 이름 (다중 테넌트면 이 클레임이 없는 토큰은 거부)
+		DefaultRole string // 역할 클레임이 없는 JWT에 부여하는 역할
+		KeyCacheTTL int    // 검증된 API 키를 메모리에 캐시하는 시간(초, 최대 300). 다른 인스턴스에서 폐기한 키도 이 시간 동안은 인증됨
+	}
+
+	// API 요청 속도 제한 및 조회 비용 제한 설정
+	QueryGuard struct {
+		RateLimit        int            // 클라이언트(API 키 또는 IP)별 초당 허용 요청 수 (0이면 제한 없음)
+		RateBurst        int            // 클라이언트별 순간 최대 요청 수
+		MaxTimeRange     int            // 조회 구간(startTime~endTime) 최대 길이(시간)
+		MaxLimit         int            // limit 매개변수 최댓값
+		TimeRanges       map[string]int // 엔드포인트 그룹별 조회 구간 최대 길이(시간) (예: traces=24,logs=72)
+		Limits           map[string]int // 엔드포인트 그룹별 limit 최댓값 (예: traces=500)
+		StatementTimeout int            // SQL 문 하나의 최대 실행 시간(밀리초, 0이면 제한 없음)
+		Timeout          int            // 조회 요청 하나의 최대 처리 시간(밀리초, 0이면 제한 없음)
+		Timeouts         map[string]int // 엔드포인트 그룹별 최대 처리 시간(밀리초) (예: traces=10000,metrics=30000)
+	}
+
+	// 알림 규칙 평가 설정
+	Alerting struct {
+		Enabled            bool
+		EvaluationInterval int // 규칙 평가 주기(초)
+	}
+
+	// 알림 전송 설정
+	Notification struct {
+		Enabled      bool
+		GroupWait    int // 같은 그룹의 알림을 모아서 보내기 전 대기 시간(초)
+		DedupWindow  int // 같은 규칙/상태 알림을 다시 보내지 않는 시간(초)
+		MaxRetries   int // 전송 실패 시 최대 재시도 횟수
+		RetryBackoff int // 첫 재시도 대기 시간(밀리초), 재시도마다 두 배
+		Timeout      int // 채널별 전송 제한 시간(초)
+
+		// 웹훅, Slack 채널이 요청을 보낼 수 있는 호스트 (비어 있으면 모든 공개 호스트)
+		// 항목과 같은 호스트이거나 그 하위 도메인이면 허용 (예: hooks.slack.com, example.com)
+		AllowedHosts []string
+		// 루프백, 사설, 링크 로컬 주소로의 웹훅 전송 허용 여부 (내부망 웹훅이 필요할 때만 사용)
+		AllowPrivateNetworks bool
+	}
+
+	// 이메일 채널용 SMTP 서버 설정
+	SMTP struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
+		From     string
+	}
+
+	// 자체 텔레메트리(브리지 자신의 트레이스와 메트릭) 내보내기 설정
+	Telemetry struct {
+		Enabled        bool
+		Exporter       string            // otlp: OTLP/HTTP로 컬렉터에 전송, loopback: 자체 수집 경로로 바로 저장 (트레이스만)
+		Endpoint       string            // OTLP/HTTP 수신 주소 (host:port)
+		Insecure       bool              // OTLP 전송에 TLS를 사용하지 않음
+		Headers        map[string]string // OTLP 요청 헤더 (예: authorization=Bearer xxx)
+		ServiceName    string            // 자체 텔레메트리의 service.name
+		SampleRatio    float64           // 루트 스팬 샘플링 비율 (0~1)
+		MetricInterval int               // 메트릭 내보내기 주기(초)
+	}
+
+	// Prometheus 메트릭 엔드포인트 설정
+	Metrics struct {
+		Enabled bool
+		Path    string // 메트릭을 노출할 API 서버 경로
+	}
+
+	// 상태 점검(/livez, /readyz) 설정
+	Health struct {
+		CheckTimeout    int // 점검별 제한 시간(밀리초)
+		FlushStaleAfter int // 마지막 플러시 성공 후 이 시간(초)이 지나면 준비되지 않은 상태로 판단
+		PollStallAfter  int // 폴링 루프가 이 시간(초) 동안 멈추면 살아 있지 않은 상태로 판단
+		MaxBufferItems  int // 버퍼 포화도 기준 항목 수 (0이면 배치 크기의 10배)
+	}
+
+	// 실행 모드 설정 (--mode 플래그를 지정하면 플래그가 우선)
+	Runtime struct {
+		Mode                string // 실행할 구성 요소 (ingest, query, cleanup, all)
+		LeaderRetryInterval int    // 백그라운드 작업 리더 잠금 획득 재시도, 잠금 연결 점검 간격(초)
+	}
 	API struct {
 		Port             int      `json:"port"`
 		Host             string   `json:"host"`
 		AllowedOrigins   []string `json:"allowedOrigins"`
 		AllowCredentials bool     `json:"allowCredentials"`
+		TrustedProxies   []string `json:"trustedProxies"` // X-Forwarded-For를 신뢰할 프록시 IP 또는 CIDR (비어 있으면 연결 주소를 클라이언트 IP로 사용)
 		ReadTimeout      int      `json:"readTimeout"`
 		WriteTimeout     int      `json:"writeTimeout"`
 		EnableSwagger    bool     `json:"enableSwagger"`
@@ -63,216 +232,41 @@ type Config struct {
 }
 
 var (
-	config *Config
-	once   sync.Once
+	current atomic.Pointer[Config]
+	once    sync.Once
 )
 
-// LoadConfig는 환경 변수 또는 파일에서 설정을 로드합니다.
-func LoadConfig() *Config {
-	once.Do(func() {
-		v := viper.New()
-
-		// 환경 변수에서 설정 가져오기
-		v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-		v.AutomaticEnv()
-
-		// 기본값 설정
-		v.SetDefault("database.host", "localhost")
-		v.SetDefault("database.port", 5432)
-		v.SetDefault("database.user", "postgres")
-		v.SetDefault("database.password", "postgres")
-		v.SetDefault("database.dbname", "telemetry")
-		v.SetDefault("database.maxconns", 20)
-
-		v.SetDefault("kafka.brokers", []string{"10.101.91.181:9092", "10.101.91.181:9093"})
-		v.SetDefault("kafka.groupid", "default-local-group")
-		v.SetDefault("kafka.clientid", "default-local-client")
-		v.SetDefault("kafka.tracestopic", "onpremise.theshop.oltp.dev.trace")
-		v.SetDefault("kafka.logstopic", "onpremise.theshop.oltp.dev.log")
-		v.SetDefault("kafka.batchsize", 100)
-		v.SetDefault("kafka.flushinterval", 5000)
-
-		// Redis 기본 설정 추가
-		v.SetDefault("redis.address", "localhost:6379")
-		v.SetDefault("redis.password", "")
-		v.SetDefault("redis.db", 0)
-		v.SetDefault("redis.poolsize", 10)
-		v.SetDefault("redis.ttl", 3600) // 1시간(초)
-		v.SetDefault("redis.enablecache", true)
-
-		v.SetDefault("logger.level", "info")
-		v.SetDefault("logger.isdev", false)
-
-		v.SetDefault("dataretention.enabled", true)
-		v.SetDefault("dataretention.cleanupinterval", 60) // 1시간 간격 (60분)
-		v.SetDefault("dataretention.retentionperiod", 30) // 30일 보존
-
-		v.SetDefault("api.port", 8080)
-		v.SetDefault("api.host", "")
-		v.SetDefault("api.allowedOrigins", []string{"*"})
-		v.SetDefault("api.allowCredentials", true)
-		v.SetDefault("api.readTimeout", 10)  // 10초
-		v.SetDefault("api.writeTimeout", 30) // 30초
-		v.SetDefault("api.enableSwagger", true)
-
-		// 환경 변수에서 개별 설정 가져오기
-		if host := v.GetString("POSTGRES_HOST"); host != "" {
-			v.Set("database.host", host)
-		}
-		if port := v.GetInt("POSTGRES_PORT"); port != 0 {
-			v.Set("database.port", port)
-		}
-		if user := v.GetString("POSTGRES_USER"); user != "" {
-			v.Set("database.user", user)
-		}
-		if password := v.GetString("POSTGRES_PASSWORD"); password != "" {
-			v.Set("database.password", password)
-		}
-		if dbname := v.GetString("POSTGRES_DB"); dbname != "" {
-			v.Set("database.dbname", dbname)
-		}
-		if maxconns := v.GetInt("POSTGRES_MAX_CONNECTIONS"); maxconns != 0 {
-			v.Set("database.maxconns", maxconns)
-		}
-
-		// Kafka 설정
-		if brokers := v.GetString("KAFKA_BROKERS"); brokers != "" {
-			v.Set("kafka.brokers", strings.Split(brokers, ","))
-		}
-		if groupID := v.GetString("KAFKA_GROUP_ID"); groupID != "" {
-			v.Set("kafka.groupid", groupID)
-		}
-		if clientID := v.GetString("KAFKA_CLIENT_ID"); clientID != "" {
-			v.Set("kafka.clientid", clientID)
-		}
-		if traceTopic := v.GetString("KAFKA_TRACE_TOPIC"); traceTopic != "" {
-			v.Set("kafka.tracestopic", traceTopic)
-		}
-		if logTopic := v.GetString("KAFKA_LOG_TOPIC"); logTopic != "" {
-			v.Set("kafka.logstopic", logTopic)
-		}
-		if batchSize := v.GetInt("BATCH_SIZE"); batchSize != 0 {
-			v.Set("kafka.batchsize", batchSize)
-		}
-		if flushInterval := v.GetInt("FLUSH_INTERVAL"); flushInterval != 0 {
-			v.Set("kafka.flushinterval", flushInterval)
-		}
-
-		// Redis 환경 변수 설정 추가
-		if redisAddr := v.GetString("REDIS_ADDRESS"); redisAddr != "" {
-			v.Set("redis.address", redisAddr)
-		}
-		if redisPassword := v.GetString("REDIS_PASSWORD"); redisPassword != "" {
-			v.Set("redis.password", redisPassword)
-		}
-		if redisDB := v.GetInt("REDIS_DB"); redisDB != 0 {
-			v.Set("redis.db", redisDB)
-		}
-		if redisPoolSize := v.GetInt("REDIS_POOL_SIZE"); redisPoolSize != 0 {
-			v.Set("redis.poolsize", redisPoolSize)
-		}
-		if redisTTL := v.GetInt("REDIS_TTL"); redisTTL != 0 {
-			v.Set("redis.ttl", redisTTL)
-		}
-		if redisEnableCache := v.GetBool("REDIS_ENABLE_CACHE"); redisEnableCache != v.GetBool("redis.enablecache") {
-			v.Set("redis.enablecache", redisEnableCache)
-		}
-
-		// 로거 설정
-		if logLevel := v.GetString("LOG_LEVEL"); logLevel != "" {
-			v.Set("logger.level", logLevel)
-		}
-		if isDev := v.GetString("NODE_ENV"); isDev != "production" {
-			v.Set("logger.isdev", true)
-		}
-
-		// 데이터 보존 설정
-		if enabled := v.GetBool("DATA_RETENTION_ENABLED"); enabled != v.GetBool("dataretention.enabled") {
-			v.Set("dataretention.enabled", enabled)
-		}
-		if interval := v.GetInt("DATA_RETENTION_CLEANUP_INTERVAL"); interval != 0 {
-			v.Set("dataretention.cleanupinterval", interval)
-		}
-		if period := v.GetInt("DATA_RETENTION_PERIOD"); period != 0 {
-			v.Set("dataretention.retentionperiod", period)
-		}
+// 설정 파일 경로를 지정하는 환경 변수
+const configFileEnv = "CONFIG_FILE"
 
-		if apiPort := v.GetInt("API_PORT"); apiPort != 0 {
-			v.Set("api.port", apiPort)
-		}
-
-		if apiHost := v.GetString("API_HOST"); apiHost != "" {
-			v.Set("api.host", apiHost)
-		}
-
-		if origins := v.GetString("API_ALLOWED_ORIGINS"); origins != "" {
-			v.Set("api.allowedOrigins", strings.Split(origins, ","))
-		}
-
-		if allowCreds := v.GetBool("API_ALLOW_CREDENTIALS"); allowCreds != v.GetBool("api.allowCredentials") {
-			v.Set("api.allowCredentials", allowCreds)
-		}
+// 실행 모드
+const (
+	ModeIngest  = "ingest"  // Kafka 수집 (컨슈머, 처리 파이프라인)
+	ModeQuery   = "query"   // 조회 API 서버
+	ModeCleanup = "cleanup" // 백그라운드 작업 (데이터 정리, 집계, 알림), 리더로 선출된 인스턴스 하나만 실행
+	ModeAll     = "all"     // 모든 구성 요소
+)
 
-		if readTimeout := v.GetInt("API_READ_TIMEOUT"); readTimeout != 0 {
-			v.Set("api.readTimeout", readTimeout)
-		}
+// Modes는 지원하는 실행 모드 목록입니다.
+var Modes = []string{ModeIngest, ModeQuery, ModeCleanup, ModeAll}
 
-		if writeTimeout := v.GetInt("API_WRITE_TIMEOUT"); writeTimeout != 0 {
-			v.Set("api.writeTimeout", writeTimeout)
-		}
-		// TODO: setDefault is not working
-		log.Debug().Bool("v.GetBool(API_ENABLE_SWAGGER)", v.GetBool("api.enableSwagger")).Msg("API_ENABLE_SWAGGER 값 확인 환경변수 미설정시 False")
-		if enableSwagger := v.GetBool("API_ENABLE_SWAGGER"); enableSwagger != v.GetBool("api.enableSwagger") {
-			v.Set("api.enableSwagger", enableSwagger)
+// LoadConfig는 기본값, 설정 파일(CONFIG_FILE), 환경 변수 순으로 설정을 로드합니다. 뒤의 값이 앞의 값을 덮어씁니다.
+// 설정 파일을 읽을 수 없거나 값이 유효하지 않으면 잘못된 항목을 모두 출력하고 종료합니다.
+func LoadConfig() *Config {
+	once.Do(func() {
+		loaded, err := load()
+		if err != nil {
+			log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+			log.Fatal().
+				Strs("errors", strings.Split(err.Error(), "\n")).
+				Msg("설정 로드 실패")
 		}
-
-		// 구성 생성
-		config = &Config{}
-
-		// 데이터베이스 설정
-		config.Database.Host = v.GetString("database.host")
-		config.Database.Port = v.GetInt("database.port")
-		config.Database.User = v.GetString("database.user")
-		config.Database.Password = v.GetString("database.password")
-		config.Database.DBName = v.GetString("database.dbname")
-		config.Database.MaxConns = v.GetInt("database.maxconns")
-
-		// Kafka 설정
-		config.Kafka.Brokers = v.GetStringSlice("kafka.brokers")
-		config.Kafka.GroupID = v.GetString("kafka.groupid")
-		config.Kafka.ClientID = v.GetString("kafka.clientid")
-		config.Kafka.TracesTopic = v.GetString("kafka.tracestopic")
-		config.Kafka.LogsTopic = v.GetString("kafka.logstopic")
-		config.Kafka.BatchSize = v.GetInt("kafka.batchsize")
-		config.Kafka.FlushInterval = v.GetInt("kafka.flushinterval")
-
-		// Redis 설정
-		config.Redis.Address = v.GetString("redis.address")
-		config.Redis.Password = v.GetString("redis.password")
-		config.Redis.DB = v.GetInt("redis.db")
-		config.Redis.PoolSize = v.GetInt("redis.poolsize")
-		config.Redis.TTL = v.GetInt("redis.ttl")
-		config.Redis.EnableCache = v.GetBool("redis.enablecache")
-
-		// 로거 설정
-		config.Logger.Level = v.GetString("logger.level")
-		config.Logger.IsDev = v.GetBool("logger.isdev")
-
-		// 데이터 보존 설정
-		config.DataRetention.Enabled = v.GetBool("dataretention.enabled")
-		config.DataRetention.CleanupInterval = v.GetInt("dataretention.cleanupinterval")
-		config.DataRetention.RetentionPeriod = v.GetInt("dataretention.retentionperiod")
-
-		config.API.Port = v.GetInt("api.port")
-		config.API.Host = v.GetString("api.host")
-		config.API.AllowedOrigins = v.GetStringSlice("api.allowedOrigins")
-		config.API.AllowCredentials = v.GetBool("api.allowCredentials")
-		config.API.ReadTimeout = v.GetInt("api.readTimeout")
-		config.API.WriteTimeout = v.GetInt("api.writeTimeout")
-		config.API.EnableSwagger = v.GetBool("api.enableSwagger")
-
+		loadedAt.Store(time.Now().UnixMilli())
+		current.Store(loaded)
 	})
 
+	config := current.Load()
+
 	log := zerolog.New(os.Stdout).With().Timestamp().Logger()
 	log.Info().
 		Str("database.host", config.Database.Host).
@@ -280,6 +274,8 @@ func LoadConfig() *Config {
 		Str("database.user", config.Database.User).
 		Str("database.dbname", config.Database.DBName).
 		Int("database.maxconns", config.Database.MaxConns).
+		Str("database.sslmode", config.Database.SSLMode).
+		Strs("database.replicas", config.Database.Replicas).
 		Strs("kafka.brokers", config.Kafka.Brokers).
 		Str("kafka.groupid", config.Kafka.GroupID).
 		Str("kafka.clientid", config.Kafka.ClientID).
@@ -287,26 +283,898 @@ func LoadConfig() *Config {
 		Str("kafka.logstopic", config.Kafka.LogsTopic).
 		Int("kafka.batchsize", config.Kafka.BatchSize).
 		Int("kafka.flushinterval", config.Kafka.FlushInterval).
+		Int("kafka.statsinterval", config.Kafka.StatsInterval).
+		Str("kafka.securityprotocol", config.Kafka.SecurityProtocol).
+		Str("kafka.saslmechanism", config.Kafka.SASLMechanism).
 		// Redis 로그 추가
 		Str("redis.address", config.Redis.Address).
 		Int("redis.db", config.Redis.DB).
 		Int("redis.poolsize", config.Redis.PoolSize).
 		Int("redis.ttl", config.Redis.TTL).
 		Bool("redis.enablecache", config.Redis.EnableCache).
+		Bool("redis.tlsenabled", config.Redis.TLSEnabled).
 		Str("logger.level", config.Logger.Level).
 		Bool("logger.isdev", config.Logger.IsDev).
 		Bool("dataretention.enabled", config.DataRetention.Enabled).
 		Int("dataretention.cleanupinterval", config.DataRetention.CleanupInterval).
 		Int("dataretention.retentionperiod", config.DataRetention.RetentionPeriod).
+		Bool("rollup.enabled", config.Rollup.Enabled).
+		Int("rollup.interval", config.Rollup.Interval).
+		Int("rollup.bucketsize", config.Rollup.BucketSize).
+		Bool("logpattern.enabled", config.LogPattern.Enabled).
+		Float64("logpattern.simthreshold", config.LogPattern.SimThreshold).
+		Int("tail.maxsubscribers", config.Tail.MaxSubscribers).
+		Int("tail.ratelimit", config.Tail.RateLimit).
+		Str("ingest.policyfile", config.Ingest.PolicyFile).
+		Bool("ingest.samplingenabled", config.Ingest.SamplingEnabled).
+		Float64("ingest.samplingrate", config.Ingest.SamplingRate).
+		Str("tenancy.headername", config.Tenancy.HeaderName).
+		Str("tenancy.attributekey", config.Tenancy.AttributeKey).
+		Int("tenancy.apikeys", len(config.Tenancy.APIKeys)).
+		Interface("tenancy.retention", config.Tenancy.Retention).
+		Interface("tenancy.quotas", config.Tenancy.Quotas).
+		Int("tenancy.defaultquota", config.Tenancy.DefaultQuota).
+		Bool("auth.enabled", config.Auth.Enabled).
+		Str("auth.jwks", config.Auth.JWKS).
+		Str("auth.defaultrole", config.Auth.DefaultRole).
+		Int("queryguard.ratelimit", config.QueryGuard.RateLimit).
+		Int("queryguard.maxtimerange", config.QueryGuard.MaxTimeRange).
+		Int("queryguard.maxlimit", config.QueryGuard.MaxLimit).
+		Int("queryguard.statementtimeout", config.QueryGuard.StatementTimeout).
+		Int("queryguard.timeout", config.QueryGuard.Timeout).
+		Bool("alerting.enabled", config.Alerting.Enabled).
+		Int("alerting.evaluationinterval", config.Alerting.EvaluationInterval).
+		Bool("notification.enabled", config.Notification.Enabled).
+		Int("notification.groupwait", config.Notification.GroupWait).
+		Str("smtp.host", config.SMTP.Host).
+		Int("smtp.port", config.SMTP.Port).
+		Bool("telemetry.enabled", config.Telemetry.Enabled).
+		Str("telemetry.exporter", config.Telemetry.Exporter).
+		Str("telemetry.endpoint", config.Telemetry.Endpoint).
+		Bool("metrics.enabled", config.Metrics.Enabled).
+		Str("metrics.path", config.Metrics.Path).
+		Int("health.checktimeout", config.Health.CheckTimeout).
+		Int("health.flushstaleafter", config.Health.FlushStaleAfter).
+		Int("health.pollstallafter", config.Health.PollStallAfter).
+		Int("health.maxbufferitems", config.Health.MaxBufferItems).
+		Str("runtime.mode", config.Runtime.Mode).
+		Int("runtime.leaderretryinterval", config.Runtime.LeaderRetryInterval).
 		Msg("설정 로드 완료")
 
 	return config
 }
 
+// load는 기본값, 설정 파일, 환경 변수를 합쳐 새 설정을 만들고 검증합니다.
+func load() (*Config, error) {
+	v := viper.New()
+
+	// 환경 변수에서 설정 가져오기 (설정 파일 값보다 우선)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	// 기본값 설정
+	v.SetDefault("database.host", "localhost")
+	v.SetDefault("database.port", 5432)
+	v.SetDefault("database.user", "postgres")
+	v.SetDefault("database.password", "postgres")
+	v.SetDefault("database.dbname", "telemetry")
+	v.SetDefault("database.maxconns", 20)
+	v.SetDefault("database.sslmode", "disable")
+	v.SetDefault("database.sslrootcert", "")
+	v.SetDefault("database.sslcert", "")
+	v.SetDefault("database.sslkey", "")
+	v.SetDefault("database.replicas", []string{})
+	v.SetDefault("database.replicacheckinterval", 10) // 10초
+	v.SetDefault("database.replicamaxlag", 0)
+
+	v.SetDefault("kafka.brokers", []string{"10.101.91.181:9092", "10.101.91.181:9093"})
+	v.SetDefault("kafka.groupid", "default-local-group")
+	v.SetDefault("kafka.clientid", "default-local-client")
+	v.SetDefault("kafka.tracestopic", "onpremise.theshop.oltp.dev.trace")
+	v.SetDefault("kafka.logstopic", "onpremise.theshop.oltp.dev.log")
+	v.SetDefault("kafka.batchsize", 100)
+	v.SetDefault("kafka.flushinterval", 5000)
+	v.SetDefault("kafka.statsinterval", 15000) // 15초
+	v.SetDefault("kafka.securityprotocol", "plaintext")
+	v.SetDefault("kafka.saslmechanism", "PLAIN")
+	v.SetDefault("kafka.saslusername", "")
+	v.SetDefault("kafka.saslpassword", "")
+	v.SetDefault("kafka.sslcalocation", "")
+	v.SetDefault("kafka.sslcertlocation", "")
+	v.SetDefault("kafka.sslkeylocation", "")
+	v.SetDefault("kafka.sslkeypassword", "")
+
+	// Redis 기본 설정 추가
+	v.SetDefault("redis.address", "localhost:6379")
+	v.SetDefault("redis.password", "")
+	v.SetDefault("redis.db", 0)
+	v.SetDefault("redis.poolsize", 10)
+	v.SetDefault("redis.ttl", 3600) // 1시간(초)
+	v.SetDefault("redis.enablecache", true)
+	v.SetDefault("redis.tlsenabled", false)
+	v.SetDefault("redis.tlscacert", "")
+	v.SetDefault("redis.tlscert", "")
+	v.SetDefault("redis.tlskey", "")
+
+	v.SetDefault("logger.level", "info")
+	v.SetDefault("logger.isdev", true) // NODE_ENV=production이 아니면 개발 모드
+
+	v.SetDefault("dataretention.enabled", true)
+	v.SetDefault("dataretention.cleanupinterval", 60) // 1시간 간격 (60분)
+	v.SetDefault("dataretention.retentionperiod", 30) // 30일 보존
+
+	v.SetDefault("rollup.enabled", true)
+	v.SetDefault("rollup.interval", 60)   // 1분 간격
+	v.SetDefault("rollup.bucketsize", 60) // 1분 단위 집계
+	v.SetDefault("rollup.lag", 60)        // 1분 유예
+	v.SetDefault("rollup.lookback", 600)  // 최근 10분 재집계
+
+	v.SetDefault("logpattern.enabled", true)
+	v.SetDefault("logpattern.depth", 3)
+	v.SetDefault("logpattern.simthreshold", 0.4)
+	v.SetDefault("logpattern.maxchildren", 100)
+	v.SetDefault("logpattern.maxclusters", 10000)
+
+	v.SetDefault("tail.maxsubscribers", 100)
+	v.SetDefault("tail.ratelimit", 100)
+	v.SetDefault("tail.buffersize", 1000)
+
+	v.SetDefault("ingest.policyfile", "")
+	v.SetDefault("ingest.samplingenabled", false)
+	v.SetDefault("ingest.samplingrate", 0.1)
+	v.SetDefault("ingest.decisionwait", 5000) // 5초
+	v.SetDefault("ingest.latencythreshold", 1000)
+	v.SetDefault("ingest.maxpendingtraces", 50000)
+	v.SetDefault("ingest.hashsalt", "")
+
+	v.SetDefault("tenancy.headername", "x-tenant-id")
+	v.SetDefault("tenancy.attributekey", "tenant.id")
+	v.SetDefault("tenancy.tracetopics", "")
+	v.SetDefault("tenancy.logtopics", "")
+	v.SetDefault("tenancy.apikeys", "")
+	v.SetDefault("tenancy.retention", "")
+	v.SetDefault("tenancy.quotas", "")
+	v.SetDefault("tenancy.defaultquota", 0)
+
+	v.SetDefault("auth.enabled", false)
+	v.SetDefault("auth.jwks", "")
+	v.SetDefault("auth.jwksrefresh", 3600) // 1시간
+	v.SetDefault("auth.jwtissuer", "")
+	v.SetDefault("auth.jwtaudience", "")
+	v.SetDefault("auth.roleclaim", "role")
+	v.SetDefault("auth.tenantclaim", "tenant")
+	v.SetDefault("auth.defaultrole", "viewer")
+	v.SetDefault("auth.keycachettl", 30) // 30초
+
+	v.SetDefault("queryguard.ratelimit", 20)
+	v.SetDefault("queryguard.rateburst", 40)
+	v.SetDefault("queryguard.maxtimerange", 168) // 7일
+	v.SetDefault("queryguard.maxlimit", 1000)
+	v.SetDefault("queryguard.timeranges", "")
+	v.SetDefault("queryguard.limits", "")
+	v.SetDefault("queryguard.statementtimeout", 30000) // 30초
+	v.SetDefault("queryguard.timeout", 15000)          // 15초
+	v.SetDefault("queryguard.timeouts", "")
+
+	v.SetDefault("alerting.enabled", true)
+	v.SetDefault("alerting.evaluationinterval", 60) // 1분 간격
+
+	v.SetDefault("notification.enabled", true)
+	v.SetDefault("notification.groupwait", 30)    // 30초
+	v.SetDefault("notification.dedupwindow", 300) // 5분
+	v.SetDefault("notification.maxretries", 3)
+	v.SetDefault("notification.retrybackoff", 1000) // 1초
+	v.SetDefault("notification.timeout", 10)        // 10초
+	v.SetDefault("notification.allowedhosts", []string{})
+	v.SetDefault("notification.allowprivatenetworks", false)
+
+	v.SetDefault("smtp.host", "localhost")
+	v.SetDefault("smtp.port", 25)
+	v.SetDefault("smtp.username", "")
+	v.SetDefault("smtp.password", "")
+	v.SetDefault("smtp.from", "alerts@localhost")
+
+	v.SetDefault("telemetry.enabled", false)
+	v.SetDefault("telemetry.exporter", "otlp")
+	v.SetDefault("telemetry.endpoint", "localhost:4318")
+	v.SetDefault("telemetry.insecure", true)
+	v.SetDefault("telemetry.headers", "")
+	v.SetDefault("telemetry.servicename", "otel-kafka-pg")
+	v.SetDefault("telemetry.sampleratio", 1.0)
+	v.SetDefault("telemetry.metricinterval", 30) // 30초
+
+	v.SetDefault("metrics.enabled", true)
+	v.SetDefault("metrics.path", "/metrics")
+
+	v.SetDefault("health.checktimeout", 2000)   // 2초
+	v.SetDefault("health.flushstaleafter", 120) // 2분
+	v.SetDefault("health.pollstallafter", 60)   // 1분
+	v.SetDefault("health.maxbufferitems", 0)
+
+	v.SetDefault("runtime.mode", ModeAll)
+	v.SetDefault("runtime.leaderretryinterval", 10) // 10초
+
+	v.SetDefault("api.port", 8080)
+	v.SetDefault("api.host", "")
+	v.SetDefault("api.allowedOrigins", []string{"*"})
+	v.SetDefault("api.allowCredentials", true)
+	v.SetDefault("api.trustedProxies", []string{})
+	v.SetDefault("api.readTimeout", 10)  // 10초
+	v.SetDefault("api.writeTimeout", 30) // 30초
+	v.SetDefault("api.enableSwagger", true)
+
+	// 설정 파일 (기본값을 덮어쓰고, 아래 환경 변수가 다시 덮어씀)
+	if file := os.Getenv(configFileEnv); file != "" {
+		if err := readConfigFile(v, file); err != nil {
+			return nil, err
+		}
+	}
+
+	// 환경 변수에서 개별 설정 가져오기
+	if host := v.GetString("POSTGRES_HOST"); host != "" {
+		v.Set("database.host", host)
+	}
+	if port := v.GetInt("POSTGRES_PORT"); port != 0 {
+		v.Set("database.port", port)
+	}
+	if user := v.GetString("POSTGRES_USER"); user != "" {
+		v.Set("database.user", user)
+	}
+	if password := v.GetString("POSTGRES_PASSWORD"); password != "" {
+		v.Set("database.password", password)
+	}
+	if dbname := v.GetString("POSTGRES_DB"); dbname != "" {
+		v.Set("database.dbname", dbname)
+	}
+	if maxconns := v.GetInt("POSTGRES_MAX_CONNECTIONS"); maxconns != 0 {
+		v.Set("database.maxconns", maxconns)
+	}
+	if sslMode := v.GetString("POSTGRES_SSLMODE"); sslMode != "" {
+		v.Set("database.sslmode", sslMode)
+	}
+	if sslRootCert := v.GetString("POSTGRES_SSLROOTCERT"); sslRootCert != "" {
+		v.Set("database.sslrootcert", sslRootCert)
+	}
+	if sslCert := v.GetString("POSTGRES_SSLCERT"); sslCert != "" {
+		v.Set("database.sslcert", sslCert)
+	}
+	if sslKey := v.GetString("POSTGRES_SSLKEY"); sslKey != "" {
+		v.Set("database.sslkey", sslKey)
+	}
+	if replicas := v.GetString("POSTGRES_REPLICAS"); replicas != "" {
+		v.Set("database.replicas", strings.Split(replicas, ","))
+	}
+	if checkInterval := v.GetInt("POSTGRES_REPLICA_CHECK_INTERVAL"); checkInterval != 0 {
+		v.Set("database.replicacheckinterval", checkInterval)
+	}
+	if _, ok := os.LookupEnv("POSTGRES_REPLICA_MAX_LAG"); ok {
+		v.Set("database.replicamaxlag", v.GetInt("POSTGRES_REPLICA_MAX_LAG"))
+	}
+
+	// Kafka 설정
+	if brokers := v.GetString("KAFKA_BROKERS"); brokers != "" {
+		v.Set("kafka.brokers", strings.Split(brokers, ","))
+	}
+	if groupID := v.GetString("KAFKA_GROUP_ID"); groupID != "" {
+		v.Set("kafka.groupid", groupID)
+	}
+	if clientID := v.GetString("KAFKA_CLIENT_ID"); clientID != "" {
+		v.Set("kafka.clientid", clientID)
+	}
+	if traceTopic := v.GetString("KAFKA_TRACE_TOPIC"); traceTopic != "" {
+		v.Set("kafka.tracestopic", traceTopic)
+	}
+	if logTopic := v.GetString("KAFKA_LOG_TOPIC"); logTopic != "" {
+		v.Set("kafka.logstopic", logTopic)
+	}
+	if batchSize := v.GetInt("BATCH_SIZE"); batchSize != 0 {
+		v.Set("kafka.batchsize", batchSize)
+	}
+	if flushInterval := v.GetInt("FLUSH_INTERVAL"); flushInterval != 0 {
+		v.Set("kafka.flushinterval", flushInterval)
+	}
+	if _, ok := os.LookupEnv("KAFKA_STATS_INTERVAL"); ok {
+		v.Set("kafka.statsinterval", v.GetInt("KAFKA_STATS_INTERVAL"))
+	}
+	if securityProtocol := v.GetString("KAFKA_SECURITY_PROTOCOL"); securityProtocol != "" {
+		v.Set("kafka.securityprotocol", securityProtocol)
+	}
+	if saslMechanism := v.GetString("KAFKA_SASL_MECHANISM"); saslMechanism != "" {
+		v.Set("kafka.saslmechanism", saslMechanism)
+	}
+	if saslUsername := v.GetString("KAFKA_SASL_USERNAME"); saslUsername != "" {
+		v.Set("kafka.saslusername", saslUsername)
+	}
+	if saslPassword := v.GetString("KAFKA_SASL_PASSWORD"); saslPassword != "" {
+		v.Set("kafka.saslpassword", saslPassword)
+	}
+	if caLocation := v.GetString("KAFKA_SSL_CA_LOCATION"); caLocation != "" {
+		v.Set("kafka.sslcalocation", caLocation)
+	}
+	if certLocation := v.GetString("KAFKA_SSL_CERT_LOCATION"); certLocation != "" {
+		v.Set("kafka.sslcertlocation", certLocation)
+	}
+	if keyLocation := v.GetString("KAFKA_SSL_KEY_LOCATION"); keyLocation != "" {
+		v.Set("kafka.sslkeylocation", keyLocation)
+	}
+	if keyPassword := v.GetString("KAFKA_SSL_KEY_PASSWORD"); keyPassword != "" {
+		v.Set("kafka.sslkeypassword", keyPassword)
+	}
+
+	// Redis 환경 변수 설정 추가
+	if redisAddr := v.GetString("REDIS_ADDRESS"); redisAddr != "" {
+		v.Set("redis.address", redisAddr)
+	}
+	if redisPassword := v.GetString("REDIS_PASSWORD"); redisPassword != "" {
+		v.Set("redis.password", redisPassword)
+	}
+	if redisDB := v.GetInt("REDIS_DB"); redisDB != 0 {
+		v.Set("redis.db", redisDB)
+	}
+	if redisPoolSize := v.GetInt("REDIS_POOL_SIZE"); redisPoolSize != 0 {
+		v.Set("redis.poolsize", redisPoolSize)
+	}
+	if redisTTL := v.GetInt("REDIS_TTL"); redisTTL != 0 {
+		v.Set("redis.ttl", redisTTL)
+	}
+	if _, ok := os.LookupEnv("REDIS_ENABLE_CACHE"); ok {
+		v.Set("redis.enablecache", v.GetBool("REDIS_ENABLE_CACHE"))
+	}
+	if _, ok := os.LookupEnv("REDIS_TLS_ENABLED"); ok {
+		v.Set("redis.tlsenabled", v.GetBool("REDIS_TLS_ENABLED"))
+	}
+	if tlsCACert := v.GetString("REDIS_TLS_CA_CERT"); tlsCACert != "" {
+		v.Set("redis.tlscacert", tlsCACert)
+	}
+	if tlsCert := v.GetString("REDIS_TLS_CERT"); tlsCert != "" {
+		v.Set("redis.tlscert", tlsCert)
+	}
+	if tlsKey := v.GetString("REDIS_TLS_KEY"); tlsKey != "" {
+		v.Set("redis.tlskey", tlsKey)
+	}
+
+	// 로거 설정
+	if logLevel := v.GetString("LOG_LEVEL"); logLevel != "" {
+		v.Set("logger.level", logLevel)
+	}
+	if nodeEnv, ok := os.LookupEnv("NODE_ENV"); ok {
+		v.Set("logger.isdev", nodeEnv != "production")
+	}
+
+	// 데이터 보존 설정
+	if _, ok := os.LookupEnv("DATA_RETENTION_ENABLED"); ok {
+		v.Set("dataretention.enabled", v.GetBool("DATA_RETENTION_ENABLED"))
+	}
+	if interval := v.GetInt("DATA_RETENTION_CLEANUP_INTERVAL"); interval != 0 {
+		v.Set("dataretention.cleanupinterval", interval)
+	}
+	if period := v.GetInt("DATA_RETENTION_PERIOD"); period != 0 {
+		v.Set("dataretention.retentionperiod", period)
+	}
+
+	// 집계 작업 설정
+	if _, ok := os.LookupEnv("ROLLUP_ENABLED"); ok {
+		v.Set("rollup.enabled", v.GetBool("ROLLUP_ENABLED"))
+	}
+	if interval := v.GetInt("ROLLUP_INTERVAL"); interval != 0 {
+		v.Set("rollup.interval", interval)
+	}
+	if bucketSize := v.GetInt("ROLLUP_BUCKET_SIZE"); bucketSize != 0 {
+		v.Set("rollup.bucketsize", bucketSize)
+	}
+	if lag := v.GetInt("ROLLUP_LAG"); lag != 0 {
+		v.Set("rollup.lag", lag)
+	}
+	if _, ok := os.LookupEnv("ROLLUP_LOOKBACK"); ok {
+		v.Set("rollup.lookback", v.GetInt("ROLLUP_LOOKBACK"))
+	}
+
+	// 로그 패턴 마이닝 설정
+	if _, ok := os.LookupEnv("LOG_PATTERN_ENABLED"); ok {
+		v.Set("logpattern.enabled", v.GetBool("LOG_PATTERN_ENABLED"))
+	}
+	if depth := v.GetInt("LOG_PATTERN_DEPTH"); depth != 0 {
+		v.Set("logpattern.depth", depth)
+	}
+	if threshold := v.GetFloat64("LOG_PATTERN_SIM_THRESHOLD"); threshold != 0 {
+		v.Set("logpattern.simthreshold", threshold)
+	}
+	if maxChildren := v.GetInt("LOG_PATTERN_MAX_CHILDREN"); maxChildren != 0 {
+		v.Set("logpattern.maxchildren", maxChildren)
+	}
+	if maxClusters := v.GetInt("LOG_PATTERN_MAX_CLUSTERS"); maxClusters != 0 {
+		v.Set("logpattern.maxclusters", maxClusters)
+	}
+
+	// 실시간 tail 설정
+	if maxSubscribers := v.GetInt("TAIL_MAX_SUBSCRIBERS"); maxSubscribers != 0 {
+		v.Set("tail.maxsubscribers", maxSubscribers)
+	}
+	if rateLimit := v.GetInt("TAIL_RATE_LIMIT"); rateLimit != 0 {
+		v.Set("tail.ratelimit", rateLimit)
+	}
+	if bufferSize := v.GetInt("TAIL_BUFFER_SIZE"); bufferSize != 0 {
+		v.Set("tail.buffersize", bufferSize)
+	}
+
+	// 수집 정책 설정
+	if policyFile := v.GetString("INGEST_POLICY_FILE"); policyFile != "" {
+		v.Set("ingest.policyfile", policyFile)
+	}
+	if _, ok := os.LookupEnv("SAMPLING_ENABLED"); ok {
+		v.Set("ingest.samplingenabled", v.GetBool("SAMPLING_ENABLED"))
+	}
+	if _, ok := os.LookupEnv("SAMPLING_RATE"); ok {
+		v.Set("ingest.samplingrate", v.GetFloat64("SAMPLING_RATE"))
+	}
+	if decisionWait := v.GetInt("SAMPLING_DECISION_WAIT"); decisionWait != 0 {
+		v.Set("ingest.decisionwait", decisionWait)
+	}
+	if latencyThreshold := v.GetFloat64("SAMPLING_LATENCY_THRESHOLD"); latencyThreshold != 0 {
+		v.Set("ingest.latencythreshold", latencyThreshold)
+	}
+	if maxPending := v.GetInt("SAMPLING_MAX_PENDING_TRACES"); maxPending != 0 {
+		v.Set("ingest.maxpendingtraces", maxPending)
+	}
+	if hashSalt := v.GetString("INGEST_HASH_SALT"); hashSalt != "" {
+		v.Set("ingest.hashsalt", hashSalt)
+	}
+
+	// 멀티 테넌시 설정
+	if headerName := v.GetString("TENANT_HEADER"); headerName != "" {
+		v.Set("tenancy.headername", headerName)
+	}
+	if attributeKey := v.GetString("TENANT_ATTRIBUTE"); attributeKey != "" {
+		v.Set("tenancy.attributekey", attributeKey)
+	}
+	if traceTopics := v.GetString("TENANT_TRACE_TOPICS"); traceTopics != "" {
+		v.Set("tenancy.tracetopics", traceTopics)
+	}
+	if logTopics := v.GetString("TENANT_LOG_TOPICS"); logTopics != "" {
+		v.Set("tenancy.logtopics", logTopics)
+	}
+	if apiKeys := v.GetString("TENANT_API_KEYS"); apiKeys != "" {
+		v.Set("tenancy.apikeys", apiKeys)
+	}
+	if retention := v.GetString("TENANT_RETENTION"); retention != "" {
+		v.Set("tenancy.retention", retention)
+	}
+	if quotas := v.GetString("TENANT_QUOTAS"); quotas != "" {
+		v.Set("tenancy.quotas", quotas)
+	}
+	if defaultQuota := v.GetInt("TENANT_DEFAULT_QUOTA"); defaultQuota != 0 {
+		v.Set("tenancy.defaultquota", defaultQuota)
+	}
+
+	// API 인증 설정
+	if _, ok := os.LookupEnv("AUTH_ENABLED"); ok {
+		v.Set("auth.enabled", v.GetBool("AUTH_ENABLED"))
+	}
+	if jwks := v.GetString("AUTH_JWKS"); jwks != "" {
+		v.Set("auth.jwks", jwks)
+	}
+	if jwksRefresh := v.GetInt("AUTH_JWKS_REFRESH"); jwksRefresh != 0 {
+		v.Set("auth.jwksrefresh", jwksRefresh)
+	}
+	if issuer := v.GetString("AUTH_JWT_ISSUER"); issuer != "" {
+		v.Set("auth.jwtissuer", issuer)
+	}
+	if audience := v.GetString("AUTH_JWT_AUDIENCE"); audience != "" {
+		v.Set("auth.jwtaudience", audience)
+	}
+	if roleClaim := v.GetString("AUTH_ROLE_CLAIM"); roleClaim != "" {
+		v.Set("auth.roleclaim", roleClaim)
+	}
+	if tenantClaim := v.GetString("AUTH_TENANT_CLAIM"); tenantClaim != "" {
+		v.Set("auth.tenantclaim", tenantClaim)
+	}
+	if defaultRole := v.GetString("AUTH_DEFAULT_ROLE"); defaultRole != "" {
+		v.Set("auth.defaultrole", defaultRole)
+	}
+	if _, ok := os.LookupEnv("AUTH_KEY_CACHE_TTL"); ok {
+		v.Set("auth.keycachettl", v.GetInt("AUTH_KEY_CACHE_TTL"))
+	}
+
+	// API 요청 속도 제한 및 조회 비용 제한 설정
+	if _, ok := os.LookupEnv("QUERY_RATE_LIMIT"); ok {
+		v.Set("queryguard.ratelimit", v.GetInt("QUERY_RATE_LIMIT"))
+	}
+	if rateBurst := v.GetInt("QUERY_RATE_BURST"); rateBurst != 0 {
+		v.Set("queryguard.rateburst", rateBurst)
+	}
+	if maxTimeRange := v.GetInt("QUERY_MAX_TIME_RANGE"); maxTimeRange != 0 {
+		v.Set("queryguard.maxtimerange", maxTimeRange)
+	}
+	if maxLimit := v.GetInt("QUERY_MAX_LIMIT"); maxLimit != 0 {
+		v.Set("queryguard.maxlimit", maxLimit)
+	}
+	if timeRanges := v.GetString("QUERY_TIME_RANGES"); timeRanges != "" {
+		v.Set("queryguard.timeranges", timeRanges)
+	}
+	if limits := v.GetString("QUERY_LIMITS"); limits != "" {
+		v.Set("queryguard.limits", limits)
+	}
+	if _, ok := os.LookupEnv("QUERY_STATEMENT_TIMEOUT"); ok {
+		v.Set("queryguard.statementtimeout", v.GetInt("QUERY_STATEMENT_TIMEOUT"))
+	}
+	if _, ok := os.LookupEnv("QUERY_TIMEOUT"); ok {
+		v.Set("queryguard.timeout", v.GetInt("QUERY_TIMEOUT"))
+	}
+	if timeouts := v.GetString("QUERY_TIMEOUTS"); timeouts != "" {
+		v.Set("queryguard.timeouts", timeouts)
+	}
+
+	// 알림 규칙 평가 설정
+	if _, ok := os.LookupEnv("ALERTING_ENABLED"); ok {
+		v.Set("alerting.enabled", v.GetBool("ALERTING_ENABLED"))
+	}
+	if interval := v.GetInt("ALERTING_EVALUATION_INTERVAL"); interval != 0 {
+		v.Set("alerting.evaluationinterval", interval)
+	}
+
+	// 알림 전송 설정
+	if _, ok := os.LookupEnv("NOTIFICATION_ENABLED"); ok {
+		v.Set("notification.enabled", v.GetBool("NOTIFICATION_ENABLED"))
+	}
+	if groupWait := v.GetInt("NOTIFICATION_GROUP_WAIT"); groupWait != 0 {
+		v.Set("notification.groupwait", groupWait)
+	}
+	if dedupWindow := v.GetInt("NOTIFICATION_DEDUP_WINDOW"); dedupWindow != 0 {
+		v.Set("notification.dedupwindow", dedupWindow)
+	}
+	if maxRetries := v.GetInt("NOTIFICATION_MAX_RETRIES"); maxRetries != 0 {
+		v.Set("notification.maxretries", maxRetries)
+	}
+	if retryBackoff := v.GetInt("NOTIFICATION_RETRY_BACKOFF"); retryBackoff != 0 {
+		v.Set("notification.retrybackoff", retryBackoff)
+	}
+	if timeout := v.GetInt("NOTIFICATION_TIMEOUT"); timeout != 0 {
+		v.Set("notification.timeout", timeout)
+	}
+	if allowedHosts := v.GetString("NOTIFICATION_ALLOWED_HOSTS"); allowedHosts != "" {
+		v.Set("notification.allowedhosts", strings.Split(allowedHosts, ","))
+	}
+	if _, ok := os.LookupEnv("NOTIFICATION_ALLOW_PRIVATE_NETWORKS"); ok {
+		v.Set("notification.allowprivatenetworks", v.GetBool("NOTIFICATION_ALLOW_PRIVATE_NETWORKS"))
+	}
+
+	// SMTP 설정
+	if smtpHost := v.GetString("SMTP_HOST"); smtpHost != "" {
+		v.Set("smtp.host", smtpHost)
+	}
+	if smtpPort := v.GetInt("SMTP_PORT"); smtpPort != 0 {
+		v.Set("smtp.port", smtpPort)
+	}
+	if smtpUsername := v.GetString("SMTP_USERNAME"); smtpUsername != "" {
+		v.Set("smtp.username", smtpUsername)
+	}
+	if smtpPassword := v.GetString("SMTP_PASSWORD"); smtpPassword != "" {
+		v.Set("smtp.password", smtpPassword)
+	}
+	if smtpFrom := v.GetString("SMTP_FROM"); smtpFrom != "" {
+		v.Set("smtp.from", smtpFrom)
+	}
+
+	// 자체 텔레메트리 설정
+	if _, ok := os.LookupEnv("TELEMETRY_ENABLED"); ok {
+		v.Set("telemetry.enabled", v.GetBool("TELEMETRY_ENABLED"))
+	}
+	if exporter := v.GetString("TELEMETRY_EXPORTER"); exporter != "" {
+		v.Set("telemetry.exporter", exporter)
+	}
+	if endpoint := v.GetString("TELEMETRY_ENDPOINT"); endpoint != "" {
+		v.Set("telemetry.endpoint", endpoint)
+	}
+	if _, ok := os.LookupEnv("TELEMETRY_INSECURE"); ok {
+		v.Set("telemetry.insecure", v.GetBool("TELEMETRY_INSECURE"))
+	}
+	if headers := v.GetString("TELEMETRY_HEADERS"); headers != "" {
+		v.Set("telemetry.headers", headers)
+	}
+	if serviceName := v.GetString("TELEMETRY_SERVICE_NAME"); serviceName != "" {
+		v.Set("telemetry.servicename", serviceName)
+	}
+	if _, ok := os.LookupEnv("TELEMETRY_SAMPLE_RATIO"); ok {
+		v.Set("telemetry.sampleratio", v.GetFloat64("TELEMETRY_SAMPLE_RATIO"))
+	}
+	if metricInterval := v.GetInt("TELEMETRY_METRIC_INTERVAL"); metricInterval != 0 {
+		v.Set("telemetry.metricinterval", metricInterval)
+	}
+
+	// Prometheus 메트릭 엔드포인트 설정
+	if _, ok := os.LookupEnv("METRICS_ENABLED"); ok {
+		v.Set("metrics.enabled", v.GetBool("METRICS_ENABLED"))
+	}
+	if metricsPath := v.GetString("METRICS_PATH"); metricsPath != "" {
+		v.Set("metrics.path", metricsPath)
+	}
+
+	// 상태 점검 설정
+	if checkTimeout := v.GetInt("HEALTH_CHECK_TIMEOUT"); checkTimeout != 0 {
+		v.Set("health.checktimeout", checkTimeout)
+	}
+	if flushStaleAfter := v.GetInt("HEALTH_FLUSH_STALE_AFTER"); flushStaleAfter != 0 {
+		v.Set("health.flushstaleafter", flushStaleAfter)
+	}
+	if pollStallAfter := v.GetInt("HEALTH_POLL_STALL_AFTER"); pollStallAfter != 0 {
+		v.Set("health.pollstallafter", pollStallAfter)
+	}
+	if maxBufferItems := v.GetInt("HEALTH_MAX_BUFFER_ITEMS"); maxBufferItems != 0 {
+		v.Set("health.maxbufferitems", maxBufferItems)
+	}
+
+	// 실행 모드 설정
+	if mode := v.GetString("APP_MODE"); mode != "" {
+		v.Set("runtime.mode", mode)
+	}
+	if leaderRetryInterval := v.GetInt("LEADER_RETRY_INTERVAL"); leaderRetryInterval != 0 {
+		v.Set("runtime.leaderretryinterval", leaderRetryInterval)
+	}
+
+	if apiPort := v.GetInt("API_PORT"); apiPort != 0 {
+		v.Set("api.port", apiPort)
+	}
+
+	if apiHost := v.GetString("API_HOST"); apiHost != "" {
+		v.Set("api.host", apiHost)
+	}
+
+	if origins := v.GetString("API_ALLOWED_ORIGINS"); origins != "" {
+		v.Set("api.allowedOrigins", strings.Split(origins, ","))
+	}
+
+	if proxies := v.GetString("API_TRUSTED_PROXIES"); proxies != "" {
+		v.Set("api.trustedProxies", strings.Split(proxies, ","))
+	}
+
+	if _, ok := os.LookupEnv("API_ALLOW_CREDENTIALS"); ok {
+		v.Set("api.allowCredentials", v.GetBool("API_ALLOW_CREDENTIALS"))
+	}
+
+	if readTimeout := v.GetInt("API_READ_TIMEOUT"); readTimeout != 0 {
+		v.Set("api.readTimeout", readTimeout)
+	}
+
+	if writeTimeout := v.GetInt("API_WRITE_TIMEOUT"); writeTimeout != 0 {
+		v.Set("api.writeTimeout", writeTimeout)
+	}
+	if _, ok := os.LookupEnv("API_ENABLE_SWAGGER"); ok {
+		v.Set("api.enableSwagger", v.GetBool("API_ENABLE_SWAGGER"))
+	}
+
+	// 비밀 값 파일 (*_FILE 환경 변수, 쿠버네티스/도커 시크릿 마운트용)
+	if err := readSecretFiles(v); err != nil {
+		return nil, err
+	}
+
+	// 구성 생성
+	config := &Config{}
+
+	// 데이터베이스 설정
+	config.Database.Host = v.GetString("database.host")
+	config.Database.Port = v.GetInt("database.port")
+	config.Database.User = v.GetString("database.user")
+	config.Database.Password = v.GetString("database.password")
+	config.Database.DBName = v.GetString("database.dbname")
+	config.Database.MaxConns = v.GetInt("database.maxconns")
+	config.Database.SSLMode = strings.ToLower(v.GetString("database.sslmode"))
+	config.Database.SSLRootCert = v.GetString("database.sslrootcert")
+	config.Database.SSLCert = v.GetString("database.sslcert")
+	config.Database.SSLKey = v.GetString("database.sslkey")
+	config.Database.Replicas = v.GetStringSlice("database.replicas")
+	config.Database.ReplicaCheckInterval = v.GetInt("database.replicacheckinterval")
+	config.Database.ReplicaMaxLag = v.GetInt("database.replicamaxlag")
+
+	// Kafka 설정
+	config.Kafka.Brokers = v.GetStringSlice("kafka.brokers")
+	config.Kafka.GroupID = v.GetString("kafka.groupid")
+	config.Kafka.ClientID = v.GetString("kafka.clientid")
+	config.Kafka.TracesTopic = v.GetString("kafka.tracestopic")
+	config.Kafka.LogsTopic = v.GetString("kafka.logstopic")
+	config.Kafka.BatchSize = v.GetInt("kafka.batchsize")
+	config.Kafka.FlushInterval = v.GetInt("kafka.flushinterval")
+	config.Kafka.StatsInterval = v.GetInt("kafka.statsinterval")
+	config.Kafka.SecurityProtocol = strings.ToLower(v.GetString("kafka.securityprotocol"))
+	config.Kafka.SASLMechanism = strings.ToUpper(v.GetString("kafka.saslmechanism"))
+	config.Kafka.SASLUsername = v.GetString("kafka.saslusername")
+	config.Kafka.SASLPassword = v.GetString("kafka.saslpassword")
+	config.Kafka.SSLCALocation = v.GetString("kafka.sslcalocation")
+	config.Kafka.SSLCertLocation = v.GetString("kafka.sslcertlocation")
+	config.Kafka.SSLKeyLocation = v.GetString("kafka.sslkeylocation")
+	config.Kafka.SSLKeyPassword = v.GetString("kafka.sslkeypassword")
+
+	// Redis 설정
+	config.Redis.Address = v.GetString("redis.address")
+	config.Redis.Password = v.GetString("redis.password")
+	config.Redis.DB = v.GetInt("redis.db")
+	config.Redis.PoolSize = v.GetInt("redis.poolsize")
+	config.Redis.TTL = v.GetInt("redis.ttl")
+	config.Redis.EnableCache = v.GetBool("redis.enablecache")
+	config.Redis.TLSEnabled = v.GetBool("redis.tlsenabled")
+	config.Redis.TLSCACert = v.GetString("redis.tlscacert")
+	config.Redis.TLSCert = v.GetString("redis.tlscert")
+	config.Redis.TLSKey = v.GetString("redis.tlskey")
+
+	// 로거 설정
+	config.Logger.Level = v.GetString("logger.level")
+	config.Logger.IsDev = v.GetBool("logger.isdev")
+
+	// 데이터 보존 설정
+	config.DataRetention.Enabled = v.GetBool("dataretention.enabled")
+	config.DataRetention.CleanupInterval = v.GetInt("dataretention.cleanupinterval")
+	config.DataRetention.RetentionPeriod = v.GetInt("dataretention.retentionperiod")
+
+	// 집계 작업 설정
+	config.Rollup.Enabled = v.GetBool("rollup.enabled")
+	config.Rollup.Interval = v.GetInt("rollup.interval")
+	config.Rollup.BucketSize = v.GetInt("rollup.bucketsize")
+	config.Rollup.Lag = v.GetInt("rollup.lag")
+	config.Rollup.Lookback = v.GetInt("rollup.lookback")
+
+	// 로그 패턴 마이닝 설정
+	config.LogPattern.Enabled = v.GetBool("logpattern.enabled")
+	config.LogPattern.Depth = v.GetInt("logpattern.depth")
+	config.LogPattern.SimThreshold = v.GetFloat64("logpattern.simthreshold")
+	config.LogPattern.MaxChildren = v.GetInt("logpattern.maxchildren")
+	config.LogPattern.MaxClusters = v.GetInt("logpattern.maxclusters")
+
+	// 실시간 tail 설정
+	config.Tail.MaxSubscribers = v.GetInt("tail.maxsubscribers")
+	config.Tail.RateLimit = v.GetInt("tail.ratelimit")
+	config.Tail.BufferSize = v.GetInt("tail.buffersize")
+
+	// 수집 정책 설정
+	config.Ingest.PolicyFile = v.GetString("ingest.policyfile")
+	config.Ingest.SamplingEnabled = v.GetBool("ingest.samplingenabled")
+	config.Ingest.SamplingRate = v.GetFloat64("ingest.samplingrate")
+	config.Ingest.DecisionWait = v.GetInt("ingest.decisionwait")
+	config.Ingest.LatencyThreshold = v.GetFloat64("ingest.latencythreshold")
+	config.Ingest.MaxPendingTraces = v.GetInt("ingest.maxpendingtraces")
+	config.Ingest.HashSalt = v.GetString("ingest.hashsalt")
+
+	// 멀티 테넌시 설정
+	config.Tenancy.HeaderName = v.GetString("tenancy.headername")
+	config.Tenancy.AttributeKey = v.GetString("tenancy.attributekey")
+	config.Tenancy.TraceTopics = parsePairs(v.GetString("tenancy.tracetopics"))
+	config.Tenancy.LogTopics = parsePairs(v.GetString("tenancy.logtopics"))
+	config.Tenancy.APIKeys = parsePairs(v.GetString("tenancy.apikeys"))
+	config.Tenancy.Retention = parseIntPairs(v.GetString("tenancy.retention"))
+	config.Tenancy.Quotas = parseIntPairs(v.GetString("tenancy.quotas"))
+	config.Tenancy.DefaultQuota = v.GetInt("tenancy.defaultquota")
+
+	// API 인증 설정
+	config.Auth.Enabled = v.GetBool("auth.enabled")
+	config.Auth.JWKS = v.GetString("auth.jwks")
+	config.Auth.JWKSRefresh = v.GetInt("auth.jwksrefresh")
+	config.Auth.JWTIssuer = v.GetString("auth.jwtissuer")
+	config.Auth.JWTAudience = v.GetString("auth.jwtaudience")
+	config.Auth.RoleClaim = v.GetString("auth.roleclaim")
+	config.Auth.TenantClaim = v.GetString("auth.tenantclaim")
+	config.Auth.DefaultRole = v.GetString("auth.defaultrole")
+	config.Auth.KeyCacheTTL = v.GetInt("auth.keycachettl")
+
+	// API 요청 속도 제한 및 조회 비용 제한 설정
+	config.QueryGuard.RateLimit = v.GetInt("queryguard.ratelimit")
+	config.QueryGuard.RateBurst = v.GetInt("queryguard.rateburst")
+	config.QueryGuard.MaxTimeRange = v.GetInt("queryguard.maxtimerange")
+	config.QueryGuard.MaxLimit = v.GetInt("queryguard.maxlimit")
+	config.QueryGuard.TimeRanges = parseIntPairs(v.GetString("queryguard.timeranges"))
+	config.QueryGuard.Limits = parseIntPairs(v.GetString("queryguard.limits"))
+	config.QueryGuard.StatementTimeout = v.GetInt("queryguard.statementtimeout")
+	config.QueryGuard.Timeout = v.GetInt("queryguard.timeout")
+	config.QueryGuard.Timeouts = parseIntPairs(v.GetString("queryguard.timeouts"))
+
+	// 알림 규칙 평가 설정
+	config.Alerting.Enabled = v.GetBool("alerting.enabled")
+	config.Alerting.EvaluationInterval = v.GetInt("alerting.evaluationinterval")
+
+	// 알림 전송 설정
+	config.Notification.Enabled = v.GetBool("notification.enabled")
+	config.Notification.GroupWait = v.GetInt("notification.groupwait")
+	config.Notification.DedupWindow = v.GetInt("notification.dedupwindow")
+	config.Notification.MaxRetries = v.GetInt("notification.maxretries")
+	config.Notification.RetryBackoff = v.GetInt("notification.retrybackoff")
+	config.Notification.Timeout = v.GetInt("notification.timeout")
+	config.Notification.AllowedHosts = v.GetStringSlice("notification.allowedhosts")
+	config.Notification.AllowPrivateNetworks = v.GetBool("notification.allowprivatenetworks")
+
+	// SMTP 설정
+	config.SMTP.Host = v.GetString("smtp.host")
+	config.SMTP.Port = v.GetInt("smtp.port")
+	config.SMTP.Username = v.GetString("smtp.username")
+	config.SMTP.Password = v.GetString("smtp.password")
+	config.SMTP.From = v.GetString("smtp.from")
+
+	// 자체 텔레메트리 설정
+	config.Telemetry.Enabled = v.GetBool("telemetry.enabled")
+	config.Telemetry.Exporter = strings.ToLower(v.GetString("telemetry.exporter"))
+	config.Telemetry.Endpoint = v.GetString("telemetry.endpoint")
+	config.Telemetry.Insecure = v.GetBool("telemetry.insecure")
+	config.Telemetry.Headers = parsePairs(v.GetString("telemetry.headers"))
+	config.Telemetry.ServiceName = v.GetString("telemetry.servicename")
+	config.Telemetry.SampleRatio = v.GetFloat64("telemetry.sampleratio")
+	config.Telemetry.MetricInterval = v.GetInt("telemetry.metricinterval")
+
+	// Prometheus 메트릭 엔드포인트 설정
+	config.Metrics.Enabled = v.GetBool("metrics.enabled")
+	config.Metrics.Path = v.GetString("metrics.path")
+
+	config.Health.CheckTimeout = v.GetInt("health.checktimeout")
+	config.Health.FlushStaleAfter = v.GetInt("health.flushstaleafter")
+	config.Health.PollStallAfter = v.GetInt("health.pollstallafter")
+	config.Health.MaxBufferItems = v.GetInt("health.maxbufferitems")
+
+	config.Runtime.Mode = strings.ToLower(v.GetString("runtime.mode"))
+	config.Runtime.LeaderRetryInterval = v.GetInt("runtime.leaderretryinterval")
+
+	config.API.Port = v.GetInt("api.port")
+	config.API.Host = v.GetString("api.host")
+	config.API.AllowedOrigins = v.GetStringSlice("api.allowedOrigins")
+	config.API.AllowCredentials = v.GetBool("api.allowCredentials")
+	config.API.TrustedProxies = v.GetStringSlice("api.trustedProxies")
+	config.API.ReadTimeout = v.GetInt("api.readTimeout")
+	config.API.WriteTimeout = v.GetInt("api.writeTimeout")
+	config.API.EnableSwagger = v.GetBool("api.enableSwagger")
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// MultiTenant는 기본 테넌트 이외의 테넌트가 설정되어 있는지 확인합니다.
+// API 키, 테넌트 전용 토픽, 테넌트별 보존 기간이나 할당량 중 하나라도 다른 테넌트를 가리키면 다중 테넌트로 봅니다.
+func (c *Config) MultiTenant() bool {
+	for _, values := range []map[string]string{c.Tenancy.APIKeys, c.Tenancy.TraceTopics, c.Tenancy.LogTopics} {
+		for _, id := range values {
+			if tenant.OrDefault(id) != tenant.Default {
+				return true
+			}
+		}
+	}
+	for _, limits := range []map[string]int{c.Tenancy.Retention, c.Tenancy.Quotas} {
+		for id := range limits {
+			if tenant.OrDefault(id) != tenant.Default {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetConfig는 현재 설정을 반환합니다.
+// 재시작 없이 바뀌는 값(ReloadableKeys)은 보관한 포인터 대신 사용할 때마다 GetConfig로 읽어야 합니다.
 func GetConfig() *Config {
-	if config == nil {
-		return LoadConfig()
+	if config := current.Load(); config != nil {
+		return config
 	}
-	return config
+	return LoadConfig()
+}
+
+// parsePairs는 "키=값,키=값" 형식의 문자열을 맵으로 변환합니다. 형식이 잘못된 항목은 건너뜁니다.
+func parsePairs(value string) map[string]string {
+	pairs := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(entry[:i])
+		val := strings.TrimSpace(entry[i+1:])
+		if key != "" && val != "" {
+			pairs[key] = val
+		}
+	}
+	return pairs
+}
+
+// parseIntPairs는 "키=숫자,키=숫자" 형식의 문자열을 맵으로 변환합니다. 숫자가 아니거나 0 이하인 항목은 건너뜁니다.
+func parseIntPairs(value string) map[string]int {
+	pairs := make(map[string]int)
+	for key, val := range parsePairs(value) {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			pairs[key] = n
+		}
+	}
+	return pairs
 }
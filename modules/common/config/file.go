@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// readConfigFile은 YAML/TOML/JSON 설정 파일(형식은 확장자로 판단)을 읽어 v에 합칩니다.
+// 키는 기본값과 같은 "섹션.항목" 구조이며(예: kafka.batchSize), 대소문자를 구분하지 않습니다.
+// 맵 형식 항목(tenancy.apiKeys 등)은 환경 변수와 같은 "키=값,키=값" 문자열로 작성합니다.
+// 기본값에 없는 키나 기본값과 형식이 다른 값이 있으면 잘못된 항목을 모두 모아 오류로 반환합니다.
+func readConfigFile(v *viper.Viper, file string) error {
+	fv := viper.New()
+	fv.SetConfigFile(file)
+	if err := fv.ReadInConfig(); err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패 (%s): %w", file, err)
+	}
+
+	known := make(map[string]bool)
+	for _, key := range v.AllKeys() {
+		known[key] = true
+	}
+
+	var errs []error
+	reported := make(map[string]bool)
+	for _, key := range fv.AllKeys() {
+		if !known[key] {
+			// 맵 형식 항목을 YAML/TOML 맵으로 작성하면 하위 키로 펼쳐짐
+			if parent := knownParent(key, known); parent != "" {
+				if !reported[parent] {
+					reported[parent] = true
+					errs = append(errs, fmt.Errorf(`%s: 맵 대신 "키=값,키=값" 형식의 문자열이 필요합니다 (%s)`, parent, file))
+				}
+				continue
+			}
+			errs = append(errs, fmt.Errorf("%s: 알 수 없는 설정 키 (%s)", key, file))
+			continue
+		}
+		if err := checkType(fv.Get(key), v.Get(key)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w (%s)", key, err, file))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return v.MergeConfigMap(fv.AllSettings())
+}
+
+// checkType은 설정 파일 값을 기본값과 같은 형식으로 변환할 수 있는지 확인합니다.
+func checkType(value, defaultValue interface{}) error {
+	var err error
+	switch defaultValue.(type) {
+	case int:
+		_, err = cast.ToIntE(value)
+	case float64:
+		_, err = cast.ToFloat64E(value)
+	case bool:
+		_, err = cast.ToBoolE(value)
+	case []string:
+		_, err = cast.ToStringSliceE(value)
+	case string:
+		_, err = cast.ToStringE(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%T 형식이 필요합니다: %v", defaultValue, value)
+	}
+	return nil
+}
+
+// knownParent는 key의 상위 키 중 기본값에 있는 키를 반환합니다. 없으면 빈 문자열입니다.
+func knownParent(key string, known map[string]bool) string {
+	for i := len(key) - 1; i > 0; i-- {
+		if key[i] == '.' && known[key[:i]] {
+			return key[:i]
+		}
+	}
+	return ""
+}
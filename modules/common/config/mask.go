@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// 비밀 값 대신 보여주는 문자열
+const maskedValue = "******"
+
+// Masked는 비밀번호, 솔트, API 키, 인증 헤더 같은 비밀 값을 가린 설정 복사본을 반환합니다.
+// 값이 설정되어 있는지는 알 수 있도록 비어 있는 값은 그대로 둡니다.
+func (c *Config) Masked() *Config {
+	masked := *c
+
+	masked.Database.Password = mask(c.Database.Password)
+	masked.Kafka.SASLPassword = mask(c.Kafka.SASLPassword)
+	masked.Kafka.SSLKeyPassword = mask(c.Kafka.SSLKeyPassword)
+	masked.Redis.Password = mask(c.Redis.Password)
+	masked.SMTP.Password = mask(c.SMTP.Password)
+	masked.Ingest.HashSalt = mask(c.Ingest.HashSalt)
+	masked.Auth.JWKS = maskURL(c.Auth.JWKS)
+
+	// API 키는 맵 키이므로 키마다 구분할 수 있는 이름으로 바꿈
+	if len(c.Tenancy.APIKeys) > 0 {
+		masked.Tenancy.APIKeys = make(map[string]string, len(c.Tenancy.APIKeys))
+		i := 0
+		for _, tenantID := range c.Tenancy.APIKeys {
+			i++
+			masked.Tenancy.APIKeys[fmt.Sprintf("%s#%d", maskedValue, i)] = tenantID
+		}
+	}
+
+	if len(c.Telemetry.Headers) > 0 {
+		masked.Telemetry.Headers = make(map[string]string, len(c.Telemetry.Headers))
+		for name, value := range c.Telemetry.Headers {
+			masked.Telemetry.Headers[name] = mask(value)
+		}
+	}
+
+	return &masked
+}
+
+// mask는 비어 있지 않은 값을 가립니다.
+func mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	return maskedValue
+}
+
+// maskURL은 URL에 포함된 사용자 정보와 쿼리(토큰 등)를 가립니다. URL이 아니면 그대로 반환합니다.
+func maskURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value
+	}
+	if u.User != nil {
+		u.User = url.User(maskedValue)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = maskedValue
+	}
+	return u.String()
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// ReloadableKeys는 재시작 없이 Reload로 바꿀 수 있는 설정 항목입니다.
+var ReloadableKeys = []string{
+	"logger.level",
+	"kafka.batchsize",
+	"kafka.flushinterval",
+	"dataretention.cleanupinterval",
+	"dataretention.retentionperiod",
+	"tenancy.retention",
+	"redis.ttl",
+}
+
+// 설정 파일 변경 이벤트를 모아서 한 번만 다시 로드하기 위한 대기 시간
+const watchDebounce = 500 * time.Millisecond
+
+var (
+	reloadMu  sync.Mutex
+	listeners []func(cfg *Config)
+
+	// 현재 설정을 로드한 시각 (Unix 밀리초)
+	loadedAt atomic.Int64
+)
+
+// Metadata는 현재 설정의 출처와 로드 시각입니다.
+type Metadata struct {
+	File       string    `json:"file,omitempty"` // 설정 파일 경로 (없으면 기본값과 환경 변수만 사용)
+	LoadedAt   time.Time `json:"loadedAt"`       // 마지막으로 설정을 로드하거나 다시 로드한 시각
+	Reloadable []string  `json:"reloadable"`     // 재시작 없이 바꿀 수 있는 항목
+}
+
+// CurrentMetadata는 현재 설정의 메타데이터를 반환합니다.
+func CurrentMetadata() Metadata {
+	return Metadata{
+		File:       os.Getenv(configFileEnv),
+		LoadedAt:   time.UnixMilli(loadedAt.Load()),
+		Reloadable: ReloadableKeys,
+	}
+}
+
+// OnReload는 Reload로 설정 값이 바뀌었을 때 호출할 함수를 등록합니다. 바뀐 뒤의 설정이 전달됩니다.
+// 타이머 주기처럼 값을 매번 읽지 않는 구성 요소가 새 값을 반영하는 데 사용합니다.
+func OnReload(fn func(cfg *Config)) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// Reload는 설정 파일과 환경 변수를 다시 읽어 ReloadableKeys 항목만 현재 설정에 반영하고, 반영한 항목을 반환합니다.
+// 그 밖의 항목이 바뀌었으면 재시작해야 반영된다고 경고합니다.
+// 새 설정을 읽을 수 없거나 유효하지 않으면 현재 설정을 그대로 유지하고 오류를 반환합니다.
+func Reload() ([]string, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	loaded, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	old := GetConfig()
+	next := *old
+	var changed []string
+	reloadValue(&changed, "logger.level", &next.Logger.Level, loaded.Logger.Level)
+	reloadValue(&changed, "kafka.batchsize", &next.Kafka.BatchSize, loaded.Kafka.BatchSize)
+	reloadValue(&changed, "kafka.flushinterval", &next.Kafka.FlushInterval, loaded.Kafka.FlushInterval)
+	reloadValue(&changed, "dataretention.cleanupinterval", &next.DataRetention.CleanupInterval, loaded.DataRetention.CleanupInterval)
+	reloadValue(&changed, "dataretention.retentionperiod", &next.DataRetention.RetentionPeriod, loaded.DataRetention.RetentionPeriod)
+	reloadValue(&changed, "tenancy.retention", &next.Tenancy.Retention, loaded.Tenancy.Retention)
+	reloadValue(&changed, "redis.ttl", &next.Redis.TTL, loaded.Redis.TTL)
+
+	if pending := changedSections(&next, loaded); len(pending) > 0 {
+		log.Warn().Strs("sections", pending).Msg("재시작해야 반영되는 설정이 바뀌었습니다")
+	}
+
+	if len(changed) == 0 {
+		log.Info().Msg("설정 다시 로드 완료: 바뀐 항목 없음")
+		return nil, nil
+	}
+
+	loadedAt.Store(time.Now().UnixMilli())
+	current.Store(&next)
+	log.Info().Strs("changed", changed).Msg("설정 다시 로드 완료")
+
+	for _, fn := range listeners {
+		fn(&next)
+	}
+	return changed, nil
+}
+
+// Watch는 설정 파일이 바뀌면 Reload를 호출합니다. 설정 파일을 사용하지 않으면 아무 일도 하지 않습니다.
+// 편집기의 파일 교체나 쿠버네티스 ConfigMap의 심볼릭 링크 교체도 감지하도록 파일이 있는 디렉터리를 감시합니다.
+// ctx가 취소되면 감시를 종료합니다.
+func Watch(ctx context.Context) error {
+	file := os.Getenv(configFileEnv)
+	if file == "" {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	name := filepath.Base(file)
+	go func() {
+		defer watcher.Close()
+
+		// 짧은 시간에 연속으로 오는 이벤트는 마지막 이벤트 뒤 한 번만 다시 로드
+		debounce := time.NewTimer(watchDebounce)
+		debounce.Stop()
+		defer debounce.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				base := filepath.Base(event.Name)
+				if base == name || strings.HasPrefix(base, "..") {
+					debounce.Reset(watchDebounce)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warn().Err(err).Str("file", file).Msg("설정 파일 감시 오류")
+			case <-debounce.C:
+				if _, err := Reload(); err != nil {
+					log.Error().Strs("errors", strings.Split(err.Error(), "\n")).Str("file", file).
+						Msg("설정 다시 로드 실패, 기존 설정 유지")
+				}
+			}
+		}
+	}()
+
+	log.Info().Str("file", file).Msg("설정 파일 변경 감시 시작")
+	return nil
+}
+
+// reloadValue는 값이 바뀌었으면 dst에 반영하고 changed에 항목 이름을 추가합니다.
+func reloadValue[T any](changed *[]string, key string, dst *T, value T) {
+	if !reflect.DeepEqual(*dst, value) {
+		*dst = value
+		*changed = append(*changed, key)
+	}
+}
+
+// changedSections는 두 설정에서 값이 다른 최상위 섹션 이름을 반환합니다.
+func changedSections(a, b *Config) []string {
+	va := reflect.ValueOf(a).Elem()
+	vb := reflect.ValueOf(b).Elem()
+
+	var sections []string
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			sections = append(sections, strings.ToLower(va.Type().Field(i).Name))
+		}
+	}
+	return sections
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// 파일에서 읽을 수 있는 비밀 값 환경 변수와 설정 키
+// 환경 변수 이름 뒤에 _FILE을 붙인 변수에 파일 경로를 지정하면 파일 내용을 값으로 사용합니다.
+var secretEnvs = []struct {
+	env string
+	key string
+}{
+	{"POSTGRES_PASSWORD", "database.password"},
+	{"KAFKA_SASL_PASSWORD", "kafka.saslpassword"},
+	{"KAFKA_SSL_KEY_PASSWORD", "kafka.sslkeypassword"},
+	{"REDIS_PASSWORD", "redis.password"},
+	{"SMTP_PASSWORD", "smtp.password"},
+	{"INGEST_HASH_SALT", "ingest.hashsalt"},
+	{"TENANT_API_KEYS", "tenancy.apikeys"},
+	{"TELEMETRY_HEADERS", "telemetry.headers"},
+}
+
+// readSecretFiles는 *_FILE 환경 변수가 가리키는 파일에서 비밀 값을 읽어 v에 설정합니다.
+// 파일 끝의 줄바꿈은 제거합니다. 같은 값을 환경 변수와 파일로 함께 지정하거나 파일을 읽을 수 없으면
+// 잘못된 항목을 모두 모아 오류로 반환합니다.
+func readSecretFiles(v *viper.Viper) error {
+	var errs []error
+	for _, secret := range secretEnvs {
+		fileEnv := secret.env + "_FILE"
+		path := os.Getenv(fileEnv)
+		if path == "" {
+			continue
+		}
+		if _, ok := os.LookupEnv(secret.env); ok {
+			errs = append(errs, fmt.Errorf("%s: %s와 %s 중 하나만 설정해야 합니다", secret.key, secret.env, fileEnv))
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s 파일 읽기 실패: %w", secret.key, fileEnv, err))
+			continue
+		}
+		v.Set(secret.key, strings.TrimRight(string(data), "\r\n"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestReadSecretFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    map[string]string // 설정 키 -> 기대 값
+		wantErr []string          // 오류 메시지에 모두 포함되어야 하는 설정 키
+	}{
+		{
+			name: "no files",
+			env:  map[string]string{},
+		},
+		{
+			name: "trailing newline is trimmed",
+			env: map[string]string{
+				"POSTGRES_PASSWORD_FILE":   "testdata/postgres_password",
+				"KAFKA_SASL_PASSWORD_FILE": "testdata/kafka_sasl_password",
+			},
+			want: map[string]string{
+				"database.password":  "s3cret 'pass'",
+				"kafka.saslpassword": "kafka-secret",
+			},
+		},
+		{
+			name: "env and file together",
+			env: map[string]string{
+				"POSTGRES_PASSWORD":      "plain",
+				"POSTGRES_PASSWORD_FILE": "testdata/postgres_password",
+			},
+			wantErr: []string{"database.password"},
+		},
+		{
+			name: "empty env and file together",
+			env: map[string]string{
+				"REDIS_PASSWORD":      "",
+				"REDIS_PASSWORD_FILE": "testdata/postgres_password",
+			},
+			wantErr: []string{"redis.password"},
+		},
+		{
+			name: "all errors are reported",
+			env: map[string]string{
+				"SMTP_PASSWORD_FILE":   "testdata/missing",
+				"TENANT_API_KEYS":      "key=acme",
+				"TENANT_API_KEYS_FILE": "testdata/postgres_password",
+				"REDIS_PASSWORD_FILE":  "testdata/postgres_password",
+			},
+			want:    map[string]string{"redis.password": "s3cret 'pass'"},
+			wantErr: []string{"smtp.password", "tenancy.apikeys"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, secret := range secretEnvs {
+				unsetEnv(t, secret.env)
+				unsetEnv(t, secret.env+"_FILE")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			v := viper.New()
+			err := readSecretFiles(v)
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Fatalf("readSecretFiles() error = %v", err)
+			}
+			if len(tt.wantErr) > 0 && err == nil {
+				t.Fatalf("readSecretFiles() error = nil, want errors for %v", tt.wantErr)
+			}
+			for _, key := range tt.wantErr {
+				if !strings.Contains(err.Error(), key) {
+					t.Errorf("readSecretFiles() error = %v, want error for %s", err, key)
+				}
+			}
+			for key, want := range tt.want {
+				if got := v.GetString(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+// unsetEnv는 테스트가 끝나면 원래 값으로 되돌리도록 환경 변수를 제거합니다.
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// 허용하는 로그 레벨
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "fatal", "panic"}
+
+// 허용하는 API 역할
+var roles = []string{"viewer", "editor", "admin"}
+
+// 허용하는 PostgreSQL sslmode (lib/pq 지원 값)
+var pgSSLModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+// 허용하는 Kafka security.protocol과 SASL 방식
+var (
+	kafkaProtocols      = []string{"plaintext", "ssl", "sasl_plaintext", "sasl_ssl"}
+	kafkaSASLMechanisms = []string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"}
+)
+
+// Validate는 설정 값의 범위와 형식을 검사합니다.
+// 첫 오류에서 멈추지 않고 잘못된 항목을 모두 모아 "키: 이유" 형식으로 반환합니다.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+	// 인증서, 키 파일은 설정된 경우에만 읽을 수 있는지 확인
+	checkFile := func(key, path string) {
+		if path == "" {
+			return
+		}
+		if _, err := os.Stat(path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: 파일을 읽을 수 없습니다: %w", key, err))
+		}
+	}
+
+	check(c.Database.Host != "", "database.host", "비어 있을 수 없습니다")
+	check(validPort(c.Database.Port), "database.port", "1~65535 범위여야 합니다 (현재 %d)", c.Database.Port)
+	check(c.Database.DBName != "", "database.dbname", "비어 있을 수 없습니다")
+	check(c.Database.MaxConns > 0, "database.maxconns", "0보다 커야 합니다 (현재 %d)", c.Database.MaxConns)
+	check(contains(pgSSLModes, c.Database.SSLMode), "database.sslmode", "%s 중 하나여야 합니다 (현재 %q)", strings.Join(pgSSLModes, ", "), c.Database.SSLMode)
+	check((c.Database.SSLCert == "") == (c.Database.SSLKey == ""), "database.sslcert", "database.sslkey와 함께 설정해야 합니다")
+	checkFile("database.sslrootcert", c.Database.SSLRootCert)
+	checkFile("database.sslcert", c.Database.SSLCert)
+	checkFile("database.sslkey", c.Database.SSLKey)
+	check(!hasEmpty(c.Database.Replicas), "database.replicas", "빈 주소가 있습니다 (현재 %v)", c.Database.Replicas)
+	if len(c.Database.Replicas) > 0 {
+		check(c.Database.ReplicaCheckInterval > 0, "database.replicacheckinterval", "0보다 커야 합니다 (현재 %d)", c.Database.ReplicaCheckInterval)
+		check(c.Database.ReplicaMaxLag >= 0, "database.replicamaxlag", "0 이상이어야 합니다 (현재 %d)", c.Database.ReplicaMaxLag)
+	}
+
+	check(len(c.Kafka.Brokers) > 0 && !hasEmpty(c.Kafka.Brokers), "kafka.brokers", "브로커 주소가 하나 이상 필요합니다 (현재 %v)", c.Kafka.Brokers)
+	check(c.Kafka.GroupID != "", "kafka.groupid", "비어 있을 수 없습니다")
+	check(c.Kafka.TracesTopic != "", "kafka.tracestopic", "비어 있을 수 없습니다")
+	check(c.Kafka.LogsTopic != "", "kafka.logstopic", "비어 있을 수 없습니다")
+	check(c.Kafka.BatchSize > 0, "kafka.batchsize", "0보다 커야 합니다 (현재 %d)", c.Kafka.BatchSize)
+	check(c.Kafka.FlushInterval > 0, "kafka.flushinterval", "0보다 커야 합니다 (현재 %d)", c.Kafka.FlushInterval)
+	check(c.Kafka.StatsInterval >= 0, "kafka.statsinterval", "0 이상이어야 합니다 (현재 %d)", c.Kafka.StatsInterval)
+	check(contains(kafkaProtocols, c.Kafka.SecurityProtocol), "kafka.securityprotocol", "%s 중 하나여야 합니다 (현재 %q)", strings.Join(kafkaProtocols, ", "), c.Kafka.SecurityProtocol)
+	if strings.HasPrefix(c.Kafka.SecurityProtocol, "sasl_") {
+		check(contains(kafkaSASLMechanisms, c.Kafka.SASLMechanism), "kafka.saslmechanism", "%s 중 하나여야 합니다 (현재 %q)", strings.Join(kafkaSASLMechanisms, ", "), c.Kafka.SASLMechanism)
+		check(c.Kafka.SASLUsername != "", "kafka.saslusername", "SASL을 사용하면 비어 있을 수 없습니다")
+		check(c.Kafka.SASLPassword != "", "kafka.saslpassword", "SASL을 사용하면 비어 있을 수 없습니다")
+	}
+	check((c.Kafka.SSLCertLocation == "") == (c.Kafka.SSLKeyLocation == ""), "kafka.sslcertlocation", "kafka.sslkeylocation과 함께 설정해야 합니다")
+	checkFile("kafka.sslcalocation", c.Kafka.SSLCALocation)
+	checkFile("kafka.sslcertlocation", c.Kafka.SSLCertLocation)
+	checkFile("kafka.sslkeylocation", c.Kafka.SSLKeyLocation)
+
+	check(contains(logLevels, strings.ToLower(c.Logger.Level)), "logger.level", "%s 중 하나여야 합니다 (현재 %q)", strings.Join(logLevels, ", "), c.Logger.Level)
+
+	if c.Redis.EnableCache {
+		check(c.Redis.Address != "", "redis.address", "캐싱을 사용하면 비어 있을 수 없습니다")
+		check(c.Redis.PoolSize > 0, "redis.poolsize", "0보다 커야 합니다 (현재 %d)", c.Redis.PoolSize)
+		check(c.Redis.TTL > 0, "redis.ttl", "0보다 커야 합니다 (현재 %d)", c.Redis.TTL)
+	}
+	if c.Redis.TLSEnabled {
+		check((c.Redis.TLSCert == "") == (c.Redis.TLSKey == ""), "redis.tlscert", "redis.tlskey와 함께 설정해야 합니다")
+		checkFile("redis.tlscacert", c.Redis.TLSCACert)
+		checkFile("redis.tlscert", c.Redis.TLSCert)
+		checkFile("redis.tlskey", c.Redis.TLSKey)
+	}
+
+	if c.DataRetention.Enabled {
+		check(c.DataRetention.CleanupInterval > 0, "dataretention.cleanupinterval", "0보다 커야 합니다 (현재 %d)", c.DataRetention.CleanupInterval)
+		check(c.DataRetention.RetentionPeriod > 0, "dataretention.retentionperiod", "0보다 커야 합니다 (현재 %d)", c.DataRetention.RetentionPeriod)
+	}
+
+	if c.Rollup.Enabled {
+		check(c.Rollup.Interval > 0, "rollup.interval", "0보다 커야 합니다 (현재 %d)", c.Rollup.Interval)
+		check(c.Rollup.BucketSize > 0, "rollup.bucketsize", "0보다 커야 합니다 (현재 %d)", c.Rollup.BucketSize)
+		check(c.Rollup.Lag >= 0, "rollup.lag", "0 이상이어야 합니다 (현재 %d)", c.Rollup.Lag)
+		check(c.Rollup.Lookback >= 0, "rollup.lookback", "0 이상이어야 합니다 (현재 %d)", c.Rollup.Lookback)
+	}
+
+	if c.LogPattern.Enabled {
+		check(c.LogPattern.Depth > 0, "logpattern.depth", "0보다 커야 합니다 (현재 %d)", c.LogPattern.Depth)
+		check(c.LogPattern.SimThreshold > 0 && c.LogPattern.SimThreshold <= 1, "logpattern.simthreshold", "0 초과 1 이하여야 합니다 (현재 %g)", c.LogPattern.SimThreshold)
+		check(c.LogPattern.MaxChildren > 0, "logpattern.maxchildren", "0보다 커야 합니다 (현재 %d)", c.LogPattern.MaxChildren)
+		check(c.LogPattern.MaxClusters > 0, "logpattern.maxclusters", "0보다 커야 합니다 (현재 %d)", c.LogPattern.MaxClusters)
+	}
+
+	check(c.Tail.MaxSubscribers > 0, "tail.maxsubscribers", "0보다 커야 합니다 (현재 %d)", c.Tail.MaxSubscribers)
+	check(c.Tail.RateLimit > 0, "tail.ratelimit", "0보다 커야 합니다 (현재 %d)", c.Tail.RateLimit)
+	check(c.Tail.BufferSize > 0, "tail.buffersize", "0보다 커야 합니다 (현재 %d)", c.Tail.BufferSize)
+
+	check(c.Ingest.SamplingRate >= 0 && c.Ingest.SamplingRate <= 1, "ingest.samplingrate", "0~1 범위여야 합니다 (현재 %g)", c.Ingest.SamplingRate)
+	if c.Ingest.SamplingEnabled {
+		check(c.Ingest.DecisionWait > 0, "ingest.decisionwait", "0보다 커야 합니다 (현재 %d)", c.Ingest.DecisionWait)
+		check(c.Ingest.MaxPendingTraces > 0, "ingest.maxpendingtraces", "0보다 커야 합니다 (현재 %d)", c.Ingest.MaxPendingTraces)
+	}
+
+	check(c.Tenancy.DefaultQuota >= 0, "tenancy.defaultquota", "0 이상이어야 합니다 (현재 %d)", c.Tenancy.DefaultQuota)
+
+	check(contains(roles, strings.ToLower(strings.TrimSpace(c.Auth.DefaultRole))), "auth.defaultrole", "%s 중 하나여야 합니다 (현재 %q)", strings.Join(roles, ", "), c.Auth.DefaultRole)
+	check(c.Auth.KeyCacheTTL >= 0 && c.Auth.KeyCacheTTL <= 300, "auth.keycachettl", "0~300 범위여야 합니다 (현재 %d)", c.Auth.KeyCacheTTL)
+
+	check(c.QueryGuard.RateLimit >= 0, "queryguard.ratelimit", "0 이상이어야 합니다 (현재 %d)", c.QueryGuard.RateLimit)
+	check(c.QueryGuard.RateBurst >= 0, "queryguard.rateburst", "0 이상이어야 합니다 (현재 %d)", c.QueryGuard.RateBurst)
+	check(c.QueryGuard.MaxTimeRange >= 0, "queryguard.maxtimerange", "0 이상이어야 합니다 (현재 %d)", c.QueryGuard.MaxTimeRange)
+	check(c.QueryGuard.MaxLimit >= 0, "queryguard.maxlimit", "0 이상이어야 합니다 (현재 %d)", c.QueryGuard.MaxLimit)
+	check(c.QueryGuard.StatementTimeout >= 0, "queryguard.statementtimeout", "0 이상이어야 합니다 (현재 %d)", c.QueryGuard.StatementTimeout)
+	check(c.QueryGuard.Timeout >= 0, "queryguard.timeout", "0 이상이어야 합니다 (현재 %d)", c.QueryGuard.Timeout)
+
+	if c.Alerting.Enabled {
+		check(c.Alerting.EvaluationInterval > 0, "alerting.evaluationinterval", "0보다 커야 합니다 (현재 %d)", c.Alerting.EvaluationInterval)
+	}
+
+	if c.Notification.Enabled {
+		check(c.Notification.GroupWait >= 0, "notification.groupwait", "0 이상이어야 합니다 (현재 %d)", c.Notification.GroupWait)
+		check(c.Notification.DedupWindow >= 0, "notification.dedupwindow", "0 이상이어야 합니다 (현재 %d)", c.Notification.DedupWindow)
+		check(c.Notification.MaxRetries >= 0, "notification.maxretries", "0 이상이어야 합니다 (현재 %d)", c.Notification.MaxRetries)
+		check(c.Notification.RetryBackoff > 0, "notification.retrybackoff", "0보다 커야 합니다 (현재 %d)", c.Notification.RetryBackoff)
+		check(c.Notification.Timeout > 0, "notification.timeout", "0보다 커야 합니다 (현재 %d)", c.Notification.Timeout)
+		check(!hasEmpty(c.Notification.AllowedHosts), "notification.allowedhosts", "빈 호스트가 있습니다 (현재 %v)", c.Notification.AllowedHosts)
+	}
+	check(validPort(c.SMTP.Port), "smtp.port", "1~65535 범위여야 합니다 (현재 %d)", c.SMTP.Port)
+
+	if c.Telemetry.Enabled {
+		check(c.Telemetry.Exporter == "otlp" || c.Telemetry.Exporter == "loopback", "telemetry.exporter", "otlp, loopback 중 하나여야 합니다 (현재 %q)", c.Telemetry.Exporter)
+		check(c.Telemetry.Exporter != "otlp" || c.Telemetry.Endpoint != "", "telemetry.endpoint", "otlp 방식에서는 비어 있을 수 없습니다")
+		check(c.Telemetry.SampleRatio >= 0 && c.Telemetry.SampleRatio <= 1, "telemetry.sampleratio", "0~1 범위여야 합니다 (현재 %g)", c.Telemetry.SampleRatio)
+		check(c.Telemetry.MetricInterval > 0, "telemetry.metricinterval", "0보다 커야 합니다 (현재 %d)", c.Telemetry.MetricInterval)
+	}
+
+	if c.Metrics.Enabled {
+		check(strings.HasPrefix(c.Metrics.Path, "/") && !strings.HasPrefix(c.Metrics.Path, "/api"), "metrics.path", "/로 시작하고 /api 밖의 경로여야 합니다 (현재 %q)", c.Metrics.Path)
+	}
+
+	check(c.Health.CheckTimeout > 0, "health.checktimeout", "0보다 커야 합니다 (현재 %d)", c.Health.CheckTimeout)
+	check(c.Health.FlushStaleAfter >= 0, "health.flushstaleafter", "0 이상이어야 합니다 (현재 %d)", c.Health.FlushStaleAfter)
+	check(c.Health.PollStallAfter >= 0, "health.pollstallafter", "0 이상이어야 합니다 (현재 %d)", c.Health.PollStallAfter)
+	check(c.Health.MaxBufferItems >= 0, "health.maxbufferitems", "0 이상이어야 합니다 (현재 %d)", c.Health.MaxBufferItems)
+
+	check(contains(Modes, c.Runtime.Mode), "runtime.mode", "%s 중 하나여야 합니다 (현재 %q)", strings.Join(Modes, ", "), c.Runtime.Mode)
+	if c.Runtime.Mode == ModeCleanup || c.Runtime.Mode == ModeAll {
+		// 리더 잠금이 연결 하나를 계속 사용하므로 백그라운드 작업이 쓸 연결이 더 있어야 함
+		check(c.Database.MaxConns >= 2, "database.maxconns", "%s 모드에서는 리더 잠금 연결을 포함해 2 이상이어야 합니다 (현재 %d)", c.Runtime.Mode, c.Database.MaxConns)
+	}
+	check(c.Runtime.LeaderRetryInterval > 0, "runtime.leaderretryinterval", "0보다 커야 합니다 (현재 %d)", c.Runtime.LeaderRetryInterval)
+
+	check(validPort(c.API.Port), "api.port", "1~65535 범위여야 합니다 (현재 %d)", c.API.Port)
+	check(c.API.ReadTimeout >= 0, "api.readtimeout", "0 이상이어야 합니다 (현재 %d)", c.API.ReadTimeout)
+	check(c.API.WriteTimeout >= 0, "api.writetimeout", "0 이상이어야 합니다 (현재 %d)", c.API.WriteTimeout)
+	for _, proxy := range c.API.TrustedProxies {
+		check(validIPOrCIDR(proxy), "api.trustedproxies", "IP 주소 또는 CIDR이어야 합니다 (현재 %q)", proxy)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validPort는 포트 번호가 유효한 범위인지 확인합니다.
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// validIPOrCIDR은 값이 IP 주소 또는 CIDR 표기인지 확인합니다.
+func validIPOrCIDR(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// contains는 values에 value가 있는지 확인합니다.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasEmpty는 빈 문자열 항목이 있는지 확인합니다.
+func hasEmpty(values []string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			return true
+		}
+	}
+	return false
+}
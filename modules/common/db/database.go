@@ -1,14 +1,19 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 )
 
 // Database는 데이터베이스 인터페이스입니다.
@@ -19,11 +24,25 @@ type Database interface {
 	Execute(query string, args ...interface{}) (sql.Result, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
 	Query(query string, args ...interface{}) (*sql.Rows, error)
+
+	// 컨텍스트가 취소되거나 기한이 지나면 실행 중인 쿼리를 취소하는 버전
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	ExecuteContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+
+	// 조회 API의 읽기 전용 쿼리를 정상 상태인 복제본에 라운드 로빈으로 보내는 버전
+	// 복제본이 없거나 모두 비정상이면, 또는 복제본 연결 오류가 나면 주 데이터베이스에서 실행합니다.
+	// 복제 지연만큼 최신 쓰기가 보이지 않을 수 있으므로 방금 쓴 데이터를 읽어야 하면 QueryContext를 사용합니다.
+	ReadQueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ReadQueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
 // PostgresDB는 PostgreSQL 구현체입니다.
+// 쓰기, 트랜잭션, 마이그레이션은 항상 주 데이터베이스(db)를 사용합니다.
 type PostgresDB struct {
-	db *sql.DB
+	db       *sql.DB
+	replicas *replicaSet // 읽기 전용 복제본 (설정하지 않으면 nil)
 }
 
 var (
@@ -33,34 +52,19 @@ var (
 )
 
 // NewDatabase는 새 데이터베이스 인스턴스를 생성합니다.
+// 읽기 전용 복제본이 설정되어 있으면 함께 연결하며, 복제본 연결 실패는 경고만 남기고 주 데이터베이스로 조회합니다.
 func NewDatabase() (Database, error) {
 	var err error
 
 	once.Do(func() {
 		cfg := config.GetConfig()
 
-		// 연결 문자열 생성
-		connStr := fmt.Sprintf(
-			"user=%s host=%s dbname=%s password=%s port=%d sslmode=disable",
-			cfg.Database.User,
-			cfg.Database.Host,
-			cfg.Database.DBName,
-			cfg.Database.Password,
-			cfg.Database.Port,
-		)
-
-		db, dbErr := sql.Open("postgres", connStr)
-		if dbErr != nil {
-			err = fmt.Errorf("데이터베이스 연결 실패: %w", dbErr)
+		db, openErr := openDB(cfg, cfg.Database.Host, cfg.Database.Port)
+		if openErr != nil {
+			err = fmt.Errorf("데이터베이스 연결 실패: %w", openErr)
 			return
 		}
 
-		// 설정
-		db.SetMaxOpenConns(cfg.Database.MaxConns)
-		db.SetMaxIdleConns(10)
-		db.SetConnMaxLifetime(time.Minute * 5)
-		db.SetConnMaxIdleTime(time.Second * 30)
-
 		// 연결 테스트
 		if pingErr := db.Ping(); pingErr != nil {
 			err = fmt.Errorf("데이터베이스 ping 실패: %w", pingErr)
@@ -72,7 +76,19 @@ func NewDatabase() (Database, error) {
 			Str("host", cfg.Database.Host).
 			Int("port", cfg.Database.Port).
 			Str("dbname", cfg.Database.DBName).
+			Str("sslmode", cfg.Database.SSLMode).
+			Int("statement_timeout_ms", cfg.QueryGuard.StatementTimeout).
 			Msg("데이터베이스 연결 성공")
+
+		if len(cfg.Database.Replicas) > 0 {
+			replicas, replicaErr := newReplicaSet(cfg, db)
+			if replicaErr != nil {
+				db.Close()
+				err = fmt.Errorf("읽기 전용 복제본 연결 실패: %w", replicaErr)
+				return
+			}
+			instance.replicas = replicas
+		}
 	})
 
 	if err != nil {
@@ -82,6 +98,66 @@ func NewDatabase() (Database, error) {
 	return instance, nil
 }
 
+// openDB는 host:port의 PostgreSQL에 대한 연결 풀을 만듭니다. 실제 연결은 처음 사용할 때 맺습니다.
+// 주 데이터베이스와 복제본이 같은 사용자, 비밀번호, TLS, 풀 설정을 사용합니다.
+func openDB(cfg *config.Config, host string, port int) (*sql.DB, error) {
+	// 연결 문자열 생성 (파일에서 읽은 비밀번호에 공백이나 따옴표가 있을 수 있으므로 값을 인용)
+	connStr := fmt.Sprintf(
+		"user=%s host=%s dbname=%s password=%s port=%d sslmode=%s",
+		dsnValue(cfg.Database.User),
+		dsnValue(host),
+		dsnValue(cfg.Database.DBName),
+		dsnValue(cfg.Database.Password),
+		port,
+		dsnValue(cfg.Database.SSLMode),
+	)
+
+	// TLS 인증서 (설정된 경우에만, 비어 있으면 lib/pq 기본 경로를 사용)
+	if cfg.Database.SSLRootCert != "" {
+		connStr += " sslrootcert=" + dsnValue(cfg.Database.SSLRootCert)
+	}
+	if cfg.Database.SSLCert != "" {
+		connStr += " sslcert=" + dsnValue(cfg.Database.SSLCert) + " sslkey=" + dsnValue(cfg.Database.SSLKey)
+	}
+
+	// 모든 세션에 SQL 문 실행 시간 제한 적용 (오래 걸리는 정리/집계 작업은 트랜잭션에서 해제)
+	if cfg.QueryGuard.StatementTimeout > 0 {
+		connStr += fmt.Sprintf(" statement_timeout=%d", cfg.QueryGuard.StatementTimeout)
+	}
+
+	// 모든 SQL 호출에 스팬을 생성하는 드라이버 래퍼 (전역 TracerProvider 사용)
+	attrs := otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.ServerAddress(host), semconv.ServerPort(port))
+	db, err := otelsql.Open("postgres", connStr,
+		attrs,
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter:           queryTracingEnabled,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 연결 풀 상태(사용 중/유휴 연결 수, 대기 시간)를 메트릭으로 기록
+	if metricErr := otelsql.RegisterDBStatsMetrics(db, attrs); metricErr != nil {
+		log.Warn().Err(metricErr).Str("host", host).Msg("데이터베이스 연결 풀 메트릭 등록 실패")
+	}
+
+	// 설정
+	db.SetMaxOpenConns(cfg.Database.MaxConns)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(time.Minute * 5)
+	db.SetConnMaxIdleTime(time.Second * 30)
+
+	return db, nil
+}
+
+// dsnValue는 연결 문자열 값을 작은따옴표로 인용하고 작은따옴표와 역슬래시를 이스케이프합니다.
+func dsnValue(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
 // GetInstance는 싱글톤 인스턴스를 반환합니다.
 func GetInstance() (Database, error) {
 	if instance == nil {
@@ -98,6 +174,11 @@ func (p *PostgresDB) GetDB() *sql.DB {
 // Close는 데이터베이스 연결을 종료합니다.
 func (p *PostgresDB) Close() error {
 	log.Info().Msg("데이터베이스 연결 종료 중...")
+	if p.replicas != nil {
+		if err := p.replicas.close(); err != nil {
+			log.Warn().Err(err).Msg("읽기 전용 복제본 연결 종료 실패")
+		}
+	}
 	err := p.db.Close()
 	if err != nil {
 		return fmt.Errorf("데이터베이스 연결 종료 실패: %w", err)
@@ -106,6 +187,46 @@ func (p *PostgresDB) Close() error {
 	return nil
 }
 
+// DisableStatementTimeout은 트랜잭션 안에서 SQL 문 실행 시간 제한을 해제합니다.
+// 데이터 정리나 집계처럼 API 조회 제한보다 오래 걸릴 수 있는 작업의 트랜잭션 시작 직후 호출합니다.
+func DisableStatementTimeout(tx *sql.Tx) error {
+	if _, err := tx.Exec("SET LOCAL statement_timeout = 0"); err != nil {
+		return fmt.Errorf("statement_timeout 해제 실패: %w", err)
+	}
+	return nil
+}
+
+// noQueryTracingKey는 SQL 스팬 생성을 생략하는 컨텍스트 키입니다.
+type noQueryTracingKey struct{}
+
+// WithoutQueryTracing은 ctx로 실행하는 SQL 호출의 스팬을 생성하지 않도록 합니다.
+// 배치 저장처럼 한 작업에서 행마다 SQL을 실행하는 경우 작업 단위 스팬만 남길 때 사용합니다.
+func WithoutQueryTracing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueryTracingKey{}, true)
+}
+
+// queryTracingEnabled는 otelsql SpanFilter로 WithoutQueryTracing이 적용된 호출의 스팬을 건너뜁니다.
+func queryTracingEnabled(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+	return ctx.Value(noQueryTracingKey{}) == nil
+}
+
+// primaryOnlyKey는 읽기 쿼리도 주 데이터베이스에서 실행하는 컨텍스트 키입니다.
+type primaryOnlyKey struct{}
+
+// WithPrimary는 ctx로 실행하는 ReadQuery* 호출이 복제본을 거치지 않고 주 데이터베이스에서 실행되도록 합니다.
+// 알림 평가처럼 복제 지연으로 최근 데이터를 놓치면 안 되는 작업에 사용합니다.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryOnlyKey{}, true)
+}
+
+// readReplica는 ctx로 읽을 복제본을 고릅니다. 사용할 복제본이 없거나 WithPrimary가 적용되면 nil입니다.
+func (p *PostgresDB) readReplica(ctx context.Context) *replica {
+	if p.replicas == nil || ctx.Value(primaryOnlyKey{}) != nil {
+		return nil
+	}
+	return p.replicas.pick()
+}
+
 // Begin은 새 트랜잭션을 시작합니다.
 func (p *PostgresDB) Begin() (*sql.Tx, error) {
 	return p.db.Begin()
@@ -125,3 +246,48 @@ func (p *PostgresDB) QueryRow(query string, args ...interface{}) *sql.Row {
 func (p *PostgresDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
 	return p.db.Query(query, args...)
 }
+
+// BeginTx는 ctx가 취소되면 롤백되는 새 트랜잭션을 시작합니다.
+func (p *PostgresDB) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return p.db.BeginTx(ctx, nil)
+}
+
+// ExecuteContext는 ctx가 취소되면 중단되는 SQL 쿼리를 실행합니다.
+func (p *PostgresDB) ExecuteContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.db.ExecContext(ctx, query, args...)
+}
+
+// QueryRowContext는 ctx가 취소되면 중단되는 단일 행 쿼리를 실행합니다.
+func (p *PostgresDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, args...)
+}
+
+// QueryContext는 ctx가 취소되면 중단되는 여러 행 쿼리를 실행합니다.
+func (p *PostgresDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, args...)
+}
+
+// ReadQueryRowContext는 읽기 전용 단일 행 쿼리를 복제본에서 실행합니다. WithPrimary가 적용된 ctx는 주 데이터베이스에서 실행합니다. 복제본 연결 오류가 나면 주 데이터베이스에서 다시 실행합니다.
+func (p *PostgresDB) ReadQueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if r := p.readReplica(ctx); r != nil {
+		row := r.db.QueryRowContext(ctx, query, args...)
+		err := row.Err()
+		if ctx.Err() != nil || !isConnError(err) {
+			return row
+		}
+		p.replicas.markUnhealthy(r, err)
+	}
+	return p.db.QueryRowContext(ctx, query, args...)
+}
+
+// ReadQueryContext는 읽기 전용 여러 행 쿼리를 복제본에서 실행합니다. WithPrimary가 적용된 ctx는 주 데이터베이스에서 실행합니다. 복제본 연결 오류가 나면 주 데이터베이스에서 다시 실행합니다.
+func (p *PostgresDB) ReadQueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if r := p.readReplica(ctx); r != nil {
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if ctx.Err() != nil || !isConnError(err) {
+			return rows, err
+		}
+		p.replicas.markUnhealthy(r, err)
+	}
+	return p.db.QueryContext(ctx, query, args...)
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestDSNValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "postgres", want: `'postgres'`},
+		{name: "empty", value: "", want: `''`},
+		{name: "space", value: "pass word", want: `'pass word'`},
+		{name: "quote", value: "it's", want: `'it\'s'`},
+		{name: "backslash", value: `a\b`, want: `'a\\b'`},
+		{name: "backslash before quote", value: `a\'`, want: `'a\\\''`},
+		{name: "injected option", value: "x sslmode=disable", want: `'x sslmode=disable'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dsnValue(tt.value)
+			if got != tt.want {
+				t.Fatalf("dsnValue(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+
+			// lib/pq가 인용한 값을 하나의 값으로 해석해야 함 (따옴표가 끝나지 않거나 옵션이 끼어들면 오류)
+			if _, err := pq.NewConnector("sslmode=disable password=" + got); err != nil {
+				t.Errorf("NewConnector(password=%s): %v", got, err)
+			}
+		})
+	}
+}
+
+// WithPrimary가 적용된 읽기는 정상 복제본이 있어도 주 데이터베이스로 가야 함
+func TestReadReplicaWithPrimary(t *testing.T) {
+	r := &replica{addr: "replica-1:5432"}
+	r.healthy.Store(true)
+	p := &PostgresDB{replicas: &replicaSet{replicas: []*replica{r}}}
+
+	if got := p.readReplica(context.Background()); got != r {
+		t.Errorf("readReplica() = %v, want the healthy replica", got)
+	}
+	if got := p.readReplica(WithPrimary(context.Background())); got != nil {
+		t.Errorf("readReplica(WithPrimary) = %v, want nil", got)
+	}
+	if got := (&PostgresDB{}).readReplica(context.Background()); got != nil {
+		t.Errorf("readReplica() without replicas = %v, want nil", got)
+	}
+}
+
+func TestCheckLag(t *testing.T) {
+	valid := func(v float64) sql.NullFloat64 { return sql.NullFloat64{Float64: v, Valid: true} }
+	const maxLag = 30 * time.Second
+
+	tests := []struct {
+		name        string
+		behindBytes sql.NullFloat64
+		lagSeconds  sql.NullFloat64
+		wantErr     bool
+	}{
+		// 주 데이터베이스에 쓰기가 없어 재생 시각은 오래됐지만 모두 따라잡음
+		{name: "caught up on idle primary", behindBytes: valid(0), lagSeconds: valid(3600)},
+		{name: "behind within max lag", behindBytes: valid(8192), lagSeconds: valid(5)},
+		// WAL 수신이 끊겨 주 데이터베이스가 앞서 나감
+		{name: "disconnected replica", behindBytes: valid(8192), lagSeconds: valid(3600), wantErr: true},
+		{name: "behind without replayed transaction", behindBytes: valid(8192), wantErr: true},
+		{name: "not a replica", lagSeconds: valid(3600)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLag(tt.behindBytes, tt.lagSeconds, maxLag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkLag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// 잠금 연결 점검, 잠금 해제 쿼리 하나의 제한 시간
+const leaderQueryTimeout = 5 * time.Second
+
+// LeaderLock은 PostgreSQL 세션 advisory lock으로 여러 인스턴스 중 하나만 작업을 실행하도록 하는 리더 선출입니다.
+// 잠금은 주 데이터베이스의 전용 연결 하나에 묶여 있어, 프로세스가 죽거나 연결이 끊기면 서버가 잠금을 풀고 다른 인스턴스가 이어받습니다.
+// 잠금을 쥔 동안 연결 풀의 연결 하나를 계속 사용합니다.
+// 트랜잭션 단위 풀링(PgBouncer transaction 모드)을 거치면 세션 잠금이 유지되지 않으므로 데이터베이스에 직접 연결해야 합니다.
+//
+// 잠금 연결이 끊긴 것은 다음 점검(interval, 점검 쿼리 제한 시간 포함)에서야 알 수 있습니다.
+// 그 사이 서버가 세션을 정리해 다른 인스턴스가 잠금을 얻으면 최대 interval + leaderQueryTimeout 동안 두 인스턴스가 함께 리더로 동작할 수 있으므로,
+// 잠금으로 보호하는 작업은 중복 실행되어도 결과가 어긋나지 않아야 합니다.
+type LeaderLock interface {
+	// Acquire는 잠금을 얻을 때까지 주기적으로 다시 시도합니다. 잠금을 얻기 전에 ctx가 취소되면 ctx.Err()를 반환합니다.
+	// 리더 자격을 잃거나 Release한 뒤 다시 호출해 선출에 다시 참여할 수 있습니다.
+	Acquire(ctx context.Context) error
+	// Lost는 마지막으로 얻은 잠금의 연결이 끊겨 리더 자격을 잃으면 닫히는 채널입니다. Acquire가 성공한 뒤 호출해야 합니다.
+	Lost() <-chan struct{}
+	// Release는 잠금을 풀고 잠금 연결을 반환합니다. 잠금을 얻지 못했으면 아무것도 하지 않습니다.
+	Release() error
+}
+
+type leaderLock struct {
+	db       *sql.DB
+	name     string
+	key      int64
+	interval time.Duration
+
+	mu       sync.Mutex
+	conn     *sql.Conn
+	lost     chan struct{}
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewLeaderLock은 name으로 구분하는 리더 잠금을 생성합니다. 같은 name을 쓰는 인스턴스끼리 하나만 잠금을 얻습니다.
+// interval마다 잠금 획득을 다시 시도하고, 잠금을 얻은 뒤에는 같은 간격으로 잠금 연결을 점검합니다.
+func NewLeaderLock(database Database, name string, interval time.Duration) LeaderLock {
+	return &leaderLock{
+		db:       database.GetDB(),
+		name:     name,
+		key:      lockKey(name),
+		interval: interval,
+		lost:     make(chan struct{}),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Acquire는 잠금을 얻을 때까지 주기적으로 다시 시도합니다.
+func (l *leaderLock) Acquire(ctx context.Context) error {
+	logged := false
+	for {
+		acquired, err := l.tryAcquire(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Warn().Err(err).Str("lock", l.name).Msg("잠금 획득 시도 실패, 다시 시도합니다")
+		case acquired:
+			log.Info().Str("lock", l.name).Int64("key", l.key).Msg("잠금 획득")
+			return nil
+		case !logged:
+			log.Info().Str("lock", l.name).Dur("retry_interval", l.interval).Msg("다른 인스턴스가 잠금을 가지고 있어 대기합니다")
+			logged = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.interval):
+		}
+	}
+}
+
+// tryAcquire는 전용 연결에서 잠금을 한 번 시도합니다. 잠금을 얻으면 연결을 보관하고, 얻지 못하면 연결을 반환합니다.
+func (l *leaderLock) tryAcquire(ctx context.Context) (bool, error) {
+	ctx = WithoutQueryTracing(ctx)
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&acquired); err != nil {
+		// 응답을 받지 못했어도 서버에서는 잠금을 얻었을 수 있으므로 연결을 버림
+		discard(conn)
+		return false, err
+	}
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+
+	// 잠금을 얻을 때마다 연결 점검과 리더 자격 상실 알림을 새로 시작
+	l.mu.Lock()
+	l.conn = conn
+	l.lost = make(chan struct{})
+	l.stopChan = make(chan struct{})
+	l.wg.Add(1)
+	go l.keepAlive(l.lost, l.stopChan)
+	l.mu.Unlock()
+	return true, nil
+}
+
+// keepAlive는 잠금 연결을 주기적으로 점검하고, 연결이 끊기면 lost를 닫습니다. stopChan이 닫히면 점검을 멈춥니다.
+func (l *leaderLock) keepAlive(lost, stopChan chan struct{}) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.ping(); err != nil {
+				log.Error().Err(err).Str("lock", l.name).Msg("리더 잠금 연결이 끊겨 리더 자격을 잃었습니다")
+				l.mu.Lock()
+				discard(l.conn)
+				l.conn = nil
+				l.mu.Unlock()
+				close(lost)
+				return
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// ping은 잠금 연결이 살아 있는지 확인합니다.
+func (l *leaderLock) ping() error {
+	ctx, cancel := context.WithTimeout(WithoutQueryTracing(context.Background()), leaderQueryTimeout)
+	defer cancel()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := l.conn.ExecContext(ctx, `SELECT 1`)
+	return err
+}
+
+// Lost는 리더 자격을 잃으면 닫히는 채널을 반환합니다.
+func (l *leaderLock) Lost() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lost
+}
+
+// Release는 연결 점검을 멈추고 잠금을 풀어 다른 인스턴스가 바로 이어받을 수 있게 합니다.
+func (l *leaderLock) Release() error {
+	l.mu.Lock()
+	select {
+	case <-l.stopChan:
+	default:
+		close(l.stopChan)
+	}
+	l.mu.Unlock()
+	l.wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(WithoutQueryTracing(context.Background()), leaderQueryTimeout)
+	defer cancel()
+
+	conn := l.conn
+	l.conn = nil
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+		// 연결을 닫아 세션을 끝내면 서버가 잠금을 풂
+		discard(conn)
+		return err
+	}
+	if err := conn.Close(); err != nil {
+		return err
+	}
+
+	log.Info().Str("lock", l.name).Msg("잠금 해제")
+	return nil
+}
+
+// WithLock은 name 잠금을 얻을 때까지 기다린 뒤 fn을 실행하고 잠금을 풉니다.
+// 여러 인스턴스가 동시에 시작할 때 스키마 초기화와 마이그레이션이 겹치지 않도록 사용합니다.
+// statement_timeout에 걸리지 않도록 대기하는 쿼리 대신 1초 간격으로 잠금을 다시 시도합니다.
+func WithLock(ctx context.Context, database Database, name string, fn func() error) error {
+	lock := NewLeaderLock(database, name, time.Second)
+	if err := lock.Acquire(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			log.Warn().Err(err).Str("lock", name).Msg("잠금 해제 실패")
+		}
+	}()
+	return fn()
+}
+
+// discard는 잠금 연결을 연결 풀에 돌려주지 않고 닫습니다.
+// 세션 잠금은 세션이 끝나야 풀리므로, 잠금을 쥐었을 수 있는 연결을 풀에 돌려주면 잠금이 남습니다.
+func discard(conn *sql.Conn) {
+	// Raw에서 driver.ErrBadConn을 반환하면 database/sql이 연결을 닫고 풀에서 제거
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+}
+
+// lockKey는 잠금 이름을 advisory lock 키(bigint)로 변환합니다.
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
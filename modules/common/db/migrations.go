@@ -0,0 +1,219 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+)
+
+// migrations는 기존 스키마 위에 순서대로 적용되는 멱등(idempotent) 스키마 변경 목록입니다.
+// InitializeSchema는 traces 테이블이 없을 때만 실행되므로, 이후에 추가되는 테이블과
+// 컬럼은 이미 운영 중인 데이터베이스에도 적용될 수 있도록 여기에 추가합니다.
+var migrations = []string{
+	// 서비스 간 호출 관계 집계 테이블 (서비스 의존성 그래프용)
+	`CREATE TABLE IF NOT EXISTS service_graph_edges (
+  id SERIAL PRIMARY KEY,
+  parent_service VARCHAR(128) NOT NULL,
+  child_service VARCHAR(128) NOT NULL,
+  time_bucket BIGINT NOT NULL,  -- 집계 시간대 (밀리초)
+  call_count INTEGER NOT NULL DEFAULT 0,
+  error_count INTEGER NOT NULL DEFAULT 0,
+  total_duration FLOAT NOT NULL DEFAULT 0,
+  min_duration FLOAT,
+  max_duration FLOAT,
+  p50_duration FLOAT,
+  p95_duration FLOAT,
+  p99_duration FLOAT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT service_graph_edges_unique UNIQUE (parent_service, child_service, time_bucket)
+)`,
+	`CREATE INDEX IF NOT EXISTS idx_service_graph_edges_time_bucket ON service_graph_edges(time_bucket)`,
+
+	// 스팬 종류 (SERVER, CLIENT 등)
+	`ALTER TABLE traces ADD COLUMN IF NOT EXISTS kind VARCHAR(32) NOT NULL DEFAULT 'UNSPECIFIED'`,
+
+	// 오퍼레이션(스팬 이름)별 집계 테이블
+	`CREATE TABLE IF NOT EXISTS operation_metrics (
+  id SERIAL PRIMARY KEY,
+  service_name VARCHAR(128) NOT NULL,
+  operation_name VARCHAR(255) NOT NULL,
+  span_kind VARCHAR(32) NOT NULL,
+  time_bucket BIGINT NOT NULL,  -- 집계 시간대 (밀리초)
+  request_count INTEGER NOT NULL DEFAULT 0,
+  error_count INTEGER NOT NULL DEFAULT 0,
+  total_duration FLOAT NOT NULL DEFAULT 0,
+  min_duration FLOAT,
+  max_duration FLOAT,
+  p50_duration FLOAT,
+  p95_duration FLOAT,
+  p99_duration FLOAT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT operation_metrics_unique UNIQUE (service_name, operation_name, span_kind, time_bucket)
+)`,
+	`CREATE INDEX IF NOT EXISTS idx_operation_metrics_time_bucket ON operation_metrics(time_bucket)`,
+	`CREATE INDEX IF NOT EXISTS idx_operation_metrics_service_time ON operation_metrics(service_name, time_bucket)`,
+
+	// 로그 패턴 (Drain 방식 템플릿)
+	`CREATE TABLE IF NOT EXISTS log_patterns (
+  pattern_id VARCHAR(32) PRIMARY KEY,
+  service_name VARCHAR(128) NOT NULL,
+  template TEXT NOT NULL,
+  first_seen BIGINT NOT NULL,  -- 타임스탬프 (밀리초)
+  last_seen BIGINT NOT NULL,   -- 타임스탬프 (밀리초)
+  sample TEXT,                 -- 가장 최근 원본 메시지
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+)`,
+	`CREATE INDEX IF NOT EXISTS idx_log_patterns_last_seen ON log_patterns(last_seen)`,
+	`ALTER TABLE logs ADD COLUMN IF NOT EXISTS pattern_id VARCHAR(32)`,
+	`CREATE INDEX IF NOT EXISTS idx_logs_pattern_id_timestamp ON logs(pattern_id, timestamp)`,
+
+	// 알림 규칙, 현재 상태, 상태 전환 이력
+	`CREATE TABLE IF NOT EXISTS alert_rules (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  description TEXT,
+  rule_type VARCHAR(32) NOT NULL,
+  service_name VARCHAR(128),
+  percentile INTEGER,
+  severity VARCHAR(32),
+  query TEXT,
+  operator VARCHAR(4) NOT NULL,
+  threshold FLOAT NOT NULL,
+  window_seconds BIGINT NOT NULL,
+  for_seconds BIGINT NOT NULL DEFAULT 0,
+  labels JSONB,
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at BIGINT NOT NULL,  -- 타임스탬프 (밀리초)
+  updated_at BIGINT NOT NULL   -- 타임스탬프 (밀리초)
+)`,
+	`CREATE TABLE IF NOT EXISTS alert_states (
+  rule_id BIGINT PRIMARY KEY REFERENCES alert_rules(id) ON DELETE CASCADE,
+  state VARCHAR(16) NOT NULL,
+  value FLOAT NOT NULL DEFAULT 0,
+  active_since BIGINT,     -- 조건을 처음 만족한 시간 (밀리초)
+  fired_at BIGINT,         -- firing 전환 시간 (밀리초)
+  last_evaluated BIGINT NOT NULL
+)`,
+	`CREATE TABLE IF NOT EXISTS alert_history (
+  id BIGSERIAL PRIMARY KEY,
+  rule_id BIGINT NOT NULL,
+  rule_name VARCHAR(255) NOT NULL,
+  state VARCHAR(16) NOT NULL,
+  value FLOAT NOT NULL,
+  threshold FLOAT NOT NULL,
+  message TEXT,
+  timestamp BIGINT NOT NULL  -- 타임스탬프 (밀리초)
+)`,
+	`CREATE INDEX IF NOT EXISTS idx_alert_history_timestamp ON alert_history(timestamp)`,
+	`CREATE INDEX IF NOT EXISTS idx_alert_history_rule_timestamp ON alert_history(rule_id, timestamp)`,
+
+	// 알림 전송 채널과 사일런스
+	`CREATE TABLE IF NOT EXISTS notification_channels (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  channel_type VARCHAR(32) NOT NULL,
+  url TEXT,
+  headers JSONB,
+  recipients JSONB,
+  matchers JSONB,
+  group_by JSONB,
+  template TEXT,
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at BIGINT NOT NULL,  -- 타임스탬프 (밀리초)
+  updated_at BIGINT NOT NULL   -- 타임스탬프 (밀리초)
+)`,
+	`CREATE TABLE IF NOT EXISTS alert_silences (
+  id BIGSERIAL PRIMARY KEY,
+  matchers JSONB NOT NULL,
+  starts_at BIGINT NOT NULL,   -- 타임스탬프 (밀리초)
+  ends_at BIGINT NOT NULL,     -- 타임스탬프 (밀리초)
+  comment TEXT,
+  created_by VARCHAR(255),
+  created_at BIGINT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS idx_alert_silences_ends_at ON alert_silences(ends_at)`,
+
+	// 멀티 테넌시: 테넌트 컬럼 (기존 데이터는 기본 테넌트)
+	`ALTER TABLE traces ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`CREATE INDEX IF NOT EXISTS idx_traces_tenant_start_time ON traces(tenant_id, start_time)`,
+	`ALTER TABLE logs ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`CREATE INDEX IF NOT EXISTS idx_logs_tenant_timestamp ON logs(tenant_id, timestamp)`,
+	`ALTER TABLE log_patterns ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+
+	// 집계 테이블은 테넌트별로 집계하도록 유일 제약 조건에 테넌트를 포함
+	`ALTER TABLE service_metrics ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`ALTER TABLE service_metrics DROP CONSTRAINT IF EXISTS service_metrics_service_time_unique`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS service_metrics_tenant_unique ON service_metrics(tenant_id, service_name, time_bucket)`,
+	`ALTER TABLE service_graph_edges ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`ALTER TABLE service_graph_edges DROP CONSTRAINT IF EXISTS service_graph_edges_unique`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS service_graph_edges_tenant_unique ON service_graph_edges(tenant_id, parent_service, child_service, time_bucket)`,
+	`ALTER TABLE operation_metrics ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`ALTER TABLE operation_metrics DROP CONSTRAINT IF EXISTS operation_metrics_unique`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS operation_metrics_tenant_unique ON operation_metrics(tenant_id, service_name, operation_name, span_kind, time_bucket)`,
+
+	// 트레이스와 로그의 ID는 테넌트마다 따로 유일하도록 기본 키와 유일 제약 조건에 테넌트를 포함
+	`DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'traces_tenant_pkey') THEN
+    ALTER TABLE traces DROP CONSTRAINT IF EXISTS traces_pkey;
+    ALTER TABLE traces ADD CONSTRAINT traces_tenant_pkey PRIMARY KEY (tenant_id, id);
+  END IF;
+END $$`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS traces_tenant_trace_span_unique ON traces(tenant_id, trace_id, span_id)`,
+	`ALTER TABLE traces DROP CONSTRAINT IF EXISTS traces_trace_id_span_id_unique`,
+	`DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'logs_tenant_pkey') THEN
+    ALTER TABLE logs DROP CONSTRAINT IF EXISTS logs_pkey;
+    ALTER TABLE logs ADD CONSTRAINT logs_tenant_pkey PRIMARY KEY (tenant_id, id);
+  END IF;
+END $$`,
+
+	// 로그 패턴 ID도 테넌트마다 따로 유일하도록 기본 키에 테넌트를 포함
+	`DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'log_patterns_tenant_pkey') THEN
+    ALTER TABLE log_patterns DROP CONSTRAINT IF EXISTS log_patterns_pkey;
+    ALTER TABLE log_patterns ADD CONSTRAINT log_patterns_tenant_pkey PRIMARY KEY (tenant_id, pattern_id);
+  END IF;
+END $$`,
+
+	// 알림 규칙, 이력, 채널, 사일런스도 테넌트별로 관리
+	`ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`ALTER TABLE alert_history ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`ALTER TABLE notification_channels ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+	`ALTER TABLE alert_silences ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default'`,
+
+	// API 키 (평문 키는 저장하지 않고 SHA-256 해시만 보관)
+	`CREATE TABLE IF NOT EXISTS api_keys (
+		id BIGSERIAL PRIMARY KEY,
+		tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+		name VARCHAR(255) NOT NULL,
+		key_hash CHAR(64) NOT NULL UNIQUE,
+		prefix VARCHAR(16) NOT NULL,
+		role VARCHAR(16) NOT NULL,
+		created_by VARCHAR(255),
+		created_at BIGINT NOT NULL,
+		expires_at BIGINT,
+		last_used_at BIGINT,
+		revoked_at BIGINT
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_api_keys_tenant ON api_keys(tenant_id, created_at DESC)`,
+}
+
+// ApplyMigrations는 등록된 스키마 변경을 순서대로 적용합니다.
+func ApplyMigrations(db Database) error {
+	log := logger.GetLogger()
+
+	for i, migration := range migrations {
+		if _, err := db.Execute(migration); err != nil {
+			log.Error().Err(err).Int("index", i).Msg("스키마 마이그레이션 실패")
+			return fmt.Errorf("스키마 마이그레이션 %d 실패: %w", i, err)
+		}
+	}
+
+	log.Info().Int("count", len(migrations)).Msg("스키마 마이그레이션 적용 완료")
+	return nil
+}
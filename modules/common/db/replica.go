@@ -0,0 +1,230 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// 복제본 점검 쿼리 하나의 제한 시간
+const replicaCheckTimeout = 3 * time.Second
+
+// replica는 읽기 전용 복제본 하나의 연결 풀과 상태입니다.
+type replica struct {
+	addr    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicaSet은 상태 점검을 통과한 복제본에 읽기 쿼리를 라운드 로빈으로 분배합니다.
+type replicaSet struct {
+	primary  *sql.DB // 복제 지연을 비교할 주 데이터베이스
+	replicas []*replica
+	next     atomic.Uint64
+	maxLag   time.Duration
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// newReplicaSet은 설정한 복제본에 대한 연결 풀을 만들고, 한 번 점검한 뒤 주기적인 점검을 시작합니다.
+// 점검에 실패한 복제본은 다시 통과할 때까지 쿼리를 받지 않습니다.
+func newReplicaSet(cfg *config.Config, primary *sql.DB) (*replicaSet, error) {
+	s := &replicaSet{
+		primary:  primary,
+		maxLag:   time.Duration(cfg.Database.ReplicaMaxLag) * time.Second,
+		stopChan: make(chan struct{}),
+	}
+
+	for _, addr := range cfg.Database.Replicas {
+		host, port, err := splitHostPort(addr, cfg.Database.Port)
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		db, err := openDB(cfg, host, port)
+		if err != nil {
+			s.close()
+			return nil, fmt.Errorf("%s: %w", addr, err)
+		}
+		s.replicas = append(s.replicas, &replica{addr: addr, db: db})
+	}
+
+	s.checkAll()
+
+	healthy := 0
+	for _, r := range s.replicas {
+		if r.healthy.Load() {
+			healthy++
+		}
+	}
+	log.Info().
+		Strs("replicas", cfg.Database.Replicas).
+		Int("healthy", healthy).
+		Int("max_lag_seconds", cfg.Database.ReplicaMaxLag).
+		Msg("읽기 전용 복제본 연결 완료")
+
+	interval := time.Duration(cfg.Database.ReplicaCheckInterval) * time.Second
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.checkAll()
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// pick은 라운드 로빈 순서로 정상 상태인 복제본을 반환합니다. 정상 복제본이 없으면 nil입니다.
+func (s *replicaSet) pick() *replica {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := s.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// checkAll은 모든 복제본을 동시에 점검하고 상태가 바뀐 복제본을 로그로 남깁니다.
+func (s *replicaSet) checkAll() {
+	var wg sync.WaitGroup
+	for _, r := range s.replicas {
+		wg.Add(1)
+		go func(r *replica) {
+			defer wg.Done()
+			err := s.check(r)
+			wasHealthy := r.healthy.Swap(err == nil)
+			switch {
+			case err != nil && wasHealthy:
+				log.Warn().Err(err).Str("replica", r.addr).Msg("읽기 전용 복제본 제외, 주 데이터베이스로 조회합니다")
+			case err != nil:
+				log.Debug().Err(err).Str("replica", r.addr).Msg("읽기 전용 복제본 점검 실패")
+			case !wasHealthy:
+				log.Info().Str("replica", r.addr).Msg("읽기 전용 복제본 사용 시작")
+			}
+		}(r)
+	}
+	wg.Wait()
+}
+
+// check는 복제본에 연결할 수 있고 복제 지연이 허용 범위 안인지 확인합니다.
+func (s *replicaSet) check(r *replica) error {
+	ctx, cancel := context.WithTimeout(WithoutQueryTracing(context.Background()), replicaCheckTimeout)
+	defer cancel()
+
+	if s.maxLag <= 0 {
+		return r.db.PingContext(ctx)
+	}
+
+	// 주 데이터베이스의 현재 WAL 위치를 먼저 읽어야, 복제본이 그 위치까지 재생했을 때 그 시점까지 따라잡았다고 볼 수 있음
+	var primaryLSN string
+	if err := s.primary.QueryRowContext(ctx, `SELECT pg_current_wal_lsn()::text`).Scan(&primaryLSN); err != nil {
+		return fmt.Errorf("주 데이터베이스 WAL 위치 조회 실패: %w", err)
+	}
+
+	// 재생하지 못한 WAL 크기와 마지막으로 재생한 트랜잭션 이후 경과 시간 (복제본이 아니면 NULL)
+	var behindBytes, lagSeconds sql.NullFloat64
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT pg_wal_lsn_diff($1::pg_lsn, pg_last_wal_replay_lsn()),
+		       EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())`,
+		primaryLSN).Scan(&behindBytes, &lagSeconds); err != nil {
+		return err
+	}
+	return checkLag(behindBytes, lagSeconds, s.maxLag)
+}
+
+// checkLag는 복제 지연이 maxLag를 넘으면 오류를 반환합니다.
+// 주 데이터베이스의 WAL 위치까지 재생했으면 쓰기가 없어 재생 시각이 오래됐더라도 지연이 없는 것으로 봅니다.
+// WAL 수신이 끊긴 복제본은 주 데이터베이스가 앞서 나가므로 경과 시간으로 지연을 판단합니다.
+func checkLag(behindBytes, lagSeconds sql.NullFloat64, maxLag time.Duration) error {
+	if !behindBytes.Valid || behindBytes.Float64 <= 0 {
+		return nil
+	}
+	if !lagSeconds.Valid {
+		return fmt.Errorf("복제본이 주 데이터베이스보다 %.0f바이트 뒤처져 있고 재생한 트랜잭션이 없습니다", behindBytes.Float64)
+	}
+	if lag := time.Duration(lagSeconds.Float64 * float64(time.Second)); lag > maxLag {
+		return fmt.Errorf("복제 지연 %s가 허용 범위 %s를 넘었습니다", lag.Round(time.Second), maxLag)
+	}
+	return nil
+}
+
+// markUnhealthy는 쿼리 중 연결 오류가 난 복제본을 다음 점검을 통과할 때까지 제외합니다.
+func (s *replicaSet) markUnhealthy(r *replica, err error) {
+	if r.healthy.Swap(false) {
+		log.Warn().Err(err).Str("replica", r.addr).Msg("읽기 전용 복제본 연결 오류, 주 데이터베이스로 조회합니다")
+	}
+}
+
+// close는 상태 점검을 멈추고 모든 복제본 연결을 종료합니다.
+func (s *replicaSet) close() error {
+	select {
+	case <-s.stopChan:
+	default:
+		close(s.stopChan)
+	}
+	s.wg.Wait()
+
+	var errs []error
+	for _, r := range s.replicas {
+		if err := r.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.addr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isConnError는 다른 서버에서 다시 시도할 만한 연결 오류인지 확인합니다.
+// SQL 문법 오류나 제한 시간 초과 같은 쿼리 자체의 오류는 주 데이터베이스에서도 같으므로 제외합니다.
+func isConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// 08: connection_exception, 57P: 서버 종료, 재시작 중 (admin_shutdown, cannot_connect_now 등)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		class := string(pqErr.Code.Class())
+		return class == "08" || (class == "57" && len(pqErr.Code) == 5 && pqErr.Code[2] == 'P')
+	}
+	return false
+}
+
+// splitHostPort는 "host" 또는 "host:port" 형식의 주소를 나눕니다. 포트가 없으면 defaultPort를 사용합니다.
+func splitHostPort(addr string, defaultPort int) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		// 포트가 없는 주소
+		return addr, defaultPort, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("복제본 주소의 포트가 잘못되었습니다: %s", addr)
+	}
+	return host, port, nil
+}
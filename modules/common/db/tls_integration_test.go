@@ -0,0 +1,154 @@
+//go:build integration
+
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// TLS 구성(docker-compose.tls.yaml)의 PostgreSQL에 접속하는 통합 테스트입니다.
+// make test-tls-integration으로 실행하며, 접속 정보는 POSTGRES_* 환경 변수로 받습니다.
+
+// integrationConfig는 환경 변수로 TLS 접속 설정을 만듭니다.
+func integrationConfig(t *testing.T) *config.Config {
+	t.Helper()
+	if os.Getenv("POSTGRES_SSLROOTCERT") == "" {
+		t.Skip("POSTGRES_SSLROOTCERT is not set")
+	}
+
+	cfg := &config.Config{}
+	cfg.Database.Host = envOr("POSTGRES_HOST", "localhost")
+	cfg.Database.Port, _ = strconv.Atoi(envOr("POSTGRES_PORT", "5432"))
+	cfg.Database.User = envOr("POSTGRES_USER", "postgres")
+	cfg.Database.Password = envOr("POSTGRES_PASSWORD", "postgres")
+	cfg.Database.DBName = envOr("POSTGRES_DB", "telemetry")
+	cfg.Database.SSLMode = envOr("POSTGRES_SSLMODE", "verify-full")
+	cfg.Database.SSLRootCert = os.Getenv("POSTGRES_SSLROOTCERT")
+	cfg.Database.SSLCert = os.Getenv("POSTGRES_SSLCERT")
+	cfg.Database.SSLKey = os.Getenv("POSTGRES_SSLKEY")
+	cfg.Database.MaxConns = 2
+	return cfg
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// verify-full, SCRAM 비밀번호 인증, 클라이언트 인증서로 접속해야 함
+func TestTLSConnection(t *testing.T) {
+	cfg := integrationConfig(t)
+	if cfg.Database.SSLCert == "" {
+		t.Fatal("POSTGRES_SSLCERT is required for the mutual TLS check")
+	}
+
+	conn, err := openDB(cfg, cfg.Database.Host, cfg.Database.Port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var ssl bool
+	var clientDN string
+	err = conn.QueryRow(`SELECT ssl, COALESCE(client_dn, '') FROM pg_stat_ssl WHERE pid = pg_backend_pid()`).Scan(&ssl, &clientDN)
+	if err != nil {
+		t.Fatalf("query pg_stat_ssl: %v", err)
+	}
+	if !ssl {
+		t.Error("connection is not encrypted")
+	}
+	if want := "CN=" + cfg.Database.User; !strings.Contains(clientDN, want) {
+		t.Errorf("client_dn = %q, want %s (client certificate not presented)", clientDN, want)
+	}
+
+	var encryption string
+	if err := conn.QueryRow(`SHOW password_encryption`).Scan(&encryption); err != nil {
+		t.Fatalf("show password_encryption: %v", err)
+	}
+	if encryption != "scram-sha-256" {
+		t.Errorf("password_encryption = %q, want scram-sha-256", encryption)
+	}
+}
+
+// 신뢰하지 않는 CA나 틀린 비밀번호로는 접속하지 못해야 함
+func TestTLSConnectionRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+	}{
+		{
+			name: "untrusted ca",
+			modify: func(cfg *config.Config) {
+				cfg.Database.SSLRootCert = writeUntrustedCA(t)
+			},
+		},
+		{
+			name: "wrong password",
+			modify: func(cfg *config.Config) {
+				cfg.Database.Password = "wrong-password"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := integrationConfig(t)
+			tt.modify(cfg)
+
+			conn, err := openDB(cfg, cfg.Database.Host, cfg.Database.Port)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			if err := conn.Ping(); err == nil {
+				t.Fatal("Ping() succeeded, want error")
+			}
+		})
+	}
+}
+
+// writeUntrustedCA는 서버 인증서를 서명하지 않은 자체 서명 CA 인증서를 임시 파일로 만듭니다.
+func writeUntrustedCA(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "untrusted-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,200 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status는 점검 결과 상태입니다.
+type Status string
+
+const (
+	StatusOK       Status = "ok"       // 정상
+	StatusDegraded Status = "degraded" // 동작하지만 주의가 필요한 상태 (준비 상태는 유지)
+	StatusDown     Status = "down"     // 비정상 (준비되지 않음)
+)
+
+// Result는 구성 요소 하나의 점검 결과입니다.
+type Result struct {
+	Status     Status                 `json:"status"`
+	Message    string                 `json:"message,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	DurationMs float64                `json:"durationMs"`
+}
+
+// Check는 구성 요소 상태를 점검하는 함수입니다. ctx 기한 안에 결과를 반환해야 합니다.
+type Check func(ctx context.Context) Result
+
+// Report는 등록된 모든 점검 결과를 모은 보고서입니다.
+// 전체 상태는 점검 결과 중 가장 나쁜 상태입니다.
+type Report struct {
+	Status     Status            `json:"status"`
+	Checks     map[string]Result `json:"checks"`
+	Timestamp  time.Time         `json:"timestamp"`
+	DurationMs float64           `json:"durationMs"`
+}
+
+// Ready는 보고서 상태가 down이 아니면 true를 반환합니다.
+func (r Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Checker는 이름별 점검 함수를 등록하고 한 번에 실행합니다.
+type Checker struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]Check
+}
+
+// NewChecker는 점검마다 timeout 기한을 적용하는 Checker를 생성합니다. timeout이 0 이하이면 기한을 두지 않습니다.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+// Register는 name 점검을 등록합니다. 같은 이름이 있으면 교체합니다.
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Run은 등록된 점검을 동시에 실행해 보고서를 반환합니다.
+// 기한 안에 끝나지 않은 점검은 down으로 기록합니다.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	start := time.Now()
+	report := Report{
+		Status:    StatusOK,
+		Checks:    make(map[string]Result, len(checks)),
+		Timestamp: start,
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			result := c.run(ctx, check)
+
+			mu.Lock()
+			report.Checks[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		report.Status = worse(report.Status, result.Status)
+	}
+	report.DurationMs = millis(time.Since(start))
+	return report
+}
+
+// run은 점검 하나를 기한 안에서 실행합니다. 점검 함수가 기한을 지키지 않아도 기한이 지나면 바로 반환합니다.
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- Down(fmt.Sprintf("점검 중 패닉 발생: %v", r), nil)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	var result Result
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = Down("점검 시간이 초과되었습니다: "+ctx.Err().Error(), nil)
+	}
+	result.DurationMs = millis(time.Since(start))
+	return result
+}
+
+// Probes는 활성 상태(/livez)와 준비 상태(/readyz) 점검 묶음입니다.
+// 활성 상태는 프로세스를 재시작해야 하는지, 준비 상태는 요청과 수집을 처리할 수 있는지를 나타냅니다.
+type Probes struct {
+	Live  *Checker
+	Ready *Checker
+}
+
+// NewProbes는 점검마다 timeout 기한을 적용하는 빈 점검 묶음을 생성합니다.
+func NewProbes(timeout time.Duration) *Probes {
+	return &Probes{
+		Live:  NewChecker(timeout),
+		Ready: NewChecker(timeout),
+	}
+}
+
+// OK는 정상 결과를 생성합니다.
+func OK(details map[string]interface{}) Result {
+	return Result{Status: StatusOK, Details: details}
+}
+
+// Degraded는 주의가 필요한 결과를 생성합니다.
+func Degraded(message string, details map[string]interface{}) Result {
+	return Result{Status: StatusDegraded, Message: message, Details: details}
+}
+
+// Down은 비정상 결과를 생성합니다.
+func Down(message string, details map[string]interface{}) Result {
+	return Result{Status: StatusDown, Message: message, Details: details}
+}
+
+// Ping은 ping 함수가 오류 없이 반환하면 정상으로 판단하는 점검을 생성합니다.
+func Ping(ping func(ctx context.Context) error) Check {
+	return func(ctx context.Context) Result {
+		if err := ping(ctx); err != nil {
+			return Down(err.Error(), nil)
+		}
+		return OK(nil)
+	}
+}
+
+// worse는 두 상태 중 더 나쁜 상태를 반환합니다.
+func worse(a, b Status) Status {
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+// rank는 상태의 심각도 순서를 반환합니다.
+func rank(s Status) int {
+	switch s {
+	case StatusOK:
+		return 0
+	case StatusDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// millis는 경과 시간을 소수점 밀리초로 변환합니다.
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
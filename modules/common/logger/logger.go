@@ -3,6 +3,7 @@ package logger
 import (
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -28,16 +29,43 @@ type ZeroLogger struct {
 
 var defaultLogger *ZeroLogger
 
-// 기본 로그 레벨 설정
-var logLevel = zerolog.InfoLevel
+// 설정 다시 로드 시 로그 레벨 변경 함수를 한 번만 등록
+var reloadOnce sync.Once
 
 // Init은 로거를 초기화합니다.
 func Init() Logger {
 	cfg := config.GetConfig()
 	
-	// 환경 변수에서 로그 레벨 가져오기
-	configLogLevel := strings.ToLower(cfg.Logger.Level)
-	switch configLogLevel {
+	// 환경 변수에서 로그 레벨 가져오기 (설정을 다시 로드하면 바뀐 레벨을 바로 적용)
+	SetLevel(cfg.Logger.Level)
+	reloadOnce.Do(func() {
+		config.OnReload(func(cfg *config.Config) {
+			SetLevel(cfg.Logger.Level)
+		})
+	})
+
+	// 개발 환경에서는 콘솔 형식으로 출력, 프로덕션에서는 JSON 형식으로 출력
+	var zl zerolog.Logger
+	
+	if cfg.Logger.IsDev {
+		output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: "2006-01-02 15:04:05"}
+		zl = zerolog.New(output).With().Timestamp().Caller().Logger()
+	} else {
+		zl = zerolog.New(os.Stdout).With().Timestamp().Logger()
+	}
+
+	// 기본 로거 설정
+	log.Logger = zl
+	defaultLogger = &ZeroLogger{logger: zl}
+	
+	return defaultLogger
+}
+
+// SetLevel은 모든 로거에 적용되는 로그 레벨을 바꿉니다. 알 수 없는 레벨이면 info를 사용합니다.
+// 로거별 레벨 대신 전역 레벨을 사용하므로 이미 만들어진 로거(WithField 등으로 복사한 로거 포함)에도 바로 적용됩니다.
+func SetLevel(level string) {
+	logLevel := zerolog.InfoLevel
+	switch strings.ToLower(level) {
 	case "trace":
 		logLevel = zerolog.TraceLevel
 	case "debug":
@@ -53,22 +81,7 @@ func Init() Logger {
 	case "panic":
 		logLevel = zerolog.PanicLevel
 	}
-
-	// 개발 환경에서는 콘솔 형식으로 출력, 프로덕션에서는 JSON 형식으로 출력
-	var zl zerolog.Logger
-	
-	if cfg.Logger.IsDev {
-		output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: "2006-01-02 15:04:05"}
-		zl = zerolog.New(output).With().Timestamp().Caller().Logger().Level(logLevel)
-	} else {
-		zl = zerolog.New(os.Stdout).With().Timestamp().Logger().Level(logLevel)
-	}
-
-	// 기본 로거 설정
-	log.Logger = zl
-	defaultLogger = &ZeroLogger{logger: zl}
-	
-	return defaultLogger
+	zerolog.SetGlobalLevel(logLevel)
 }
 
 // GetLogger는 현재 로거 인스턴스를 반환합니다.
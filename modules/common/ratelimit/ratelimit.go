@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket은 초당 rate개씩 토큰을 채우고 최대 burst개까지 몰아서 허용하는 토큰 버킷입니다.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket은 가득 찬 토큰 버킷을 생성합니다.
+// rate가 0 이하이면 nil(제한 없음)을 반환하고, burst가 0 이하이면 rate와 같게 설정합니다.
+func NewTokenBucket(rate, burst int) *TokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = rate
+	}
+	return &TokenBucket{rate: float64(rate), burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Allow는 토큰을 하나 소비할 수 있으면 true를 반환합니다.
+func (b *TokenBucket) Allow() bool {
+	ok, _ := b.Take()
+	return ok
+}
+
+// Take는 토큰을 하나 소비합니다. 토큰이 없으면 false와 다음 토큰이 생길 때까지의 대기 시간을 반환합니다.
+func (b *TokenBucket) Take() (bool, time.Duration) {
+	if b == nil {
+		return true, 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Remaining은 현재 남은 토큰 수(소수점 이하 버림)를 반환합니다.
+func (b *TokenBucket) Remaining() int {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return int(b.tokens)
+}
+
+// 오래 사용하지 않은 클라이언트 버킷 정리 주기
+const sweepInterval = time.Minute
+
+// Limiter는 클라이언트 키별 토큰 버킷을 관리합니다.
+// idle 시간 동안 사용하지 않은 버킷은 주기적으로 제거해 메모리가 클라이언트 수만큼 계속 늘지 않도록 합니다.
+type Limiter struct {
+	rate  int
+	burst int
+	idle  time.Duration
+
+	mu        sync.Mutex
+	buckets   map[string]*limiterEntry
+	lastSweep time.Time
+}
+
+// limiterEntry는 클라이언트 버킷과 마지막 사용 시각입니다.
+type limiterEntry struct {
+	bucket   *TokenBucket
+	lastSeen time.Time
+}
+
+// NewLimiter는 클라이언트별 초당 rate개, 최대 burst개 요청을 허용하는 Limiter를 생성합니다.
+// rate가 0 이하이면 nil(제한 없음)을 반환합니다.
+func NewLimiter(rate, burst int, idle time.Duration) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &Limiter{
+		rate:      rate,
+		burst:     burst,
+		idle:      idle,
+		buckets:   make(map[string]*limiterEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow는 key 클라이언트의 요청을 허용하는지와 남은 토큰 수, 거부 시 재시도까지의 대기 시간을 반환합니다.
+func (l *Limiter) Allow(key string) (bool, int, time.Duration) {
+	if l == nil {
+		return true, 0, 0
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+	entry, ok := l.buckets[key]
+	if !ok {
+		entry = &limiterEntry{bucket: NewTokenBucket(l.rate, l.burst)}
+		l.buckets[key] = entry
+	}
+	entry.lastSeen = now
+	l.mu.Unlock()
+
+	allowed, retryAfter := entry.bucket.Take()
+	return allowed, entry.bucket.Remaining(), retryAfter
+}
+
+// Limit는 클라이언트별 최대 순간 요청 수를 반환합니다.
+func (l *Limiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+	if l.burst <= 0 {
+		return l.rate
+	}
+	return l.burst
+}
+
+// sweep은 idle 시간 동안 사용하지 않은 버킷을 제거합니다. 호출자가 mu를 잡고 있어야 합니다.
+func (l *Limiter) sweep(now time.Time) {
+	for key, entry := range l.buckets {
+		if now.Sub(entry.lastSeen) >= l.idle {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
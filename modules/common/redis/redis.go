@@ -2,6 +2,11 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
 	"sync"
 	"time"
 
@@ -21,6 +26,9 @@ type Client interface {
 	// Delete는 키를 삭제합니다.
 	Delete(ctx context.Context, key string) error
 
+	// Ping은 Redis 서버 연결 상태를 확인합니다.
+	Ping(ctx context.Context) error
+
 	// Close는 Redis 연결을 종료합니다.
 	Close() error
 }
@@ -47,12 +55,22 @@ func NewRedisClient() (Client, error) {
 		cfg := config.GetConfig()
 		log := logger.GetLogger()
 
+		// TLS 설정 (인증서 파일 오류는 재시도해도 해결되지 않으므로 바로 실패)
+		var tlsConfig *tls.Config
+		if cfg.Redis.TLSEnabled {
+			if tlsConfig, err = newTLSConfig(cfg); err != nil {
+				log.Error().Err(err).Msg("Redis TLS 설정 실패")
+				return
+			}
+		}
+
 		for retryCount < maxRetries {
 			client := redis.NewClient(&redis.Options{
-				Addr:     cfg.Redis.Address,
-				Password: cfg.Redis.Password,
-				DB:       cfg.Redis.DB,
-				PoolSize: cfg.Redis.PoolSize,
+				Addr:      cfg.Redis.Address,
+				Password:  cfg.Redis.Password,
+				DB:        cfg.Redis.DB,
+				PoolSize:  cfg.Redis.PoolSize,
+				TLSConfig: tlsConfig,
 			})
 
 			// 연결 테스트
@@ -75,6 +93,7 @@ func NewRedisClient() (Client, error) {
 				Str("addr", cfg.Redis.Address).
 				Int("db", cfg.Redis.DB).
 				Int("poolSize", cfg.Redis.PoolSize).
+				Bool("tls", cfg.Redis.TLSEnabled).
 				Msg("Redis 연결 성공")
 
 			return
@@ -90,6 +109,39 @@ func NewRedisClient() (Client, error) {
 	return instance, nil
 }
 
+// newTLSConfig는 Redis 연결용 TLS 설정을 만듭니다.
+// CA 인증서가 없으면 시스템 CA로 서버 인증서를 검증하고, 클라이언트 인증서가 있으면 상호 TLS를 사용합니다.
+func newTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	// 서버 이름 검증에 사용할 호스트 이름
+	if host, _, err := net.SplitHostPort(cfg.Redis.Address); err == nil {
+		tlsConfig.ServerName = host
+	}
+
+	if cfg.Redis.TLSCACert != "" {
+		caCert, err := os.ReadFile(cfg.Redis.TLSCACert)
+		if err != nil {
+			return nil, fmt.Errorf("CA 인증서 읽기 실패: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("CA 인증서에 PEM 인증서가 없습니다: %s", cfg.Redis.TLSCACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.Redis.TLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.Redis.TLSCert, cfg.Redis.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("클라이언트 인증서 읽기 실패: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 // GetInstance는 싱글톤 인스턴스를 반환합니다.
 func GetInstance() (Client, error) {
 	if instance == nil {
@@ -117,6 +169,11 @@ func (r *RedisClient) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// Ping은 Redis 서버 연결 상태를 확인합니다.
+func (r *RedisClient) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 // Close는 Redis 연결을 종료합니다.
 func (r *RedisClient) Close() error {
 	r.log.Info().Msg("Redis 연결 종료 중...")
@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/ratelimit"
+)
+
+var (
+	// ErrTooManySubscribers는 구독자 수가 최대값에 도달했을 때 반환됩니다.
+	ErrTooManySubscribers = errors.New("too many subscribers")
+	// ErrHubClosed는 종료된 허브에 구독하려 할 때 반환됩니다.
+	ErrHubClosed = errors.New("hub closed")
+)
+
+// Hub는 수집된 항목을 구독자에게 전달하는 프로세스 내 pub/sub 허브입니다.
+// Publish는 절대 블로킹되지 않으며, 구독자의 버퍼가 가득 차거나 전송 속도 제한을 넘으면 항목을 버립니다.
+type Hub[T any] struct {
+	mu             sync.RWMutex
+	subscribers    map[uint64]*Subscription[T]
+	nextID         uint64
+	maxSubscribers int
+	bufferSize     int
+	closed         bool
+}
+
+// NewHub는 새 허브를 생성합니다.
+func NewHub[T any](maxSubscribers, bufferSize int) *Hub[T] {
+	return &Hub[T]{
+		subscribers:    make(map[uint64]*Subscription[T]),
+		maxSubscribers: maxSubscribers,
+		bufferSize:     bufferSize,
+	}
+}
+
+// Subscribe는 match 조건에 맞는 항목을 초당 ratePerSecond개까지 받는 구독을 생성합니다.
+// ratePerSecond가 0 이하이면 속도 제한을 적용하지 않습니다.
+func (h *Hub[T]) Subscribe(match func(T) bool, ratePerSecond int) (*Subscription[T], error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	if h.maxSubscribers > 0 && len(h.subscribers) >= h.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+
+	h.nextID++
+	sub := &Subscription[T]{
+		id:      h.nextID,
+		hub:     h,
+		ch:      make(chan T, h.bufferSize),
+		match:   match,
+		limiter: ratelimit.NewTokenBucket(ratePerSecond, ratePerSecond),
+	}
+	h.subscribers[sub.id] = sub
+	return sub, nil
+}
+
+// Publish는 항목을 조건에 맞는 모든 구독자에게 전달합니다.
+func (h *Hub[T]) Publish(items []T) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.closed || len(h.subscribers) == 0 {
+		return
+	}
+
+	for _, sub := range h.subscribers {
+		for _, item := range items {
+			if sub.match != nil && !sub.match(item) {
+				continue
+			}
+			if !sub.limiter.Allow() {
+				atomic.AddUint64(&sub.dropped, 1)
+				continue
+			}
+			select {
+			case sub.ch <- item:
+			default:
+				atomic.AddUint64(&sub.dropped, 1)
+			}
+		}
+	}
+}
+
+// Subscribers는 현재 구독자 수를 반환합니다.
+func (h *Hub[T]) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
+// Close는 허브를 종료하고 모든 구독의 채널을 닫습니다.
+func (h *Hub[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for id, sub := range h.subscribers {
+		close(sub.ch)
+		delete(h.subscribers, id)
+	}
+}
+
+// unsubscribe는 구독을 허브에서 제거하고 채널을 닫습니다.
+func (h *Hub[T]) unsubscribe(sub *Subscription[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub.id]; ok {
+		close(sub.ch)
+		delete(h.subscribers, sub.id)
+	}
+}
+
+// Subscription은 허브 구독입니다.
+type Subscription[T any] struct {
+	id      uint64
+	hub     *Hub[T]
+	ch      chan T
+	match   func(T) bool
+	limiter *ratelimit.TokenBucket
+	dropped uint64
+	once    sync.Once
+}
+
+// C는 구독한 항목을 받는 채널을 반환합니다. 구독이 해제되거나 허브가 종료되면 닫힙니다.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Dropped는 버퍼 초과 또는 속도 제한으로 버려진 항목 수를 반환합니다.
+func (s *Subscription[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close는 구독을 해제합니다.
+func (s *Subscription[T]) Close() {
+	s.once.Do(func() {
+		s.hub.unsubscribe(s)
+	})
+}
@@ -0,0 +1,41 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// LoopbackSink는 loopback 방식에서 내보낸 스팬을 자체 수집 경로로 전달하는 함수입니다.
+// Kafka 메시지와 같은 OTLP ResourceSpans 형식으로 전달되므로 같은 프로세서로 변환할 수 있습니다.
+type LoopbackSink func(ctx context.Context, resourceSpans []*tracepb.ResourceSpans) error
+
+// loopbackClient는 OTLP 트레이스 exporter의 전송 계층을 네트워크 대신 LoopbackSink로 대체합니다.
+type loopbackClient struct {
+	sink atomic.Pointer[LoopbackSink]
+}
+
+// setSink는 스팬을 전달할 수집 경로를 설정합니다.
+func (c *loopbackClient) setSink(sink LoopbackSink) {
+	c.sink.Store(&sink)
+}
+
+// Start는 otlptrace.Client 구현으로 연결할 대상이 없으므로 아무것도 하지 않습니다.
+func (c *loopbackClient) Start(ctx context.Context) error {
+	return nil
+}
+
+// Stop은 otlptrace.Client 구현으로 정리할 연결이 없으므로 아무것도 하지 않습니다.
+func (c *loopbackClient) Stop(ctx context.Context) error {
+	return nil
+}
+
+// UploadTraces는 스팬을 수집 경로로 전달합니다. 수집 경로가 연결되기 전의 스팬은 버립니다.
+func (c *loopbackClient) UploadTraces(ctx context.Context, resourceSpans []*tracepb.ResourceSpans) error {
+	sink := c.sink.Load()
+	if sink == nil || *sink == nil {
+		return nil
+	}
+	return (*sink)(ctx, resourceSpans)
+}
@@ -0,0 +1,178 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// 자체 텔레메트리 내보내기 방식
+const (
+	ExporterOTLP     = "otlp"     // OTLP/HTTP로 컬렉터에 전송
+	ExporterLoopback = "loopback" // 컬렉터 없이 자체 수집 경로로 바로 저장
+)
+
+// serviceVersion은 자체 텔레메트리 리소스의 service.version입니다.
+const serviceVersion = "1.0.0"
+
+// Provider는 자체 텔레메트리용 OpenTelemetry SDK 프로바이더를 관리합니다.
+// Setup이 전역 TracerProvider, MeterProvider를 설정하므로 각 패키지는 otel.Tracer, otel.Meter로 계측합니다.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	loopback       *loopbackClient
+}
+
+// Setup은 설정에 따라 OpenTelemetry SDK를 초기화하고 전역 프로바이더로 등록합니다.
+// Prometheus 메트릭이 활성화되어 있으면 자체 텔레메트리 설정과 관계없이 메트릭을 Prometheus 기본 레지스트리에 등록합니다.
+// 둘 다 비활성화되어 있으면 전파기만 등록하고 아무것도 내보내지 않는 Provider를 반환합니다.
+// loopback 방식은 트레이스만 내보내며, 스팬은 SetLoopback으로 수집 경로를 연결하기 전까지 버려집니다.
+func Setup(ctx context.Context, cfg *config.Config, log logger.Logger) (*Provider, error) {
+	// 들어오는 요청의 traceparent를 이어받고 나가는 요청에 전달
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	provider := &Provider{}
+	if !cfg.Telemetry.Enabled && !cfg.Metrics.Enabled {
+		log.Info().Msg("자체 텔레메트리 및 Prometheus 메트릭 비활성화")
+		return provider, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.Telemetry.ServiceName),
+			semconv.ServiceVersion(serviceVersion),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("텔레메트리 리소스 생성 실패: %w", err)
+	}
+
+	var spanExporter sdktrace.SpanExporter
+	var metricReaders []sdkmetric.Reader
+
+	if cfg.Metrics.Enabled {
+		// 스코프 정보 레이블 없이 Prometheus 기본 레지스트리에 등록 (API 서버의 메트릭 경로에서 노출)
+		promExporter, err := otelprom.New(otelprom.WithoutScopeInfo())
+		if err != nil {
+			return nil, fmt.Errorf("Prometheus 메트릭 exporter 생성 실패: %w", err)
+		}
+		metricReaders = append(metricReaders, promExporter)
+	}
+
+	switch {
+	case !cfg.Telemetry.Enabled:
+		log.Info().Msg("자체 텔레메트리 내보내기 비활성화")
+
+	case cfg.Telemetry.Exporter == ExporterOTLP:
+		traceOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Telemetry.Endpoint)}
+		metricOptions := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Telemetry.Endpoint)}
+		if cfg.Telemetry.Insecure {
+			traceOptions = append(traceOptions, otlptracehttp.WithInsecure())
+			metricOptions = append(metricOptions, otlpmetrichttp.WithInsecure())
+		}
+		if len(cfg.Telemetry.Headers) > 0 {
+			traceOptions = append(traceOptions, otlptracehttp.WithHeaders(cfg.Telemetry.Headers))
+			metricOptions = append(metricOptions, otlpmetrichttp.WithHeaders(cfg.Telemetry.Headers))
+		}
+
+		spanExporter, err = otlptracehttp.New(ctx, traceOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("OTLP 트레이스 exporter 생성 실패: %w", err)
+		}
+		metricExporter, err := otlpmetrichttp.New(ctx, metricOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("OTLP 메트릭 exporter 생성 실패: %w", err)
+		}
+		metricReaders = append(metricReaders, sdkmetric.NewPeriodicReader(metricExporter,
+			sdkmetric.WithInterval(time.Duration(cfg.Telemetry.MetricInterval)*time.Second)))
+
+	case cfg.Telemetry.Exporter == ExporterLoopback:
+		// 저장소에 메트릭 테이블이 없으므로 loopback은 트레이스만 내보냄
+		provider.loopback = &loopbackClient{}
+		spanExporter, err = otlptrace.New(ctx, provider.loopback)
+		if err != nil {
+			return nil, fmt.Errorf("loopback 트레이스 exporter 생성 실패: %w", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("지원하지 않는 텔레메트리 exporter입니다: %q (otlp, loopback)", cfg.Telemetry.Exporter)
+	}
+
+	if spanExporter != nil {
+		provider.tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithResource(res),
+			sdktrace.WithBatcher(spanExporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Telemetry.SampleRatio))),
+		)
+		otel.SetTracerProvider(provider.tracerProvider)
+
+		log.Info().
+			Str("exporter", cfg.Telemetry.Exporter).
+			Str("endpoint", cfg.Telemetry.Endpoint).
+			Str("service_name", cfg.Telemetry.ServiceName).
+			Float64("sample_ratio", cfg.Telemetry.SampleRatio).
+			Msg("자체 텔레메트리 트레이스 내보내기 활성화")
+	}
+
+	if len(metricReaders) > 0 {
+		options := []sdkmetric.Option{sdkmetric.WithResource(res)}
+		for _, reader := range metricReaders {
+			options = append(options, sdkmetric.WithReader(reader))
+		}
+		provider.meterProvider = sdkmetric.NewMeterProvider(options...)
+		otel.SetMeterProvider(provider.meterProvider)
+
+		log.Info().
+			Bool("prometheus", cfg.Metrics.Enabled).
+			Bool("otlp", cfg.Telemetry.Enabled && cfg.Telemetry.Exporter == ExporterOTLP).
+			Msg("자체 메트릭 수집 활성화")
+	}
+
+	return provider, nil
+}
+
+// SetLoopback은 loopback 방식에서 내보낸 스팬을 저장할 수집 경로를 연결합니다.
+// loopback 방식이 아니면 아무것도 하지 않습니다.
+func (p *Provider) SetLoopback(sink LoopbackSink) {
+	if p == nil || p.loopback == nil {
+		return
+	}
+	p.loopback.setSink(sink)
+}
+
+// Shutdown은 버퍼에 남은 스팬과 메트릭을 내보낸 뒤 프로바이더를 종료합니다.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	var errs []error
+	if p.tracerProvider != nil {
+		if err := p.tracerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("트레이스 프로바이더 종료 실패: %w", err))
+		}
+	}
+	if p.meterProvider != nil {
+		if err := p.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("메트릭 프로바이더 종료 실패: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
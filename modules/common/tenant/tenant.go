@@ -0,0 +1,39 @@
+package tenant
+
+import (
+	"strings"
+)
+
+// Default는 테넌트를 알 수 없을 때 사용하는 기본 테넌트 ID입니다. 테이블의 tenant_id 컬럼 기본값과 같습니다.
+const Default = "default"
+
+// ContextKey는 gin 컨텍스트에 요청 테넌트 ID를 저장하는 키입니다.
+const ContextKey = "tenantID"
+
+// 테넌트 ID 최대 길이 (tenant_id 컬럼 크기)
+const maxLength = 64
+
+// Normalize는 테넌트 ID의 앞뒤 공백을 제거합니다.
+// 비어 있거나, 64자를 넘거나, 영문자/숫자/'.'/'_'/'-' 이외의 문자가 있으면 빈 문자열을 반환합니다.
+func Normalize(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" || len(id) > maxLength {
+		return ""
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return ""
+		}
+	}
+	return id
+}
+
+// OrDefault는 올바른 테넌트 ID면 그대로, 아니면 기본 테넌트 ID를 반환합니다.
+func OrDefault(id string) string {
+	if id = Normalize(id); id != "" {
+		return id
+	}
+	return Default
+}
@@ -9,12 +9,18 @@ import (
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
+	commonDB "github.com/seongpil0948/otel-kafka-pg/modules/common/db"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/stream"
+	"github.com/seongpil0948/otel-kafka-pg/modules/kafka/policy"
 	"github.com/seongpil0948/otel-kafka-pg/modules/kafka/processor"
 	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
 	logService "github.com/seongpil0948/otel-kafka-pg/modules/log/service"
 	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 	traceService "github.com/seongpil0948/otel-kafka-pg/modules/trace/service"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MessageBuffer는 메시지 버퍼 구조체입니다.
@@ -30,18 +36,33 @@ type Consumer interface {
 	Start(ctx context.Context) error
 	Stop() error
 	FlushBuffer() error
+	// IngestTraces는 Kafka를 거치지 않은 스팬을 메시지와 같은 수집 경로(테넌트 지정, 수집 정책, 버퍼)에 추가합니다.
+	IngestTraces(traces []traceDomain.TraceItem)
+
+	// CheckKafka는 컨슈머 실행, 브로커 연결, 파티션 할당 상태를 점검합니다. (준비 상태)
+	CheckKafka(ctx context.Context) health.Result
+	// CheckPipeline은 마지막 플러시 성공 시각과 버퍼 포화도를 점검합니다. (준비 상태)
+	CheckPipeline(ctx context.Context) health.Result
+	// CheckPolling은 폴링 루프가 멈추지 않았는지 점검합니다. (활성 상태)
+	CheckPolling(ctx context.Context) health.Result
 }
 
 // KafkaConsumer는 Kafka 소비자 구현체입니다.
 type KafkaConsumer struct {
 	client        *kafka.Consumer
 	processor     processor.Processor
+	pipeline      policy.Pipeline
+	tenants       *tenantResolver
 	traceService  traceService.TraceService
 	logService    logService.LogService
+	traceHub      *stream.Hub[traceDomain.TraceItem]
+	logHub        *stream.Hub[logDomain.LogItem]
 	cfg           *config.Config
 	log           logger.Logger
 	messageBuffer MessageBuffer
-	flushTicker   *time.Ticker
+	flushInterval chan time.Duration // 다시 로드한 플러시 주기를 타이머를 가진 periodicFlush로 전달
+	lag           partitionLag
+	state         consumerState
 	isRunning     bool
 	ctx           context.Context
 	cancel        context.CancelFunc
@@ -49,18 +70,27 @@ type KafkaConsumer struct {
 }
 
 // NewConsumer는 새 Kafka 소비자 인스턴스를 생성합니다.
+// 처리된 데이터는 테넌트 지정과 테넌트별 수집 한도, pipeline의 수집 정책(드롭 규칙, 테일 샘플링)을 거쳐 버퍼에 추가됩니다.
+// 저장에 성공한 트레이스와 로그는 traceHub, logHub로 발행되어 실시간 tail 구독자에게 전달됩니다.
 func NewConsumer(
 	proc processor.Processor, 
+	pipeline policy.Pipeline,
 	traceService traceService.TraceService,
 	logService logService.LogService,
+	traceHub *stream.Hub[traceDomain.TraceItem],
+	logHub *stream.Hub[logDomain.LogItem],
 ) Consumer {
 	cfg := config.GetConfig()
 	log := logger.GetLogger()
 
-	return &KafkaConsumer{
+	c := &KafkaConsumer{
 		processor:     proc,
+		pipeline:      pipeline,
+		tenants:       newTenantResolver(cfg),
 		traceService:  traceService,
 		logService:    logService,
+		traceHub:      traceHub,
+		logHub:        logHub,
 		cfg:           cfg,
 		log:           log,
 		messageBuffer: MessageBuffer{
@@ -68,8 +98,26 @@ func NewConsumer(
 			Logs:         []logDomain.LogItem{},
 			LastFlushTime: time.Now(),
 		},
+		flushInterval: make(chan time.Duration, 1),
 		isRunning: false,
 	}
+
+	if err := c.registerMetrics(); err != nil {
+		log.Warn().Err(err).Msg("Kafka 컨슈머 메트릭 등록 실패")
+	}
+
+	// 설정을 다시 로드해 플러시 주기가 바뀌면 실행 중인 타이머에 반영
+	// 타이머는 periodicFlush만 다루므로 새 주기를 채널로 보내고, 이전 값이 남아 있으면 최신 값으로 바꿈
+	config.OnReload(func(cfg *config.Config) {
+		interval := time.Duration(cfg.Kafka.FlushInterval) * time.Millisecond
+		select {
+		case <-c.flushInterval:
+		default:
+		}
+		c.flushInterval <- interval
+	})
+
+	return c
 }
 
 func (c *KafkaConsumer) Start(ctx context.Context) error {
@@ -104,6 +152,14 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 			"metadata.max.age.ms":            300000,
 	}
 
+	// 파티션별 컨슈머 랙을 메트릭으로 노출하기 위한 librdkafka 통계 이벤트
+	if c.cfg.Kafka.StatsInterval > 0 {
+		(*kafkaConfig)["statistics.interval.ms"] = c.cfg.Kafka.StatsInterval
+	}
+
+	// 보안 프로토콜 (SASL 인증, TLS)
+	setSecurityConfig(kafkaConfig, c.cfg)
+
 	// 소비자 생성
 	consumer, err := kafka.NewConsumer(kafkaConfig)
 	if err != nil {
@@ -112,8 +168,10 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 	}
 	c.client = consumer
 
-	// 토픽 구독
+	// 토픽 구독 (테넌트 전용 토픽 포함)
 	topics := []string{c.cfg.Kafka.TracesTopic, c.cfg.Kafka.LogsTopic}
+	topics = append(topics, topicNames(c.cfg.Tenancy.TraceTopics)...)
+	topics = append(topics, topicNames(c.cfg.Tenancy.LogTopics)...)
 	err = consumer.SubscribeTopics(topics, nil)
 	if err != nil {
 			c.log.Error().Err(err).Msg("Failed to subscribe to Kafka topics")
@@ -121,9 +179,6 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 	}
 	c.log.Info().Strs("topics", topics).Msg("Subscribed to Kafka topics")
 
-	// 플러시 타이머 시작
-	c.flushTicker = time.NewTicker(time.Duration(c.cfg.Kafka.FlushInterval) * time.Millisecond)
-
 	// 메시지 수신 고루틴 시작
 	c.wg.Add(1)
 	go func() {
@@ -139,18 +194,27 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 	}()
 
 	c.isRunning = true
+	touch(&c.state.lastFlush)
+	c.state.brokersDownAt.Store(0)
+	c.state.running.Store(true)
 	c.log.Info().Msg("Kafka consumer started successfully")
 
 	return nil
 }
 
 // periodicFlush는 주기적으로 메시지 버퍼를 플러시합니다.
+// 플러시 타이머는 이 고루틴만 다루며, 설정을 다시 로드해 바뀐 주기는 flushInterval로 받습니다.
 func (c *KafkaConsumer) periodicFlush() {
+	flushTicker := time.NewTicker(time.Duration(config.GetConfig().Kafka.FlushInterval) * time.Millisecond)
+	defer flushTicker.Stop()
+
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
-		case <-c.flushTicker.C:
+		case interval := <-c.flushInterval:
+			flushTicker.Reset(interval)
+		case <-flushTicker.C:
 			if err := c.FlushBuffer(); err != nil {
 				c.log.Error().Err(err).Msg("주기적 버퍼 플러시 중 오류 발생")
 			}
@@ -168,6 +232,8 @@ func (c *KafkaConsumer) consumeMessages() {
 			return
 		default:
 			// 메시지 폴링
+			pollStart := time.Now()
+			touch(&c.state.lastPoll)
 			ev := c.client.Poll(100) // 100ms 타임아웃으로 메시지 폴링
 			if ev == nil {
 				continue
@@ -175,8 +241,14 @@ func (c *KafkaConsumer) consumeMessages() {
 
 			switch e := ev.(type) {
 			case *kafka.Message:
-				// 메시지 처리
-				if err := c.processMessage(e); err != nil {
+				touch(&c.state.lastActivity)
+
+				// 메시지 처리 (수신 스팬 아래에 처리 스팬 생성)
+				pollCtx, pollSpan := startPollSpan(c.ctx, e, pollStart)
+				err := c.processMessage(pollCtx, e)
+				recordMessage(pollCtx, *e.TopicPartition.Topic, err)
+				endSpan(pollSpan, err)
+				if err != nil {
 					c.log.Error().Err(err).
 						Str("topic", *e.TopicPartition.Topic).
 						Int32("partition", e.TopicPartition.Partition).
@@ -190,7 +262,9 @@ func (c *KafkaConsumer) consumeMessages() {
 				logsLen := len(c.messageBuffer.Logs)
 				c.messageBuffer.mu.Unlock()
 
-				if tracesLen >= c.cfg.Kafka.BatchSize || logsLen >= c.cfg.Kafka.BatchSize {
+				// 배치 크기는 설정을 다시 로드하면 바뀔 수 있으므로 매번 현재 설정에서 읽음
+				batchSize := config.GetConfig().Kafka.BatchSize
+				if tracesLen >= batchSize || logsLen >= batchSize {
 					if err := c.FlushBuffer(); err != nil {
 						c.log.Error().Err(err).Msg("버퍼 플러시 중 오류 발생")
 					}
@@ -205,14 +279,19 @@ func (c *KafkaConsumer) consumeMessages() {
 				// 치명적인 에러인 경우 재연결 시도
 				if e.Code() == kafka.ErrAllBrokersDown ||
 				   e.Code() == kafka.ErrNetworkException {
+					touch(&c.state.brokersDownAt)
 					c.log.Error().Msg("Critical Kafka error, attempting to reconnect")
 					go c.reconnect()
 					return
 				}
 
 			case *kafka.Stats:
-				// Kafka 통계 정보 로깅
-				c.log.Debug().Msg("Kafka stats received")
+				touch(&c.state.lastActivity)
+
+				// 파티션별 컨슈머 랙 갱신
+				if err := c.lag.update(e.String()); err != nil {
+					c.log.Warn().Err(err).Msg("Kafka 통계 파싱 실패")
+				}
 
 			default:
 				// 기타 Kafka 이벤트 처리
@@ -225,44 +304,59 @@ func (c *KafkaConsumer) consumeMessages() {
 }
 
 // 메시지 처리 함수
-func (c *KafkaConsumer) processMessage(msg *kafka.Message) error {
+func (c *KafkaConsumer) processMessage(ctx context.Context, msg *kafka.Message) (err error) {
 	if msg == nil || msg.Value == nil {
 		return nil
 	}
 
+	_, span := tracer.Start(ctx, "kafka.process")
+	defer func() { endSpan(span, err) }()
+
 	topic := *msg.TopicPartition.Topic
 	
 	// 메시지 압축 해제
 	decompressedValue, err := c.processor.DecompressMessage(msg.Value)
 	if err != nil {
+		recordDecodeFailure(ctx, topic, "decompress")
 		return fmt.Errorf("message decompression failed: %w", err)
 	}
 
+	// 메시지 헤더 또는 토픽 매핑으로 정해진 테넌트
+	messageTenant := c.tenants.messageTenant(msg)
+
 	// 토픽에 따른 메시지 처리
-	switch topic {
-	case c.cfg.Kafka.TracesTopic:
+	switch {
+	case c.isTracesTopic(topic):
 		traces, err := c.processor.ProcessTraceData(decompressedValue)
 		if err != nil {
+			recordDecodeFailure(ctx, topic, "decode")
 			return fmt.Errorf("trace data processing failed: %w", err)
 		}
 		
-		if len(traces) > 0 {
-			c.messageBuffer.mu.Lock()
-			c.messageBuffer.Traces = append(c.messageBuffer.Traces, traces...)
-			c.messageBuffer.mu.Unlock()
-			c.log.Debug().Int("count", len(traces)).Msg("Processed trace data")
-		}
-
-	case c.cfg.Kafka.LogsTopic:
+		// 테넌트 지정과 수집 한도, 드롭 규칙 적용 후 샘플링이 결정된 스팬만 버퍼에 추가
+		received := len(traces)
+		traces = c.tenants.traces(messageTenant, traces)
+		traces = c.pipeline.Traces(traces)
+		c.bufferTraces(traces)
+		span.SetAttributes(attribute.Int("items.received", received), attribute.Int("items.buffered", len(traces)))
+		c.log.Debug().Int("count", received).Int("buffered", len(traces)).Msg("Processed trace data")
+
+	case c.isLogsTopic(topic):
 		logs, err := c.processor.ProcessLogData(decompressedValue)
 		if err != nil {
+			recordDecodeFailure(ctx, topic, "decode")
 			return fmt.Errorf("log data processing failed: %w", err)
 		}
 		
+		received := len(logs)
+		logs = c.tenants.logs(messageTenant, logs)
+		logs = c.pipeline.Logs(logs)
+		span.SetAttributes(attribute.Int("items.received", received), attribute.Int("items.buffered", len(logs)))
 		if len(logs) > 0 {
 			c.messageBuffer.mu.Lock()
 			c.messageBuffer.Logs = append(c.messageBuffer.Logs, logs...)
 			c.messageBuffer.mu.Unlock()
+			recordBuffered(signalLogs, len(logs))
 			c.log.Debug().Int("count", len(logs)).Msg("Processed log data")
 		}
 
@@ -273,8 +367,41 @@ func (c *KafkaConsumer) processMessage(msg *kafka.Message) error {
 	return nil
 }
 
+// isTracesTopic은 트레이스 토픽 또는 테넌트 전용 트레이스 토픽인지 확인합니다.
+func (c *KafkaConsumer) isTracesTopic(topic string) bool {
+	_, ok := c.cfg.Tenancy.TraceTopics[topic]
+	return ok || topic == c.cfg.Kafka.TracesTopic
+}
+
+// isLogsTopic은 로그 토픽 또는 테넌트 전용 로그 토픽인지 확인합니다.
+func (c *KafkaConsumer) isLogsTopic(topic string) bool {
+	_, ok := c.cfg.Tenancy.LogTopics[topic]
+	return ok || topic == c.cfg.Kafka.LogsTopic
+}
+
+// IngestTraces는 자체 텔레메트리(loopback)처럼 Kafka를 거치지 않은 스팬을 수집 경로에 추가합니다.
+// 메시지 테넌트가 없으므로 항목 속성 또는 기본 테넌트로 지정됩니다.
+func (c *KafkaConsumer) IngestTraces(traces []traceDomain.TraceItem) {
+	traces = c.tenants.traces("", traces)
+	c.bufferTraces(c.pipeline.Traces(traces))
+}
+
+// bufferTraces는 스팬을 메시지 버퍼에 추가합니다.
+func (c *KafkaConsumer) bufferTraces(traces []traceDomain.TraceItem) {
+	if len(traces) == 0 {
+		return
+	}
+	c.messageBuffer.mu.Lock()
+	c.messageBuffer.Traces = append(c.messageBuffer.Traces, traces...)
+	c.messageBuffer.mu.Unlock()
+	recordBuffered(signalTraces, len(traces))
+}
+
 // FlushBuffer는 버퍼에 있는 메시지를 데이터베이스에 저장합니다.
-func (c *KafkaConsumer) FlushBuffer() error {
+// 저장 전에 샘플링 대기 시간이 지난 트레이스의 보존 스팬을 버퍼에 추가합니다.
+func (c *KafkaConsumer) FlushBuffer() (err error) {
+	c.bufferTraces(c.pipeline.Ready(false))
+
 	// 버퍼가 비어있는지 확인
 	c.messageBuffer.mu.Lock()
 	tracesLen := len(c.messageBuffer.Traces)
@@ -283,6 +410,7 @@ func (c *KafkaConsumer) FlushBuffer() error {
 	if tracesLen == 0 && logsLen == 0 {
 		c.messageBuffer.LastFlushTime = time.Now()
 		c.messageBuffer.mu.Unlock()
+		touch(&c.state.lastFlush)
 		return nil
 	}
 
@@ -297,10 +425,23 @@ func (c *KafkaConsumer) FlushBuffer() error {
 	c.messageBuffer.LastFlushTime = time.Now()
 	c.messageBuffer.mu.Unlock()
 
+	// 종료 시 마지막 플러시도 저장되도록 취소된 컨슈머 컨텍스트 대신 별도 컨텍스트 사용
+	ctx, span := tracer.Start(context.Background(), "kafka.flush",
+		trace.WithAttributes(attribute.Int("traces", tracesLen), attribute.Int("logs", logsLen)))
+	flushStart := time.Now()
+	defer func() {
+		endSpan(span, err)
+		flushDuration.Record(ctx, time.Since(flushStart).Seconds())
+	}()
+
+	// 행마다 실행하는 INSERT 스팬은 생략하고 플러시 스팬만 남김
+	// (loopback 방식에서 자체 스팬 저장이 다시 저장 스팬을 만드는 반복도 막음)
+	ctx = commonDB.WithoutQueryTracing(ctx)
+
 	// 트레이스 데이터 저장
 	if tracesLen > 0 {
 		c.log.Info().Int("count", tracesLen).Msg("Flushing trace data to database")
-		err := c.traceService.SaveTraces(traces)
+		err := c.traceService.SaveTraces(ctx, traces)
 		if err != nil {
 			c.log.Error().Err(err).Msg("Error saving traces")
 			// 실패 시 다시 버퍼에 추가
@@ -309,12 +450,15 @@ func (c *KafkaConsumer) FlushBuffer() error {
 			c.messageBuffer.mu.Unlock()
 			return err
 		}
+		recordWritten(ctx, signalTraces, tracesLen)
+		// 실시간 tail 구독자에게 발행
+		c.traceHub.Publish(traces)
 	}
 
 	// 로그 데이터 저장
 	if logsLen > 0 {
 		c.log.Info().Int("count", logsLen).Msg("Flushing log data to database")
-		err := c.logService.SaveLogs(logs)
+		err := c.logService.SaveLogs(ctx, logs)
 		if err != nil {
 			c.log.Error().Err(err).Msg("Error saving logs")
 			// 실패 시 다시 버퍼에 추가
@@ -323,8 +467,12 @@ func (c *KafkaConsumer) FlushBuffer() error {
 			c.messageBuffer.mu.Unlock()
 			return err
 		}
+		recordWritten(ctx, signalLogs, logsLen)
+		// 실시간 tail 구독자에게 발행
+		c.logHub.Publish(logs)
 	}
 
+	touch(&c.state.lastFlush)
 	return nil
 }
 
@@ -335,6 +483,8 @@ func (c *KafkaConsumer) Stop() error {
 		return nil
 	}
 
+	c.state.running.Store(false)
+
 	// 컨텍스트 취소
 	if c.cancel != nil {
 		c.cancel()
@@ -343,17 +493,13 @@ func (c *KafkaConsumer) Stop() error {
 	// 고루틴 종료 대기
 	c.wg.Wait()
 
-	// 마지막으로 버퍼 플러시
+	// 샘플링 대기 중인 트레이스를 모두 결정한 뒤 마지막으로 버퍼 플러시
+	c.bufferTraces(c.pipeline.Ready(true))
 	err := c.FlushBuffer()
 	if err != nil {
 		c.log.Error().Err(err).Msg("Error flushing buffer during shutdown")
 	}
 
-	// 타이머 정리
-	if c.flushTicker != nil {
-		c.flushTicker.Stop()
-	}
-
 	// 컨슈머 연결 해제
 	err = c.client.Close()
 	if err != nil {
@@ -361,6 +507,20 @@ func (c *KafkaConsumer) Stop() error {
 		return err
 	}
 
+	stats := c.pipeline.Stats()
+	c.log.Info().
+		Int64("spans_received", stats.SpansReceived).
+		Interface("transforms", stats.Transforms).
+		Interface("spans_dropped", stats.SpansDropped).
+		Int64("spans_sampled_out", stats.SpansSampledOut).
+		Interface("traces_kept", stats.TracesKept).
+		Int64("traces_sampled_out", stats.TracesSampledOut).
+		Int64("logs_received", stats.LogsReceived).
+		Interface("logs_dropped", stats.LogsDropped).
+		Interface("redactions", stats.Redactions).
+		Interface("tenant_quota_dropped", c.tenants.droppedCounts()).
+		Msg("Ingestion policy stats")
+
 	c.client = nil
 	c.isRunning = false
 	c.log.Info().Msg("Kafka consumer stopped successfully")
@@ -5,13 +5,20 @@ go 1.24.2
 require (
 	github.com/confluentinc/confluent-kafka-go/v2 v2.3.0
 	github.com/seongpil0948/otel-kafka-pg/modules/common v0.0.0
+	github.com/seongpil0948/otel-kafka-pg/modules/kafka/policy v0.0.0-00010101000000-000000000000
 	github.com/seongpil0948/otel-kafka-pg/modules/kafka/processor v0.0.0-00010101000000-000000000000
 	github.com/seongpil0948/otel-kafka-pg/modules/log v0.0.0-20250505092541-8ec1922b0f76
 	github.com/seongpil0948/otel-kafka-pg/modules/trace v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/metric v1.37.0
+	go.opentelemetry.io/otel/trace v1.37.0
 )
 
 require (
+	github.com/XSAM/otelsql v0.39.0 // indirect
 	github.com/fsnotify/fsnotify v1.7.0 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.25.1 // indirect
@@ -32,12 +39,13 @@ require (
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/spf13/viper v1.18.2 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/proto/otlp v1.5.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/exp v0.0.0-20230905200255-921286631fa9 // indirect
 	golang.org/x/net v0.38.0 // indirect
-	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250102185135-69823020774d // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250102185135-69823020774d // indirect
@@ -49,6 +57,7 @@ require (
 
 replace (
 	github.com/seongpil0948/otel-kafka-pg/modules/common => ../../common
+	github.com/seongpil0948/otel-kafka-pg/modules/kafka/policy => ../policy
 	github.com/seongpil0948/otel-kafka-pg/modules/kafka/processor => ../processor
 	github.com/seongpil0948/otel-kafka-pg/modules/log => ../../log
 	github.com/seongpil0948/otel-kafka-pg/modules/trace => ../../trace
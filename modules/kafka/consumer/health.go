@@ -0,0 +1,141 @@
+package consumer
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/health"
+)
+
+// 버퍼 포화도가 이 비율 이상이면 degraded로 판단
+const bufferWarnRatio = 0.8
+
+// consumerState는 상태 점검에 사용하는 컨슈머 상태입니다.
+// 폴링, 플러시 고루틴과 점검 요청이 동시에 접근하므로 시각은 UnixNano 원자적 값으로 저장합니다.
+type consumerState struct {
+	running       atomic.Bool
+	lastPoll      atomic.Int64 // 폴링 루프가 마지막으로 돈 시각
+	lastActivity  atomic.Int64 // 브로커에서 마지막으로 메시지나 통계를 받은 시각
+	brokersDownAt atomic.Int64 // 마지막으로 모든 브로커 연결이 끊긴 시각
+	lastFlush     atomic.Int64 // 마지막으로 플러시에 성공한 시각
+}
+
+// touch는 시각 값을 현재 시각으로 갱신합니다.
+func touch(v *atomic.Int64) {
+	v.Store(time.Now().UnixNano())
+}
+
+// loadTime은 저장된 시각을 반환합니다. 기록이 없으면 zero 값입니다.
+func loadTime(v *atomic.Int64) time.Time {
+	n := v.Load()
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// CheckKafka는 컨슈머 실행 여부, 브로커 연결 상태, 파티션 할당 상태를 점검합니다.
+// 실행 중이 아니거나 모든 브로커 연결이 끊긴 뒤 아무 이벤트도 받지 못했으면 down,
+// 할당된 파티션이 없으면(리밸런싱 중이거나 파티션보다 컨슈머가 많은 경우) degraded입니다.
+func (c *KafkaConsumer) CheckKafka(_ context.Context) health.Result {
+	if !c.state.running.Load() {
+		return health.Down("Kafka 컨슈머가 실행 중이 아닙니다", nil)
+	}
+
+	details := map[string]interface{}{
+		"groupId": c.cfg.Kafka.GroupID,
+	}
+	if lastActivity := loadTime(&c.state.lastActivity); !lastActivity.IsZero() {
+		details["lastActivity"] = lastActivity
+	}
+
+	if downAt := loadTime(&c.state.brokersDownAt); !downAt.IsZero() && !loadTime(&c.state.lastActivity).After(downAt) {
+		details["brokersDownAt"] = downAt
+		return health.Down("모든 Kafka 브로커와 연결이 끊겼습니다", details)
+	}
+
+	client := c.client
+	if client == nil {
+		return health.Down("Kafka 컨슈머가 실행 중이 아닙니다", details)
+	}
+	assignment, err := client.Assignment()
+	if err != nil {
+		return health.Down("파티션 할당 상태 조회 실패: "+err.Error(), details)
+	}
+	details["assignedPartitions"] = len(assignment)
+	if len(assignment) == 0 {
+		return health.Degraded("할당된 파티션이 없습니다", details)
+	}
+
+	return health.OK(details)
+}
+
+// CheckPipeline은 마지막 플러시 성공 후 지난 시간과 버퍼 포화도를 점검합니다.
+// 플러시가 Health.FlushStaleAfter 동안 성공하지 못했거나 버퍼가 가득 차면 down,
+// 버퍼가 기준의 80% 이상이면 degraded입니다.
+func (c *KafkaConsumer) CheckPipeline(_ context.Context) health.Result {
+	c.messageBuffer.mu.Lock()
+	buffered := len(c.messageBuffer.Traces) + len(c.messageBuffer.Logs)
+	c.messageBuffer.mu.Unlock()
+
+	maxItems := c.cfg.Health.MaxBufferItems
+	if maxItems <= 0 {
+		maxItems = config.GetConfig().Kafka.BatchSize * 10
+	}
+	saturation := 0.0
+	if maxItems > 0 {
+		saturation = float64(buffered) / float64(maxItems)
+	}
+
+	details := map[string]interface{}{
+		"bufferedItems":    buffered,
+		"maxBufferItems":   maxItems,
+		"bufferSaturation": saturation,
+		"pendingTraces":    c.pipeline.Stats().PendingTraces,
+	}
+
+	lastFlush := loadTime(&c.state.lastFlush)
+	if !lastFlush.IsZero() {
+		since := time.Since(lastFlush)
+		details["lastFlush"] = lastFlush
+		details["sinceLastFlushSeconds"] = since.Seconds()
+
+		if staleAfter := time.Duration(c.cfg.Health.FlushStaleAfter) * time.Second; staleAfter > 0 && since > staleAfter {
+			return health.Down("마지막 플러시 성공 후 너무 오랜 시간이 지났습니다", details)
+		}
+	}
+
+	switch {
+	case saturation >= 1:
+		return health.Down("메시지 버퍼가 가득 찼습니다", details)
+	case saturation >= bufferWarnRatio:
+		return health.Degraded("메시지 버퍼가 거의 찼습니다", details)
+	}
+	return health.OK(details)
+}
+
+// CheckPolling은 폴링 루프가 멈추지 않았는지 점검합니다.
+// 컨슈머가 실행 중인데 Health.PollStallAfter 동안 폴링하지 않았으면 down입니다. 실행 중이 아니면(재연결 중 포함) 정상으로 봅니다.
+func (c *KafkaConsumer) CheckPolling(_ context.Context) health.Result {
+	if !c.state.running.Load() {
+		return health.OK(map[string]interface{}{"running": false})
+	}
+
+	lastPoll := loadTime(&c.state.lastPoll)
+	if lastPoll.IsZero() {
+		return health.OK(map[string]interface{}{"running": true})
+	}
+
+	since := time.Since(lastPoll)
+	details := map[string]interface{}{
+		"running":              true,
+		"lastPoll":             lastPoll,
+		"sinceLastPollSeconds": since.Seconds(),
+	}
+	if stallAfter := time.Duration(c.cfg.Health.PollStallAfter) * time.Second; stallAfter > 0 && since > stallAfter {
+		return health.Down("Kafka 폴링 루프가 멈췄습니다", details)
+	}
+	return health.OK(details)
+}
@@ -0,0 +1,40 @@
+package consumer
+
+import (
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// setSecurityConfig는 설정한 보안 프로토콜에 맞는 librdkafka SASL, TLS 설정을 추가합니다.
+// plaintext이면 아무것도 추가하지 않으며, 값 검증은 설정 로드 시 이미 끝난 상태입니다.
+func setSecurityConfig(kafkaConfig *kafka.ConfigMap, cfg *config.Config) {
+	protocol := cfg.Kafka.SecurityProtocol
+	if protocol == "" || protocol == "plaintext" {
+		return
+	}
+	(*kafkaConfig)["security.protocol"] = protocol
+
+	// SASL 인증 (sasl_plaintext, sasl_ssl)
+	if strings.HasPrefix(protocol, "sasl_") {
+		(*kafkaConfig)["sasl.mechanisms"] = cfg.Kafka.SASLMechanism
+		(*kafkaConfig)["sasl.username"] = cfg.Kafka.SASLUsername
+		(*kafkaConfig)["sasl.password"] = cfg.Kafka.SASLPassword
+	}
+
+	// TLS (ssl, sasl_ssl), CA를 지정하지 않으면 librdkafka가 시스템 CA를 사용
+	if strings.HasSuffix(protocol, "ssl") {
+		if cfg.Kafka.SSLCALocation != "" {
+			(*kafkaConfig)["ssl.ca.location"] = cfg.Kafka.SSLCALocation
+		}
+		// 클라이언트 인증서가 있으면 상호 TLS
+		if cfg.Kafka.SSLCertLocation != "" {
+			(*kafkaConfig)["ssl.certificate.location"] = cfg.Kafka.SSLCertLocation
+			(*kafkaConfig)["ssl.key.location"] = cfg.Kafka.SSLKeyLocation
+			if cfg.Kafka.SSLKeyPassword != "" {
+				(*kafkaConfig)["ssl.key.password"] = cfg.Kafka.SSLKeyPassword
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package consumer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+)
+
+// TLS 구성(docker-compose.tls.yaml)의 Kafka에 SASL_SSL로 접속하는 통합 테스트입니다.
+// make test-tls-integration으로 실행하며, 접속 정보는 KAFKA_* 환경 변수로 받습니다.
+
+// integrationConfig는 환경 변수로 SASL_SSL 접속 설정을 만듭니다.
+func integrationConfig(t *testing.T) *config.Config {
+	t.Helper()
+	if os.Getenv("KAFKA_SSL_CA_LOCATION") == "" {
+		t.Skip("KAFKA_SSL_CA_LOCATION is not set")
+	}
+
+	cfg := &config.Config{}
+	cfg.Kafka.Brokers = []string{envOr("KAFKA_BROKERS", "localhost:9095")}
+	cfg.Kafka.SecurityProtocol = "sasl_ssl"
+	cfg.Kafka.SASLMechanism = envOr("KAFKA_SASL_MECHANISM", "SCRAM-SHA-512")
+	cfg.Kafka.SASLUsername = envOr("KAFKA_SASL_USERNAME", "telemetry")
+	cfg.Kafka.SASLPassword = os.Getenv("KAFKA_SASL_PASSWORD")
+	cfg.Kafka.SSLCALocation = os.Getenv("KAFKA_SSL_CA_LOCATION")
+	cfg.Kafka.SSLCertLocation = os.Getenv("KAFKA_SSL_CERT_LOCATION")
+	cfg.Kafka.SSLKeyLocation = os.Getenv("KAFKA_SSL_KEY_LOCATION")
+	return cfg
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// fetchMetadata는 설정으로 관리자 클라이언트를 만들어 브로커 메타데이터를 조회합니다.
+func fetchMetadata(cfg *config.Config) error {
+	kafkaConfig := &kafka.ConfigMap{
+		"bootstrap.servers": cfg.Kafka.Brokers[0],
+	}
+	setSecurityConfig(kafkaConfig, cfg)
+
+	admin, err := kafka.NewAdminClient(kafkaConfig)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	_, err = admin.GetMetadata(nil, true, 10000)
+	return err
+}
+
+// 호스트 이름을 검증하는 TLS, SCRAM 인증, 클라이언트 인증서로 접속해야 함
+func TestSASLSSLConnection(t *testing.T) {
+	cfg := integrationConfig(t)
+	if cfg.Kafka.SSLCertLocation == "" {
+		t.Fatal("KAFKA_SSL_CERT_LOCATION is required for the mutual TLS check")
+	}
+
+	if err := fetchMetadata(cfg); err != nil {
+		t.Fatalf("metadata request failed: %v", err)
+	}
+}
+
+// 틀린 비밀번호나 인증서와 맞지 않는 호스트 이름으로는 접속하지 못해야 함
+func TestSASLSSLConnectionRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+	}{
+		{
+			name: "wrong password",
+			modify: func(cfg *config.Config) {
+				cfg.Kafka.SASLPassword = "wrong-password"
+			},
+		},
+		{
+			// 서버 인증서의 SAN에 없는 주소
+			name: "hostname mismatch",
+			modify: func(cfg *config.Config) {
+				cfg.Kafka.Brokers = []string{"127.0.0.2:9095"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := integrationConfig(t)
+			tt.modify(cfg)
+
+			if err := fetchMetadata(cfg); err == nil {
+				t.Fatal("metadata request succeeded, want error")
+			}
+		})
+	}
+}
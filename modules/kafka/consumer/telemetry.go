@@ -0,0 +1,274 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 자체 텔레메트리 계측 이름
+const instrumentationName = "github.com/seongpil0948/otel-kafka-pg/modules/kafka/consumer"
+
+var (
+	tracer = otel.Tracer(instrumentationName)
+	meter  = otel.Meter(instrumentationName)
+
+	// 토픽별 처리한 메시지 수
+	messagesConsumed, _ = meter.Int64Counter("kafka.consumer.messages",
+		metric.WithDescription("토픽별 처리한 Kafka 메시지 수"))
+
+	// 압축 해제, OTLP 디코딩에 실패한 메시지 수
+	decodeFailures, _ = meter.Int64Counter("kafka.consumer.decode.failures",
+		metric.WithDescription("토픽별 압축 해제(stage=decompress), 디코딩(stage=decode)에 실패한 메시지 수"))
+
+	// 수집 정책을 통과해 버퍼에 추가된 항목 수
+	itemsBuffered, _ = meter.Int64Counter("kafka.consumer.items.buffered",
+		metric.WithDescription("버퍼에 추가된 항목 수 (signal=traces|logs)"))
+
+	// 버퍼 플러시(데이터베이스 저장) 시간
+	flushDuration, _ = meter.Float64Histogram("kafka.consumer.flush.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("버퍼 플러시 시간"))
+
+	// 플러시로 데이터베이스에 저장한 행 수
+	rowsWritten, _ = meter.Int64Counter("kafka.consumer.rows.written",
+		metric.WithDescription("데이터베이스에 저장한 행 수 (signal=traces|logs)"))
+)
+
+// 메트릭의 signal 속성 값
+const (
+	signalTraces = "traces"
+	signalLogs   = "logs"
+)
+
+// startPollSpan은 Poll로 받은 메시지의 수신 스팬을 Poll 호출 시각부터 시작합니다.
+func startPollSpan(ctx context.Context, msg *kafka.Message, pollStart time.Time) (context.Context, trace.Span) {
+	topic := ""
+	if msg.TopicPartition.Topic != nil {
+		topic = *msg.TopicPartition.Topic
+	}
+
+	return tracer.Start(ctx, "poll "+topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithTimestamp(pollStart),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeReceive,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(msg.TopicPartition.Partition))),
+			semconv.MessagingKafkaOffset(int(msg.TopicPartition.Offset)),
+			semconv.MessagingMessageBodySize(len(msg.Value)),
+		),
+	)
+}
+
+// recordMessage는 처리한 메시지 수를 토픽별로 기록합니다.
+func recordMessage(ctx context.Context, topic string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	messagesConsumed.Add(ctx, 1, metric.WithAttributes(
+		semconv.MessagingDestinationName(topic),
+		attribute.String("result", result),
+	))
+}
+
+// recordDecodeFailure는 압축 해제 또는 디코딩에 실패한 메시지를 기록합니다.
+func recordDecodeFailure(ctx context.Context, topic, stage string) {
+	decodeFailures.Add(ctx, 1, metric.WithAttributes(
+		semconv.MessagingDestinationName(topic),
+		attribute.String("stage", stage),
+	))
+}
+
+// recordBuffered는 버퍼에 추가된 항목 수를 기록합니다.
+func recordBuffered(signal string, count int) {
+	if count > 0 {
+		itemsBuffered.Add(context.Background(), int64(count), metric.WithAttributes(attribute.String("signal", signal)))
+	}
+}
+
+// recordWritten은 데이터베이스에 저장한 행 수를 기록합니다.
+func recordWritten(ctx context.Context, signal string, count int) {
+	rowsWritten.Add(ctx, int64(count), metric.WithAttributes(attribute.String("signal", signal)))
+}
+
+// partitionLag는 librdkafka 통계에서 읽은 파티션별 컨슈머 랙입니다.
+type partitionLag struct {
+	mu   sync.Mutex
+	lags map[string]map[string]int64 // 토픽 -> 파티션 -> 랙
+}
+
+// librdkafkaStats는 librdkafka 통계 JSON 중 컨슈머 랙 계산에 필요한 부분입니다.
+type librdkafkaStats struct {
+	Topics map[string]struct {
+		Partitions map[string]struct {
+			ConsumerLag int64 `json:"consumer_lag"`
+		} `json:"partitions"`
+	} `json:"topics"`
+}
+
+// update는 통계 이벤트로 파티션별 랙을 갱신합니다.
+// 내부용 파티션(-1)과 랙을 아직 알 수 없는 파티션(-1)은 제외합니다.
+func (p *partitionLag) update(statsJSON string) error {
+	var stats librdkafkaStats
+	if err := json.Unmarshal([]byte(statsJSON), &stats); err != nil {
+		return err
+	}
+
+	lags := make(map[string]map[string]int64, len(stats.Topics))
+	for topic, topicStats := range stats.Topics {
+		for partition, partitionStats := range topicStats.Partitions {
+			if partition == "-1" || partitionStats.ConsumerLag < 0 {
+				continue
+			}
+			if lags[topic] == nil {
+				lags[topic] = make(map[string]int64)
+			}
+			lags[topic][partition] = partitionStats.ConsumerLag
+		}
+	}
+
+	p.mu.Lock()
+	p.lags = lags
+	p.mu.Unlock()
+	return nil
+}
+
+// observe는 파티션별 랙을 게이지로 관측합니다.
+func (p *partitionLag) observe(observer metric.Observer, gauge metric.Int64ObservableGauge) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for topic, partitions := range p.lags {
+		for partition, lag := range partitions {
+			observer.ObserveInt64(gauge, lag, metric.WithAttributes(
+				semconv.MessagingDestinationName(topic),
+				semconv.MessagingDestinationPartitionID(partition),
+			))
+		}
+	}
+}
+
+// registerMetrics는 버퍼 크기, 컨슈머 랙, 수집 정책 통계, 테넌트 한도 초과 항목 수를 수집 시점에 관측하도록 등록합니다.
+func (c *KafkaConsumer) registerMetrics() error {
+	bufferItems, err := meter.Int64ObservableGauge("kafka.consumer.buffer.items",
+		metric.WithDescription("플러시를 기다리는 버퍼 항목 수 (signal=traces|logs)"))
+	if err != nil {
+		return err
+	}
+	consumerLag, err := meter.Int64ObservableGauge("kafka.consumer.lag",
+		metric.WithDescription("파티션별 컨슈머 랙 (librdkafka 통계)"))
+	if err != nil {
+		return err
+	}
+	pendingTraces, err := meter.Int64ObservableGauge("ingest.traces.pending",
+		metric.WithDescription("샘플링 결정을 기다리는 트레이스 수"))
+	if err != nil {
+		return err
+	}
+	spansReceived, err := meter.Int64ObservableCounter("ingest.spans.received",
+		metric.WithDescription("수집 정책에 들어온 스팬 수"))
+	if err != nil {
+		return err
+	}
+	spansDropped, err := meter.Int64ObservableCounter("ingest.spans.dropped",
+		metric.WithDescription("드롭 규칙별 버린 스팬 수"))
+	if err != nil {
+		return err
+	}
+	spansSampledOut, err := meter.Int64ObservableCounter("ingest.spans.sampled_out",
+		metric.WithDescription("샘플링으로 버린 스팬 수"))
+	if err != nil {
+		return err
+	}
+	tracesKept, err := meter.Int64ObservableCounter("ingest.traces.kept",
+		metric.WithDescription("보존 사유별 보존한 트레이스 수"))
+	if err != nil {
+		return err
+	}
+	tracesSampledOut, err := meter.Int64ObservableCounter("ingest.traces.sampled_out",
+		metric.WithDescription("샘플링으로 버린 트레이스 수"))
+	if err != nil {
+		return err
+	}
+	logsReceived, err := meter.Int64ObservableCounter("ingest.logs.received",
+		metric.WithDescription("수집 정책에 들어온 로그 수"))
+	if err != nil {
+		return err
+	}
+	logsDropped, err := meter.Int64ObservableCounter("ingest.logs.dropped",
+		metric.WithDescription("드롭 규칙별 버린 로그 수"))
+	if err != nil {
+		return err
+	}
+	redactions, err := meter.Int64ObservableCounter("ingest.redactions",
+		metric.WithDescription("비식별화 규칙/탐지기별 처리 횟수"))
+	if err != nil {
+		return err
+	}
+	transforms, err := meter.Int64ObservableCounter("ingest.transforms",
+		metric.WithDescription("변환별 속성을 바꾼 횟수"))
+	if err != nil {
+		return err
+	}
+	quotaDropped, err := meter.Int64ObservableCounter("ingest.tenant.quota_dropped",
+		metric.WithDescription("테넌트별 수집 한도 초과로 버린 항목 수"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		c.messageBuffer.mu.Lock()
+		tracesLen := len(c.messageBuffer.Traces)
+		logsLen := len(c.messageBuffer.Logs)
+		c.messageBuffer.mu.Unlock()
+		observer.ObserveInt64(bufferItems, int64(tracesLen), metric.WithAttributes(attribute.String("signal", signalTraces)))
+		observer.ObserveInt64(bufferItems, int64(logsLen), metric.WithAttributes(attribute.String("signal", signalLogs)))
+
+		c.lag.observe(observer, consumerLag)
+
+		stats := c.pipeline.Stats()
+		observer.ObserveInt64(pendingTraces, int64(stats.PendingTraces))
+		observer.ObserveInt64(spansReceived, stats.SpansReceived)
+		observer.ObserveInt64(spansSampledOut, stats.SpansSampledOut)
+		observer.ObserveInt64(tracesSampledOut, stats.TracesSampledOut)
+		observer.ObserveInt64(logsReceived, stats.LogsReceived)
+		observeCounts(observer, spansDropped, "rule", stats.SpansDropped)
+		observeCounts(observer, tracesKept, "reason", stats.TracesKept)
+		observeCounts(observer, logsDropped, "rule", stats.LogsDropped)
+		observeCounts(observer, redactions, "rule", stats.Redactions)
+		observeCounts(observer, transforms, "transform", stats.Transforms)
+		observeCounts(observer, quotaDropped, "tenant", c.tenants.droppedCounts())
+		return nil
+	}, bufferItems, consumerLag, pendingTraces, spansReceived, spansDropped, spansSampledOut,
+		tracesKept, tracesSampledOut, logsReceived, logsDropped, redactions, transforms, quotaDropped)
+	return err
+}
+
+// observeCounts는 키별 누적 값을 key 속성으로 구분해 관측합니다.
+func observeCounts(observer metric.Observer, counter metric.Int64ObservableCounter, key string, counts map[string]int64) {
+	for value, count := range counts {
+		observer.ObserveInt64(counter, count, metric.WithAttributes(attribute.String(key, value)))
+	}
+}
+
+// endSpan은 오류가 있으면 스팬에 기록한 뒤 스팬을 종료합니다.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
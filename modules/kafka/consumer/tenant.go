@@ -0,0 +1,159 @@
+package consumer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// tenantResolver는 수집 항목의 테넌트를 결정하고 테넌트별 분당 수집 한도를 적용합니다.
+// 테넌트 전용 토픽의 메시지는 토픽 매핑의 테넌트로 정하고, 생산자가 다른 테넌트로 쓰지 못하도록 헤더와 속성은 무시합니다.
+// 공유 토픽의 메시지만 메시지 헤더, 항목의 리소스 속성 순으로 결정하며 모두 없으면 기본 테넌트입니다.
+// 수집 한도는 프로세스마다 따로 세므로, 수집 인스턴스가 여러 개이면 테넌트의 실제 한도는 인스턴스 수만큼 늘어납니다.
+type tenantResolver struct {
+	headerName   string
+	attributeKey string
+	topics       map[string]string // 토픽 -> 테넌트
+	quotas       map[string]int
+	defaultQuota int
+
+	window  int64            // 현재 한도 구간 (Unix 분)
+	counts  map[string]int   // 현재 구간의 테넌트별 수집 항목 수
+	dropped map[string]int64 // 한도 초과로 버린 테넌트별 누적 항목 수
+	mu      sync.Mutex
+}
+
+// newTenantResolver는 설정의 테넌시 항목으로 tenantResolver를 생성합니다.
+func newTenantResolver(cfg *config.Config) *tenantResolver {
+	topics := make(map[string]string, len(cfg.Tenancy.TraceTopics)+len(cfg.Tenancy.LogTopics))
+	for topic, id := range cfg.Tenancy.TraceTopics {
+		topics[topic] = id
+	}
+	for topic, id := range cfg.Tenancy.LogTopics {
+		topics[topic] = id
+	}
+
+	return &tenantResolver{
+		headerName:   cfg.Tenancy.HeaderName,
+		attributeKey: cfg.Tenancy.AttributeKey,
+		topics:       topics,
+		quotas:       cfg.Tenancy.Quotas,
+		defaultQuota: cfg.Tenancy.DefaultQuota,
+		counts:       make(map[string]int),
+		dropped:      make(map[string]int64),
+	}
+}
+
+// messageTenant는 토픽 매핑 또는 공유 토픽의 메시지 헤더로 정해진 테넌트를 반환합니다. 둘 다 없으면 빈 문자열입니다.
+func (r *tenantResolver) messageTenant(msg *kafka.Message) string {
+	if msg.TopicPartition.Topic != nil {
+		if id := tenant.Normalize(r.topics[*msg.TopicPartition.Topic]); id != "" {
+			return id
+		}
+	}
+	if r.headerName != "" {
+		for _, header := range msg.Headers {
+			if header.Key == r.headerName {
+				if id := tenant.Normalize(string(header.Value)); id != "" {
+					return id
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// itemTenant는 메시지 테넌트가 없을 때(공유 토픽이고 헤더가 없을 때) 항목 속성으로 테넌트를 결정합니다.
+func (r *tenantResolver) itemTenant(messageTenant string, attributes map[string]interface{}) string {
+	if messageTenant != "" {
+		return messageTenant
+	}
+	if r.attributeKey != "" {
+		if value, ok := attributes[r.attributeKey]; ok {
+			return tenant.OrDefault(fmt.Sprint(value))
+		}
+	}
+	return tenant.Default
+}
+
+// traces는 스팬에 테넌트를 지정하고 수집 한도를 넘은 스팬을 제외합니다.
+func (r *tenantResolver) traces(messageTenant string, items []traceDomain.TraceItem) []traceDomain.TraceItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	kept := items[:0]
+	for i := range items {
+		items[i].TenantID = r.itemTenant(messageTenant, items[i].Attributes)
+		if r.allow(items[i].TenantID, now) {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept
+}
+
+// logs는 로그에 테넌트를 지정하고 수집 한도를 넘은 로그를 제외합니다.
+func (r *tenantResolver) logs(messageTenant string, items []logDomain.LogItem) []logDomain.LogItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	kept := items[:0]
+	for i := range items {
+		items[i].TenantID = r.itemTenant(messageTenant, items[i].Attributes)
+		if r.allow(items[i].TenantID, now) {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept
+}
+
+// allow는 테넌트의 현재 분 구간 수집 항목 수가 한도 이내인지 확인하고 항목 수를 늘립니다.
+func (r *tenantResolver) allow(tenantID string, now time.Time) bool {
+	quota, ok := r.quotas[tenantID]
+	if !ok {
+		quota = r.defaultQuota
+	}
+	if quota <= 0 {
+		return true
+	}
+
+	if window := now.Unix() / 60; window != r.window {
+		r.window = window
+		r.counts = make(map[string]int)
+	}
+
+	if r.counts[tenantID] >= quota {
+		r.dropped[tenantID]++
+		return false
+	}
+	r.counts[tenantID]++
+	return true
+}
+
+// droppedCounts는 한도 초과로 버린 테넌트별 누적 항목 수를 반환합니다.
+func (r *tenantResolver) droppedCounts() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dropped := make(map[string]int64, len(r.dropped))
+	for id, count := range r.dropped {
+		dropped[id] = count
+	}
+	return dropped
+}
+
+// topicNames는 테넌트 전용 토픽 이름 목록을 반환합니다.
+func topicNames(topics map[string]string) []string {
+	names := make([]string, 0, len(topics))
+	for topic := range topics {
+		names = append(names, topic)
+	}
+	return names
+}
@@ -0,0 +1,52 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// 전용 토픽은 헤더와 속성으로 다른 테넌트를 지정할 수 없고, 공유 토픽만 헤더, 속성 순으로 테넌트를 정해야 함
+func TestTenantResolution(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Tenancy.HeaderName = "x-tenant-id"
+	cfg.Tenancy.AttributeKey = "tenant.id"
+	cfg.Tenancy.TraceTopics = map[string]string{"otlp.traces.acme": "acme"}
+	r := newTenantResolver(cfg)
+
+	tests := []struct {
+		name      string
+		topic     string
+		header    string
+		attribute string
+		want      string
+	}{
+		{name: "dedicated topic", topic: "otlp.traces.acme", want: "acme"},
+		{name: "dedicated topic ignores header", topic: "otlp.traces.acme", header: "globex", want: "acme"},
+		{name: "dedicated topic ignores attribute", topic: "otlp.traces.acme", attribute: "globex", want: "acme"},
+		{name: "shared topic header", topic: "otlp.traces", header: "globex", attribute: "initech", want: "globex"},
+		{name: "shared topic attribute", topic: "otlp.traces", attribute: "initech", want: "initech"},
+		{name: "shared topic default", topic: "otlp.traces", want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic := tt.topic
+			msg := &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic}}
+			if tt.header != "" {
+				msg.Headers = []kafka.Header{{Key: "x-tenant-id", Value: []byte(tt.header)}}
+			}
+			item := traceDomain.TraceItem{Attributes: map[string]interface{}{}}
+			if tt.attribute != "" {
+				item.Attributes["tenant.id"] = tt.attribute
+			}
+
+			items := r.traces(r.messageTenant(msg), []traceDomain.TraceItem{item})
+			if got := items[0].TenantID; got != tt.want {
+				t.Errorf("tenant = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
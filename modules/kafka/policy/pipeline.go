@@ -0,0 +1,235 @@
+package policy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// Pipeline은 프로세서와 컨슈머 버퍼 사이에서 수집 정책을 적용하는 단계입니다.
+// 속성 변환, 드롭 규칙, 비식별화, 테일 샘플링 순서로 적용합니다.
+type Pipeline interface {
+	// Traces는 속성 변환, 드롭 규칙, 비식별화를 적용한 스팬을 샘플링 대기열에 추가하고, 지금 버퍼에 넣을 스팬을 반환합니다.
+	// 샘플링을 사용하지 않으면 샘플링 대기 없이 바로 반환합니다.
+	Traces(items []traceDomain.TraceItem) []traceDomain.TraceItem
+	// Logs는 속성 변환, 드롭 규칙, 비식별화를 적용한 로그를 반환합니다.
+	Logs(items []logDomain.LogItem) []logDomain.LogItem
+	// Ready는 대기 시간이 지난 트레이스의 샘플링을 결정하고 보존할 스팬을 반환합니다.
+	// force면 대기 중인 모든 트레이스를 즉시 결정합니다 (종료 시 사용).
+	Ready(force bool) []traceDomain.TraceItem
+	// Stats는 누적 처리 통계를 반환합니다.
+	Stats() Stats
+}
+
+// Stats는 수집 정책 처리 통계입니다.
+type Stats struct {
+	SpansReceived    int64            `json:"spansReceived"`
+	SpansDropped     map[string]int64 `json:"spansDropped"` // 드롭 규칙별 버린 스팬 수
+	SpansSampledOut  int64            `json:"spansSampledOut"`
+	TracesKept       map[string]int64 `json:"tracesKept"` // 보존 사유(error, latency, probabilistic)별 트레이스 수
+	TracesSampledOut int64            `json:"tracesSampledOut"`
+	PendingTraces    int              `json:"pendingTraces"`
+	LogsReceived     int64            `json:"logsReceived"`
+	LogsDropped      map[string]int64 `json:"logsDropped"` // 드롭 규칙별 버린 로그 수
+	Redactions       map[string]int64 `json:"redactions"`  // 비식별화 규칙/탐지기별 처리 횟수
+	Transforms       map[string]int64 `json:"transforms"`  // 변환별 속성을 바꾼 횟수
+}
+
+// pipelineImpl은 Pipeline 인터페이스의 구현체입니다.
+type pipelineImpl struct {
+	rules    *Rules
+	redactor *redactor    // 비식별화 규칙이 없으면 nil
+	sampler  *tailSampler // 샘플링을 사용하지 않으면 nil
+	stats    Stats
+	mu       sync.Mutex
+	log      logger.Logger
+}
+
+// NewPipeline은 설정의 정책 파일과 샘플링 설정으로 Pipeline을 생성합니다.
+func NewPipeline(cfg *config.Config) (Pipeline, error) {
+	rules, err := LoadRules(cfg.Ingest.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+	// 솔트가 없으면 해시를 사전 대입으로 쉽게 되돌릴 수 있음
+	if rules.Redaction.hasHashRule() && cfg.Ingest.HashSalt == "" {
+		return nil, fmt.Errorf("policy file %s: ingest hash salt (INGEST_HASH_SALT) is required for hash redaction rules", cfg.Ingest.PolicyFile)
+	}
+
+	p := &pipelineImpl{
+		rules: rules,
+		stats: Stats{
+			SpansDropped: make(map[string]int64),
+			TracesKept:   make(map[string]int64),
+			LogsDropped:  make(map[string]int64),
+			Redactions:   make(map[string]int64),
+			Transforms:   make(map[string]int64),
+		},
+		log: logger.GetLogger(),
+	}
+	p.redactor = newRedactor(&rules.Redaction, cfg.Ingest.HashSalt, &p.stats)
+
+	if cfg.Ingest.SamplingEnabled {
+		p.sampler = newTailSampler(
+			cfg.Ingest.SamplingRate,
+			time.Duration(cfg.Ingest.DecisionWait)*time.Millisecond,
+			cfg.Ingest.LatencyThreshold,
+			cfg.Ingest.MaxPendingTraces,
+			&p.stats,
+		)
+	}
+
+	p.log.Info().
+		Str("policy_file", cfg.Ingest.PolicyFile).
+		Int("transforms", len(rules.Transforms)).
+		Int("trace_drop_rules", len(rules.Traces.Drop)).
+		Int("log_drop_rules", len(rules.Logs.Drop)).
+		Int("redaction_rules", len(rules.Redaction.Rules)).
+		Strs("redaction_detectors", rules.Redaction.Detectors).
+		Bool("sampling_enabled", cfg.Ingest.SamplingEnabled).
+		Float64("sampling_rate", cfg.Ingest.SamplingRate).
+		Int("decision_wait_ms", cfg.Ingest.DecisionWait).
+		Float64("latency_threshold_ms", cfg.Ingest.LatencyThreshold).
+		Msg("수집 정책 로드 완료")
+
+	return p, nil
+}
+
+// Traces는 속성 변환, 드롭 규칙, 비식별화를 적용한 스팬을 샘플링 대기열에 추가하고, 지금 버퍼에 넣을 스팬을 반환합니다.
+func (p *pipelineImpl) Traces(items []traceDomain.TraceItem) []traceDomain.TraceItem {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.SpansReceived += int64(len(items))
+
+	if len(p.rules.Transforms) > 0 {
+		for i := range items {
+			p.countTransforms(p.rules.Transforms.ApplySpan(&items[i]))
+		}
+	}
+
+	filtered := items
+	if len(p.rules.Traces.Drop) > 0 {
+		filtered = make([]traceDomain.TraceItem, 0, len(items))
+		for i := range items {
+			if rule := p.matchSpan(&items[i]); rule != "" {
+				p.stats.SpansDropped[rule]++
+				continue
+			}
+			filtered = append(filtered, items[i])
+		}
+	}
+
+	if p.redactor != nil {
+		for i := range filtered {
+			p.redactor.span(&filtered[i])
+		}
+	}
+
+	if p.sampler == nil {
+		return filtered
+	}
+	return p.sampler.add(filtered, time.Now())
+}
+
+// Logs는 속성 변환, 드롭 규칙, 비식별화를 적용한 로그를 반환합니다.
+func (p *pipelineImpl) Logs(items []logDomain.LogItem) []logDomain.LogItem {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.LogsReceived += int64(len(items))
+
+	if len(p.rules.Transforms) > 0 {
+		for i := range items {
+			p.countTransforms(p.rules.Transforms.ApplyLog(&items[i]))
+		}
+	}
+
+	filtered := items
+	if len(p.rules.Logs.Drop) > 0 {
+		filtered = make([]logDomain.LogItem, 0, len(items))
+		for i := range items {
+			if rule := p.matchLog(&items[i]); rule != "" {
+				p.stats.LogsDropped[rule]++
+				continue
+			}
+			filtered = append(filtered, items[i])
+		}
+	}
+
+	if p.redactor != nil {
+		for i := range filtered {
+			p.redactor.log(&filtered[i])
+		}
+	}
+	return filtered
+}
+
+// Ready는 대기 시간이 지난 트레이스의 샘플링을 결정하고 보존할 스팬을 반환합니다.
+func (p *pipelineImpl) Ready(force bool) []traceDomain.TraceItem {
+	if p.sampler == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sampler.ready(time.Now(), force)
+}
+
+// Stats는 누적 처리 통계의 복사본을 반환합니다.
+func (p *pipelineImpl) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.SpansDropped = copyCounts(p.stats.SpansDropped)
+	stats.TracesKept = copyCounts(p.stats.TracesKept)
+	stats.LogsDropped = copyCounts(p.stats.LogsDropped)
+	stats.Redactions = copyCounts(p.stats.Redactions)
+	stats.Transforms = copyCounts(p.stats.Transforms)
+	if p.sampler != nil {
+		stats.PendingTraces = p.sampler.pendingCount()
+	}
+	return stats
+}
+
+// matchSpan은 스팬에 일치하는 첫 드롭 규칙의 이름을 반환합니다.
+func (p *pipelineImpl) matchSpan(item *traceDomain.TraceItem) string {
+	for i := range p.rules.Traces.Drop {
+		if p.rules.Traces.Drop[i].MatchSpan(item) {
+			return p.rules.Traces.Drop[i].Name
+		}
+	}
+	return ""
+}
+
+// matchLog는 로그에 일치하는 첫 드롭 규칙의 이름을 반환합니다.
+func (p *pipelineImpl) matchLog(item *logDomain.LogItem) string {
+	for i := range p.rules.Logs.Drop {
+		if p.rules.Logs.Drop[i].MatchLog(item) {
+			return p.rules.Logs.Drop[i].Name
+		}
+	}
+	return ""
+}
+
+// countTransforms는 적용된 변환 횟수를 기록합니다.
+func (p *pipelineImpl) countTransforms(applied []string) {
+	for _, name := range applied {
+		p.stats.Transforms[name]++
+	}
+}
+
+// copyCounts는 카운터 맵을 복사합니다.
+func copyCounts(counts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(counts))
+	for key, value := range counts {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+func TestPipelineDropsSpans(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Ingest.PolicyFile = "testdata/drop.yaml"
+	p, err := NewPipeline(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	span := func(id, service, name string, attributes map[string]interface{}) traceDomain.TraceItem {
+		return traceDomain.TraceItem{TraceID: "t", SpanID: id, ServiceName: service, Name: name, Attributes: attributes}
+	}
+	kept := p.Traces([]traceDomain.TraceItem{
+		span("health", "api", "GET /healthz", nil),
+		span("health-prefix", "api", "GET /healthz/deep", nil),
+		span("internal", "checkout", "charge", map[string]interface{}{"internal": true}),
+		span("internal-other-service", "web", "charge", map[string]interface{}{"internal": true}),
+		span("public", "checkout", "charge", nil),
+	})
+
+	if got := itemSpanIDs(kept); !slices.Equal(got, []string{"health-prefix", "internal-other-service", "public"}) {
+		t.Errorf("kept spans = %v", got)
+	}
+	stats := p.Stats()
+	if stats.SpansReceived != 5 || stats.SpansDropped["health-check"] != 1 || stats.SpansDropped["internal"] != 1 {
+		t.Errorf("stats = received %d dropped %v", stats.SpansReceived, stats.SpansDropped)
+	}
+	// 샘플링을 사용하지 않으면 대기하는 트레이스가 없음
+	if ready := p.Ready(true); len(ready) != 0 {
+		t.Errorf("Ready(true) = %d spans without sampling, want 0", len(ready))
+	}
+}
+
+func TestPipelineDropsLogs(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Ingest.PolicyFile = "testdata/drop.yaml"
+	p, err := NewPipeline(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept := p.Logs([]logDomain.LogItem{
+		{ID: "debug", ServiceName: "checkout", Severity: "debug", Message: "cart loaded"},
+		{ID: "info", ServiceName: "checkout", Severity: "INFO", Message: "cart loaded"},
+		{ID: "heartbeat", ServiceName: "web", Severity: "INFO", Message: "heartbeat ok"},
+	})
+
+	if len(kept) != 1 || kept[0].ID != "info" {
+		t.Errorf("kept logs = %+v, want only info", kept)
+	}
+	stats := p.Stats()
+	if stats.LogsDropped["debug-noise"] != 1 || stats.LogsDropped["heartbeat"] != 1 {
+		t.Errorf("LogsDropped = %v", stats.LogsDropped)
+	}
+}
+
+// 종료 시 Ready(true)는 대기 시간과 관계없이 대기 중인 모든 트레이스를 결정해야 함
+func TestPipelineReadyForceDrains(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Ingest.SamplingEnabled = true
+	cfg.Ingest.SamplingRate = 1
+	cfg.Ingest.DecisionWait = 60000
+	cfg.Ingest.MaxPendingTraces = 100
+	p, err := NewPipeline(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kept := p.Traces([]traceDomain.TraceItem{testItem("t1", "a"), testItem("t2", "b"), testItem("t1", "c")}); len(kept) != 0 {
+		t.Fatalf("Traces() returned %d spans before the decision wait", len(kept))
+	}
+	if ready := p.Ready(false); len(ready) != 0 {
+		t.Fatalf("Ready(false) = %d spans before the decision wait", len(ready))
+	}
+	if pending := p.Stats().PendingTraces; pending != 2 {
+		t.Fatalf("PendingTraces = %d, want 2", pending)
+	}
+
+	if got := itemSpanIDs(p.Ready(true)); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Ready(true) = %v, want all spans", got)
+	}
+	if pending := p.Stats().PendingTraces; pending != 0 {
+		t.Errorf("PendingTraces = %d after Ready(true), want 0", pending)
+	}
+}
+
+// hash 규칙이 있으면 솔트 없이 파이프라인을 만들 수 없어야 함
+func TestNewPipelineRequiresHashSalt(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		salt    string
+		wantErr bool
+	}{
+		{name: "hash rule without salt", file: "testdata/redact.yaml", wantErr: true},
+		{name: "hash rule with salt", file: "testdata/redact.yaml", salt: "salt"},
+		{name: "no hash rule without salt", file: "testdata/drop.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Ingest.PolicyFile = tt.file
+			cfg.Ingest.HashSalt = tt.salt
+
+			_, err := NewPipeline(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPipeline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
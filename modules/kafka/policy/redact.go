@@ -0,0 +1,289 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// 비식별화 방식
+const (
+	RedactDrop = "drop" // 속성 삭제
+	RedactHash = "hash" // 값을 솔트를 더한 SHA-256 해시로 교체
+	RedactMask = "mask" // 정규식에 일치하는 부분을 치환
+)
+
+// 기본 치환 문자열
+const defaultReplacement = "[REDACTED]"
+
+// 내장 탐지기: 속성 값과 로그 본문에서 개인정보를 찾아 [REDACTED:<이름>]으로 치환합니다.
+var detectors = map[string]*regexp.Regexp{
+	"email":       regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	"credit_card": regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+	"jwt":         regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+}
+
+// Redaction은 수집 정책 파일의 비식별화 설정입니다.
+//
+//	redaction:
+//	  detectors: [email, credit_card, jwt]
+//	  rules:
+//	    - name: user-id
+//	      keys: [enduser.id]
+//	      action: hash
+//	    - name: url-token
+//	      keys: [http.url]
+//	      action: mask
+//	      pattern: "token=[^&]*"
+//	      replacement: "token=[REDACTED]"
+type Redaction struct {
+	Detectors []string     `yaml:"detectors"`
+	Rules     []RedactRule `yaml:"rules"`
+}
+
+// RedactRule은 속성 또는 로그 본문에 적용할 비식별화 규칙입니다.
+// keys, keyPattern을 지정하면 일치하는 속성에만 적용하고, 지정하지 않은 mask 규칙은 모든 문자열 속성 값과 로그 본문에 적용합니다.
+type RedactRule struct {
+	Name        string   `yaml:"name"`
+	Keys        []string `yaml:"keys"`        // 속성 키 (정확히 일치)
+	KeyPattern  string   `yaml:"keyPattern"`  // 속성 키 정규식
+	Action      string   `yaml:"action"`      // drop, hash, mask
+	Pattern     string   `yaml:"pattern"`     // mask 대상 정규식
+	Replacement string   `yaml:"replacement"` // mask 치환 문자열 (기본값 [REDACTED])
+
+	keys       map[string]struct{}
+	keyPattern *regexp.Regexp
+	pattern    *regexp.Regexp
+}
+
+// compile은 비식별화 설정을 검증하고 정규식을 컴파일합니다.
+func (r *Redaction) compile() error {
+	for _, name := range r.Detectors {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("redaction.detectors: unknown detector %q", name)
+		}
+	}
+
+	for i := range r.Rules {
+		if err := r.Rules[i].compile(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compile은 규칙 하나를 검증합니다.
+func (r *RedactRule) compile(index int) error {
+	if r.Name == "" {
+		r.Name = fmt.Sprintf("redaction-%d", index)
+	}
+	if r.Replacement == "" {
+		r.Replacement = defaultReplacement
+	}
+
+	r.keys = make(map[string]struct{}, len(r.Keys))
+	for _, key := range r.Keys {
+		r.keys[key] = struct{}{}
+	}
+
+	var err error
+	if r.KeyPattern != "" {
+		if r.keyPattern, err = regexp.Compile(r.KeyPattern); err != nil {
+			return fmt.Errorf("redaction.rules[%d]: invalid keyPattern: %w", index, err)
+		}
+	}
+
+	switch r.Action {
+	case RedactDrop, RedactHash:
+		if !r.hasKeys() {
+			return fmt.Errorf("redaction.rules[%d]: keys or keyPattern is required for %s", index, r.Action)
+		}
+	case RedactMask:
+		if r.Pattern == "" {
+			return fmt.Errorf("redaction.rules[%d]: pattern is required for mask", index)
+		}
+		if r.pattern, err = regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("redaction.rules[%d]: invalid pattern: %w", index, err)
+		}
+	default:
+		return fmt.Errorf("redaction.rules[%d]: unsupported action %q", index, r.Action)
+	}
+
+	return nil
+}
+
+// hasHashRule은 hash 규칙이 있는지 확인합니다.
+func (r *Redaction) hasHashRule() bool {
+	for i := range r.Rules {
+		if r.Rules[i].Action == RedactHash {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKeys는 규칙이 특정 속성에만 적용되는지 확인합니다.
+func (r *RedactRule) hasKeys() bool {
+	return len(r.keys) > 0 || r.keyPattern != nil
+}
+
+// matchKey는 속성 키가 규칙 대상인지 확인합니다.
+func (r *RedactRule) matchKey(key string) bool {
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	return r.keyPattern != nil && r.keyPattern.MatchString(key)
+}
+
+// redactor는 스팬과 로그에 비식별화 규칙과 내장 탐지기를 적용합니다. 동시성 제어는 호출하는 pipeline이 담당합니다.
+type redactor struct {
+	keyed     []*RedactRule // 특정 속성 대상 규칙
+	global    []*RedactRule // 모든 문자열 값 대상 mask 규칙
+	detectors []string
+	salt      string
+	stats     *Stats
+}
+
+// newRedactor는 비식별화 설정으로 redactor를 생성합니다. 적용할 규칙이 없으면 nil을 반환합니다.
+func newRedactor(redaction *Redaction, salt string, stats *Stats) *redactor {
+	if len(redaction.Rules) == 0 && len(redaction.Detectors) == 0 {
+		return nil
+	}
+
+	r := &redactor{detectors: redaction.Detectors, salt: salt, stats: stats}
+	for i := range redaction.Rules {
+		rule := &redaction.Rules[i]
+		if rule.hasKeys() {
+			r.keyed = append(r.keyed, rule)
+		} else {
+			r.global = append(r.global, rule)
+		}
+	}
+	return r
+}
+
+// span은 스팬 속성을 비식별화합니다.
+func (r *redactor) span(item *traceDomain.TraceItem) {
+	r.attributes(item.Attributes)
+}
+
+// log는 로그 속성과 본문을 비식별화합니다.
+func (r *redactor) log(item *logDomain.LogItem) {
+	r.attributes(item.Attributes)
+	item.Message = r.text(item.Message)
+}
+
+// attributes는 속성 맵에 키 대상 규칙을 적용한 뒤, 남은 문자열 값에 전역 규칙과 탐지기를 적용합니다.
+func (r *redactor) attributes(attributes map[string]interface{}) {
+	for key, value := range attributes {
+		dropped := false
+		for _, rule := range r.keyed {
+			if !rule.matchKey(key) {
+				continue
+			}
+
+			switch rule.Action {
+			case RedactDrop:
+				delete(attributes, key)
+				r.stats.Redactions[rule.Name]++
+				dropped = true
+			case RedactHash:
+				value = r.hash(fmt.Sprint(value))
+				r.stats.Redactions[rule.Name]++
+			case RedactMask:
+				value = r.walk(value, func(s string) string { return r.mask(rule, s) })
+			}
+
+			if dropped {
+				break
+			}
+		}
+		if dropped {
+			continue
+		}
+
+		attributes[key] = r.walk(value, r.text)
+	}
+}
+
+// walk는 값 안의 모든 문자열(중첩 배열, 맵 포함)에 fn을 적용합니다.
+func (r *redactor) walk(value interface{}, fn func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.walk(v[i], fn)
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = r.walk(v[key], fn)
+		}
+	}
+	return value
+}
+
+// text는 문자열에 전역 mask 규칙과 내장 탐지기를 적용합니다.
+func (r *redactor) text(s string) string {
+	for _, rule := range r.global {
+		s = r.mask(rule, s)
+	}
+	for _, name := range r.detectors {
+		s = r.detect(name, s)
+	}
+	return s
+}
+
+// mask는 규칙의 정규식에 일치하는 부분을 치환하고 치환 횟수를 기록합니다.
+func (r *redactor) mask(rule *RedactRule, s string) string {
+	return rule.pattern.ReplaceAllStringFunc(s, func(string) string {
+		r.stats.Redactions[rule.Name]++
+		return rule.Replacement
+	})
+}
+
+// detect는 내장 탐지기로 찾은 값을 치환하고 치환 횟수를 기록합니다.
+// 카드 번호는 Luhn 검사를 통과한 경우에만 치환합니다.
+func (r *redactor) detect(name, s string) string {
+	return detectors[name].ReplaceAllStringFunc(s, func(match string) string {
+		if name == "credit_card" && !luhnValid(match) {
+			return match
+		}
+		r.stats.Redactions["detector:"+name]++
+		return "[REDACTED:" + name + "]"
+	})
+}
+
+// hash는 값을 솔트를 더한 SHA-256 해시로 교체합니다. 같은 값은 같은 해시가 되므로 검색과 집계에 계속 사용할 수 있습니다.
+func (r *redactor) hash(value string) string {
+	sum := sha256.Sum256([]byte(r.salt + value))
+	return "sha256:" + hex.EncodeToString(sum[:16])
+}
+
+// luhnValid는 숫자(공백, 하이픈 무시)가 Luhn 검사를 통과하는지 확인합니다.
+func luhnValid(s string) bool {
+	sum := 0
+	digits := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+		double = !double
+	}
+	return digits >= 13 && sum%10 == 0
+}
@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Rules는 수집 정책 파일(YAML)의 내용입니다.
+//
+//	traces:
+//	  drop:
+//	    - name: health-check
+//	      spanName: "^GET /(healthz|readyz)$"
+//	logs:
+//	  drop:
+//	    - name: debug-noise
+//	      service: checkout
+//	      severities: [DEBUG, TRACE]
+//
+// 속성 변환은 Transform, 비식별화 설정은 Redaction을 참고하세요.
+type Rules struct {
+	Transforms Transforms  `yaml:"transforms"`
+	Traces     SignalRules `yaml:"traces"`
+	Logs       SignalRules `yaml:"logs"`
+	Redaction  Redaction   `yaml:"redaction"`
+}
+
+// SignalRules는 신호(트레이스/로그)별 규칙입니다.
+type SignalRules struct {
+	Drop []DropRule `yaml:"drop"`
+}
+
+// DropRule은 수집 단계에서 버릴 스팬이나 로그의 조건입니다. 지정한 조건을 모두 만족하면 버립니다.
+type DropRule struct {
+	Name       string            `yaml:"name"`       // 통계에 표시할 규칙 이름
+	Service    string            `yaml:"service"`    // 서비스 이름 (정확히 일치)
+	SpanName   string            `yaml:"spanName"`   // 스팬 이름 정규식 (트레이스)
+	Message    string            `yaml:"message"`    // 로그 메시지 정규식 (로그)
+	Severities []string          `yaml:"severities"` // 로그 심각도 (로그)
+	Attributes map[string]string `yaml:"attributes"` // 속성 값, "*"이면 속성이 있기만 하면 일치
+
+	spanName *regexp.Regexp
+	message  *regexp.Regexp
+}
+
+// LoadRules는 수집 정책 파일을 읽고 검증합니다. path가 비어 있으면 빈 규칙을 반환합니다.
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return &Rules{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("policy file %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+// ParseRules는 YAML 형식의 수집 정책을 파싱하고 검증합니다. 알 수 없는 필드는 오류로 처리합니다.
+func ParseRules(data []byte) (*Rules, error) {
+	rules := &Rules{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	if err := rules.compile(); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	return rules, nil
+}
+
+// compile은 규칙을 검증하고 정규식을 컴파일합니다.
+func (r *Rules) compile() error {
+	if err := r.Transforms.compile(); err != nil {
+		return err
+	}
+
+	for i := range r.Traces.Drop {
+		rule := &r.Traces.Drop[i]
+		if rule.Message != "" || len(rule.Severities) > 0 {
+			return fmt.Errorf("traces.drop[%d]: message and severities apply to logs only", i)
+		}
+		if err := rule.compile("traces", i); err != nil {
+			return err
+		}
+	}
+
+	for i := range r.Logs.Drop {
+		rule := &r.Logs.Drop[i]
+		if rule.SpanName != "" {
+			return fmt.Errorf("logs.drop[%d]: spanName applies to traces only", i)
+		}
+		if err := rule.compile("logs", i); err != nil {
+			return err
+		}
+	}
+
+	return r.Redaction.compile()
+}
+
+// compile은 규칙 하나를 검증합니다. 조건이 없는 규칙은 모든 데이터를 버리므로 허용하지 않습니다.
+func (d *DropRule) compile(signal string, index int) error {
+	if d.Service == "" && d.SpanName == "" && d.Message == "" && len(d.Severities) == 0 && len(d.Attributes) == 0 {
+		return fmt.Errorf("%s.drop[%d]: at least one condition is required", signal, index)
+	}
+	if d.Name == "" {
+		d.Name = fmt.Sprintf("%s-drop-%d", signal, index)
+	}
+
+	var err error
+	if d.SpanName != "" {
+		if d.spanName, err = regexp.Compile(d.SpanName); err != nil {
+			return fmt.Errorf("%s.drop[%d]: invalid spanName: %w", signal, index, err)
+		}
+	}
+	if d.Message != "" {
+		if d.message, err = regexp.Compile(d.Message); err != nil {
+			return fmt.Errorf("%s.drop[%d]: invalid message: %w", signal, index, err)
+		}
+	}
+
+	return nil
+}
+
+// MatchSpan은 스팬이 규칙의 모든 조건을 만족하는지 확인합니다.
+func (d *DropRule) MatchSpan(item *traceDomain.TraceItem) bool {
+	if d.Service != "" && item.ServiceName != d.Service {
+		return false
+	}
+	if d.spanName != nil && !d.spanName.MatchString(item.Name) {
+		return false
+	}
+	return matchAttributes(d.Attributes, item.Attributes)
+}
+
+// MatchLog는 로그가 규칙의 모든 조건을 만족하는지 확인합니다.
+func (d *DropRule) MatchLog(item *logDomain.LogItem) bool {
+	if d.Service != "" && item.ServiceName != d.Service {
+		return false
+	}
+	if len(d.Severities) > 0 && !containsFold(d.Severities, item.Severity) {
+		return false
+	}
+	if d.message != nil && !d.message.MatchString(item.Message) {
+		return false
+	}
+	return matchAttributes(d.Attributes, item.Attributes)
+}
+
+// matchAttributes는 조건의 모든 속성이 일치하는지 확인합니다. 값은 문자열로 비교합니다.
+func matchAttributes(conditions map[string]string, attributes map[string]interface{}) bool {
+	for key, want := range conditions {
+		value, ok := attributes[key]
+		if !ok {
+			return false
+		}
+		if want != "*" && fmt.Sprint(value) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold는 대소문자 구분 없이 values에 value가 있는지 확인합니다.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
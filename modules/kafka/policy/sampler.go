@@ -0,0 +1,192 @@
+package policy
+
+import (
+	"hash/fnv"
+	"time"
+
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// 샘플링 결정 후에도 같은 트레이스의 늦은 스팬에 결정을 적용하는 시간
+const decisionTTL = time.Minute
+
+// 트레이스 보존 사유
+const (
+	KeepError         = "error"
+	KeepLatency       = "latency"
+	KeepProbabilistic = "probabilistic"
+)
+
+// pendingTrace는 샘플링 결정을 기다리는 트레이스입니다.
+type pendingTrace struct {
+	traceID     string
+	spans       []traceDomain.TraceItem
+	firstSeen   time.Time
+	hasError    bool
+	maxDuration float64
+}
+
+// decision은 샘플링이 결정된 트레이스의 결과입니다.
+type decision struct {
+	keep      bool
+	decidedAt time.Time
+}
+
+// tailSampler는 트레이스별로 스팬을 모은 뒤 트레이스 전체를 보고 보존 여부를 결정합니다.
+// 오류 스팬이나 임계값 이상 지연된 스팬이 있는 트레이스는 항상 보존하고, 나머지는 trace ID 해시로 일정 비율만 보존합니다.
+// 해시 기반이므로 여러 인스턴스가 같은 트레이스의 스팬을 나눠 받아도 같은 결정을 내립니다.
+// 동시성 제어는 호출하는 pipeline이 담당합니다.
+type tailSampler struct {
+	rate             float64
+	wait             time.Duration
+	latencyThreshold float64
+	maxPending       int
+
+	pending map[string]*pendingTrace // 키: traceKey
+	order   []string                 // 대기 트레이스의 도착 순서
+
+	decided      map[string]decision // 키: traceKey
+	decidedOrder []string            // 결정 순서 (만료 정리용)
+
+	stats *Stats
+}
+
+// newTailSampler는 새 테일 샘플러를 생성합니다.
+func newTailSampler(rate float64, wait time.Duration, latencyThreshold float64, maxPending int, stats *Stats) *tailSampler {
+	if rate < 0 {
+		rate = 0
+	}
+	if rate > 1 {
+		rate = 1
+	}
+	if maxPending <= 0 {
+		maxPending = 1
+	}
+
+	return &tailSampler{
+		rate:             rate,
+		wait:             wait,
+		latencyThreshold: latencyThreshold,
+		maxPending:       maxPending,
+		pending:          make(map[string]*pendingTrace),
+		decided:          make(map[string]decision),
+		stats:            stats,
+	}
+}
+
+// add는 스팬을 트레이스별 대기열에 추가합니다.
+// 이미 결정된 트레이스의 스팬은 결정에 따라 바로 반환하거나 버리며, 대기 트레이스 수가 최대치를 넘으면 가장 오래된 트레이스를 먼저 결정합니다.
+func (s *tailSampler) add(items []traceDomain.TraceItem, now time.Time) []traceDomain.TraceItem {
+	kept := []traceDomain.TraceItem{}
+
+	for _, item := range items {
+		key := traceKey(item)
+		if d, ok := s.decided[key]; ok {
+			if d.keep {
+				kept = append(kept, item)
+			} else {
+				s.stats.SpansSampledOut++
+			}
+			continue
+		}
+
+		trace, ok := s.pending[key]
+		if !ok {
+			if len(s.pending) >= s.maxPending {
+				kept = append(kept, s.decideOldest(now)...)
+			}
+			trace = &pendingTrace{traceID: item.TraceID, firstSeen: now}
+			s.pending[key] = trace
+			s.order = append(s.order, key)
+		}
+
+		trace.spans = append(trace.spans, item)
+		if item.Status == "ERROR" {
+			trace.hasError = true
+		}
+		if item.Duration > trace.maxDuration {
+			trace.maxDuration = item.Duration
+		}
+	}
+
+	return kept
+}
+
+// ready는 대기 시간이 지난 트레이스(force면 모든 트레이스)를 결정하고 보존할 스팬을 반환합니다.
+func (s *tailSampler) ready(now time.Time, force bool) []traceDomain.TraceItem {
+	kept := []traceDomain.TraceItem{}
+
+	for len(s.order) > 0 {
+		trace := s.pending[s.order[0]]
+		if !force && now.Sub(trace.firstSeen) < s.wait {
+			break
+		}
+		kept = append(kept, s.decideOldest(now)...)
+	}
+
+	s.expire(now)
+	return kept
+}
+
+// decideOldest는 가장 오래 기다린 트레이스의 보존 여부를 결정합니다.
+func (s *tailSampler) decideOldest(now time.Time) []traceDomain.TraceItem {
+	key := s.order[0]
+	s.order = s.order[1:]
+	trace := s.pending[key]
+	delete(s.pending, key)
+
+	reason := s.reason(trace)
+	s.decided[key] = decision{keep: reason != "", decidedAt: now}
+	s.decidedOrder = append(s.decidedOrder, key)
+
+	if reason == "" {
+		s.stats.TracesSampledOut++
+		s.stats.SpansSampledOut += int64(len(trace.spans))
+		return nil
+	}
+
+	s.stats.TracesKept[reason]++
+	return trace.spans
+}
+
+// reason은 트레이스를 보존할 사유를 반환합니다. 버릴 트레이스면 빈 문자열을 반환합니다.
+func (s *tailSampler) reason(trace *pendingTrace) string {
+	switch {
+	case trace.hasError:
+		return KeepError
+	case s.latencyThreshold > 0 && trace.maxDuration >= s.latencyThreshold:
+		return KeepLatency
+	case s.sampled(trace.traceID):
+		return KeepProbabilistic
+	}
+	return ""
+}
+
+// sampled는 trace ID 해시로 확률 샘플링 보존 여부를 결정합니다.
+func (s *tailSampler) sampled(traceID string) bool {
+	h := fnv.New64a()
+	h.Write([]byte(traceID))
+	return float64(h.Sum64()%10000) < s.rate*10000
+}
+
+// expire는 늦은 스팬을 기다리는 시간이 지난 결정을 정리합니다.
+func (s *tailSampler) expire(now time.Time) {
+	for len(s.decidedOrder) > 0 {
+		key := s.decidedOrder[0]
+		if now.Sub(s.decided[key].decidedAt) < decisionTTL {
+			break
+		}
+		delete(s.decided, key)
+		s.decidedOrder = s.decidedOrder[1:]
+	}
+}
+
+// traceKey는 테넌트별로 트레이스를 구분하는 키입니다. 테넌트가 다르면 trace ID가 같아도 다른 트레이스입니다.
+func traceKey(item traceDomain.TraceItem) string {
+	return item.TenantID + "\x00" + item.TraceID
+}
+
+// pendingCount는 결정을 기다리는 트레이스 수를 반환합니다.
+func (s *tailSampler) pendingCount() int {
+	return len(s.pending)
+}
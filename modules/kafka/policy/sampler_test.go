@@ -0,0 +1,215 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+func testItem(traceID, spanID string) traceDomain.TraceItem {
+	return traceDomain.TraceItem{TraceID: traceID, SpanID: spanID, Status: "OK", Duration: 10}
+}
+
+// itemSpanIDs는 스팬 ID를 정렬해 반환합니다.
+func itemSpanIDs(items []traceDomain.TraceItem) []string {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.SpanID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func newTestStats() *Stats {
+	return &Stats{
+		SpansDropped: make(map[string]int64),
+		TracesKept:   make(map[string]int64),
+		LogsDropped:  make(map[string]int64),
+		Redactions:   make(map[string]int64),
+		Transforms:   make(map[string]int64),
+	}
+}
+
+// 확률 샘플링으로 모두 버리더라도 오류, 지연 트레이스는 보존해야 함
+func TestTailSamplerKeepsErrorAndLatency(t *testing.T) {
+	errorSpan := testItem("error-trace", "e2")
+	errorSpan.Status = "ERROR"
+	slowSpan := testItem("slow-trace", "s1")
+	slowSpan.Duration = 500
+
+	tests := []struct {
+		name       string
+		items      []traceDomain.TraceItem
+		wantSpans  []string
+		wantReason string
+	}{
+		{name: "error", items: []traceDomain.TraceItem{testItem("error-trace", "e1"), errorSpan}, wantSpans: []string{"e1", "e2"}, wantReason: KeepError},
+		{name: "latency", items: []traceDomain.TraceItem{slowSpan, testItem("slow-trace", "s2")}, wantSpans: []string{"s1", "s2"}, wantReason: KeepLatency},
+		{name: "normal", items: []traceDomain.TraceItem{testItem("normal-trace", "n1")}, wantSpans: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := newTestStats()
+			s := newTailSampler(0, time.Second, 100, 10, stats)
+			now := time.Now()
+
+			if kept := s.add(tt.items, now); len(kept) != 0 {
+				t.Fatalf("add() returned %d spans before the decision wait", len(kept))
+			}
+			kept := s.ready(now, true)
+
+			if got := itemSpanIDs(kept); !slices.Equal(got, tt.wantSpans) {
+				t.Errorf("kept spans = %v, want %v", got, tt.wantSpans)
+			}
+			if tt.wantReason != "" && stats.TracesKept[tt.wantReason] != 1 {
+				t.Errorf("TracesKept = %v, want one %s trace", stats.TracesKept, tt.wantReason)
+			}
+			if tt.wantReason == "" && (stats.TracesSampledOut != 1 || stats.SpansSampledOut != int64(len(tt.items))) {
+				t.Errorf("sampled out = %d traces %d spans, want 1 trace %d spans", stats.TracesSampledOut, stats.SpansSampledOut, len(tt.items))
+			}
+		})
+	}
+}
+
+// 같은 trace ID는 인스턴스가 달라도 같은 결정을 받아야 함
+func TestTailSamplerDeterministic(t *testing.T) {
+	first := newTailSampler(0.3, time.Second, 0, 10, newTestStats())
+	second := newTailSampler(0.3, time.Second, 0, 10, newTestStats())
+
+	kept := 0
+	for i := 0; i < 1000; i++ {
+		traceID := fmt.Sprintf("%032x", i)
+		if first.sampled(traceID) != second.sampled(traceID) {
+			t.Fatalf("sampled(%s) differs between samplers", traceID)
+		}
+		if first.sampled(traceID) != first.sampled(traceID) {
+			t.Fatalf("sampled(%s) is not stable", traceID)
+		}
+		if first.sampled(traceID) {
+			kept++
+		}
+	}
+	if kept < 200 || kept > 400 {
+		t.Errorf("kept %d of 1000 traces, want about 300", kept)
+	}
+
+	for _, tt := range []struct {
+		rate float64
+		want bool
+	}{{0, false}, {1, true}} {
+		s := newTailSampler(tt.rate, time.Second, 0, 10, newTestStats())
+		if got := s.sampled("any-trace"); got != tt.want {
+			t.Errorf("rate %v: sampled() = %v, want %v", tt.rate, got, tt.want)
+		}
+	}
+}
+
+// 대기 트레이스 수가 최대치를 넘으면 대기 시간과 관계없이 가장 오래된 트레이스를 결정해야 함
+func TestTailSamplerMaxPending(t *testing.T) {
+	stats := newTestStats()
+	s := newTailSampler(1, time.Hour, 0, 2, stats)
+	now := time.Now()
+
+	kept := s.add([]traceDomain.TraceItem{testItem("t1", "a"), testItem("t2", "b")}, now)
+	if len(kept) != 0 || s.pendingCount() != 2 {
+		t.Fatalf("kept %d spans with %d pending, want 0 kept and 2 pending", len(kept), s.pendingCount())
+	}
+
+	kept = s.add([]traceDomain.TraceItem{testItem("t3", "c"), testItem("t1", "a2")}, now)
+	if got := itemSpanIDs(kept); !slices.Equal(got, []string{"a", "a2"}) {
+		t.Errorf("kept spans = %v, want the oldest trace t1 and its late span", got)
+	}
+	if s.pendingCount() != 2 {
+		t.Errorf("pendingCount() = %d, want 2", s.pendingCount())
+	}
+	if stats.TracesKept[KeepProbabilistic] != 1 {
+		t.Errorf("TracesKept = %v, want one probabilistic trace", stats.TracesKept)
+	}
+}
+
+// 결정 후 decisionTTL 안에 도착한 스팬은 같은 결정을 따르고, 지난 뒤에는 새로 대기해야 함
+func TestTailSamplerLateSpans(t *testing.T) {
+	errorSpan := testItem("kept", "k1")
+	errorSpan.Status = "ERROR"
+
+	stats := newTestStats()
+	s := newTailSampler(0, time.Second, 0, 10, stats)
+	now := time.Now()
+
+	s.add([]traceDomain.TraceItem{errorSpan, testItem("dropped", "d1")}, now)
+	s.ready(now.Add(time.Second), false)
+
+	late := now.Add(decisionTTL / 2)
+	kept := s.add([]traceDomain.TraceItem{testItem("kept", "k2"), testItem("dropped", "d2")}, late)
+	if got := itemSpanIDs(kept); !slices.Equal(got, []string{"k2"}) {
+		t.Errorf("late spans kept = %v, want [k2]", got)
+	}
+	if stats.SpansSampledOut != 2 {
+		t.Errorf("SpansSampledOut = %d, want 2", stats.SpansSampledOut)
+	}
+	if s.pendingCount() != 0 {
+		t.Errorf("pendingCount() = %d, want 0", s.pendingCount())
+	}
+
+	expired := now.Add(time.Second + decisionTTL)
+	s.ready(expired, false)
+	if kept := s.add([]traceDomain.TraceItem{testItem("kept", "k3")}, expired); len(kept) != 0 {
+		t.Errorf("span after decisionTTL was kept immediately, want pending")
+	}
+	if s.pendingCount() != 1 {
+		t.Errorf("pendingCount() = %d, want 1", s.pendingCount())
+	}
+}
+
+// ready는 대기 시간이 지난 트레이스만 결정하고, force면 모두 결정해야 함
+func TestTailSamplerReady(t *testing.T) {
+	s := newTailSampler(1, time.Second, 0, 10, newTestStats())
+	now := time.Now()
+
+	s.add([]traceDomain.TraceItem{testItem("old", "o1")}, now)
+	s.add([]traceDomain.TraceItem{testItem("new", "n1")}, now.Add(900*time.Millisecond))
+
+	if got := itemSpanIDs(s.ready(now.Add(time.Second), false)); !slices.Equal(got, []string{"o1"}) {
+		t.Errorf("ready() = %v, want [o1]", got)
+	}
+	if got := itemSpanIDs(s.ready(now.Add(time.Second), true)); !slices.Equal(got, []string{"n1"}) {
+		t.Errorf("ready(force) = %v, want [n1]", got)
+	}
+	if s.pendingCount() != 0 {
+		t.Errorf("pendingCount() = %d, want 0", s.pendingCount())
+	}
+}
+
+// 테넌트가 다르면 trace ID가 같아도 따로 결정해야 함
+func TestTailSamplerSeparatesTenants(t *testing.T) {
+	errorSpan := testItem("shared", "a1")
+	errorSpan.TenantID = "tenant-a"
+	errorSpan.Status = "ERROR"
+	otherSpan := testItem("shared", "b1")
+	otherSpan.TenantID = "tenant-b"
+
+	s := newTailSampler(0, time.Second, 0, 10, newTestStats())
+	now := time.Now()
+
+	s.add([]traceDomain.TraceItem{errorSpan, otherSpan}, now)
+	if s.pendingCount() != 2 {
+		t.Fatalf("pendingCount() = %d, want 2", s.pendingCount())
+	}
+	if got := itemSpanIDs(s.ready(now, true)); !slices.Equal(got, []string{"a1"}) {
+		t.Errorf("kept spans = %v, want only tenant-a's error trace", got)
+	}
+
+	// 늦게 도착한 스팬도 자기 테넌트의 결정을 따름
+	lateOther := testItem("shared", "b2")
+	lateOther.TenantID = "tenant-b"
+	lateError := testItem("shared", "a2")
+	lateError.TenantID = "tenant-a"
+	if got := itemSpanIDs(s.add([]traceDomain.TraceItem{lateOther, lateError}, now)); !slices.Equal(got, []string{"a2"}) {
+		t.Errorf("late spans kept = %v, want [a2]", got)
+	}
+}
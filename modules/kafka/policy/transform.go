@@ -0,0 +1,273 @@
+package policy
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	logDomain "github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	traceDomain "github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// 속성 변환 방식
+const (
+	TransformSet     = "set"     // key에 value 설정 (기존 값 덮어씀)
+	TransformRename  = "rename"  // from 키를 to 키로 이동
+	TransformDelete  = "delete"  // key 삭제
+	TransformCopy    = "copy"    // from 값을 to 키로 복사
+	TransformExtract = "extract" // from 값에서 정규식 캡처 그룹을 추출해 to(또는 이름 있는 그룹 이름) 키로 설정
+)
+
+// 변환 대상 신호
+const (
+	SignalTraces = "traces"
+	SignalLogs   = "logs"
+)
+
+// Transform은 스팬/로그 속성에 적용할 선언적 변환입니다. 정책 파일에 나열한 순서대로 적용됩니다.
+// rename, copy, extract는 대상 키에 값이 이미 있으면 overwrite가 true일 때만 덮어씁니다.
+//
+//	transforms:
+//	  - action: rename
+//	    from: http.status_code
+//	    to: http.response.status_code
+//	  - action: extract
+//	    from: k8s.namespace.name
+//	    pattern: "^(prod|stage|dev)-"
+//	    to: deployment.environment
+//	  - action: set
+//	    key: team
+//	    value: payments
+//	    when:
+//	      service: checkout
+type Transform struct {
+	Name      string     `yaml:"name"`   // 통계에 표시할 변환 이름
+	Signal    string     `yaml:"signal"` // traces, logs (비어 있으면 모두)
+	Action    string     `yaml:"action"`
+	Key       string     `yaml:"key"`       // set, delete
+	Value     string     `yaml:"value"`     // set
+	From      string     `yaml:"from"`      // rename, copy, extract
+	To        string     `yaml:"to"`        // rename, copy, extract
+	Pattern   string     `yaml:"pattern"`   // extract 정규식
+	Overwrite bool       `yaml:"overwrite"` // rename, copy, extract 대상 키 덮어쓰기 여부
+	When      *Condition `yaml:"when"`      // 모든 조건을 만족할 때만 적용
+
+	pattern *regexp.Regexp
+}
+
+// Condition은 변환을 적용할 조건입니다. 지정한 조건을 모두 만족해야 합니다.
+type Condition struct {
+	Service    string            `yaml:"service"`    // 서비스 이름 (정확히 일치)
+	Attributes map[string]string `yaml:"attributes"` // 속성 값, "*"이면 속성이 있기만 하면 일치
+	Matches    map[string]string `yaml:"matches"`    // 속성 값 정규식
+	Missing    []string          `yaml:"missing"`    // 없어야 하는 속성
+
+	matches map[string]*regexp.Regexp
+}
+
+// Transforms는 순서대로 적용할 변환 목록입니다.
+type Transforms []Transform
+
+// compile은 변환 목록을 검증하고 정규식을 컴파일합니다.
+func (ts Transforms) compile() error {
+	for i := range ts {
+		if err := ts[i].compile(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compile은 변환 하나를 검증합니다.
+func (t *Transform) compile(index int) error {
+	if t.Name == "" {
+		t.Name = fmt.Sprintf("transform-%d-%s", index, t.Action)
+	}
+
+	switch t.Signal {
+	case "", SignalTraces, SignalLogs:
+	default:
+		return fmt.Errorf("transforms[%d]: unsupported signal %q", index, t.Signal)
+	}
+
+	switch t.Action {
+	case TransformSet, TransformDelete:
+		if t.Key == "" {
+			return fmt.Errorf("transforms[%d]: key is required for %s", index, t.Action)
+		}
+	case TransformRename, TransformCopy:
+		if t.From == "" || t.To == "" {
+			return fmt.Errorf("transforms[%d]: from and to are required for %s", index, t.Action)
+		}
+	case TransformExtract:
+		if t.From == "" || t.Pattern == "" {
+			return fmt.Errorf("transforms[%d]: from and pattern are required for extract", index)
+		}
+		pattern, err := regexp.Compile(t.Pattern)
+		if err != nil {
+			return fmt.Errorf("transforms[%d]: invalid pattern: %w", index, err)
+		}
+		if t.To == "" && !hasNamedGroup(pattern) {
+			return fmt.Errorf("transforms[%d]: to or a named capture group is required for extract", index)
+		}
+		if t.To != "" && pattern.NumSubexp() == 0 {
+			return fmt.Errorf("transforms[%d]: pattern needs a capture group", index)
+		}
+		t.pattern = pattern
+	default:
+		return fmt.Errorf("transforms[%d]: unsupported action %q", index, t.Action)
+	}
+
+	if t.When != nil {
+		t.When.matches = make(map[string]*regexp.Regexp, len(t.When.Matches))
+		for key, expr := range t.When.Matches {
+			pattern, err := regexp.Compile(expr)
+			if err != nil {
+				return fmt.Errorf("transforms[%d]: invalid when.matches[%s]: %w", index, key, err)
+			}
+			t.When.matches[key] = pattern
+		}
+	}
+
+	return nil
+}
+
+// ApplySpan은 스팬에 변환을 순서대로 적용하고 실제로 속성을 바꾼 변환 이름을 반환합니다.
+func (ts Transforms) ApplySpan(item *traceDomain.TraceItem) []string {
+	if item.Attributes == nil {
+		item.Attributes = make(map[string]interface{})
+	}
+	return ts.apply(SignalTraces, item.ServiceName, item.Attributes)
+}
+
+// ApplyLog는 로그에 변환을 순서대로 적용하고 실제로 속성을 바꾼 변환 이름을 반환합니다.
+func (ts Transforms) ApplyLog(item *logDomain.LogItem) []string {
+	if item.Attributes == nil {
+		item.Attributes = make(map[string]interface{})
+	}
+	return ts.apply(SignalLogs, item.ServiceName, item.Attributes)
+}
+
+// apply는 신호에 해당하는 변환을 적용합니다.
+func (ts Transforms) apply(signal, service string, attributes map[string]interface{}) []string {
+	var applied []string
+	for i := range ts {
+		t := &ts[i]
+		if t.Signal != "" && t.Signal != signal {
+			continue
+		}
+		if t.When != nil && !t.When.match(service, attributes) {
+			continue
+		}
+		if t.apply(attributes) {
+			applied = append(applied, t.Name)
+		}
+	}
+	return applied
+}
+
+// apply는 변환 하나를 적용하고 속성이 바뀌었는지 반환합니다.
+func (t *Transform) apply(attributes map[string]interface{}) bool {
+	switch t.Action {
+	case TransformSet:
+		return assign(attributes, t.Key, t.Value)
+
+	case TransformDelete:
+		if _, ok := attributes[t.Key]; !ok {
+			return false
+		}
+		delete(attributes, t.Key)
+		return true
+
+	case TransformRename, TransformCopy:
+		value, ok := attributes[t.From]
+		if !ok || t.From == t.To || !t.writable(attributes, t.To) {
+			return false
+		}
+		changed := assign(attributes, t.To, value)
+		if t.Action == TransformRename {
+			delete(attributes, t.From)
+			changed = true
+		}
+		return changed
+
+	case TransformExtract:
+		value, ok := attributes[t.From]
+		if !ok {
+			return false
+		}
+		match := t.pattern.FindStringSubmatch(fmt.Sprint(value))
+		if match == nil {
+			return false
+		}
+
+		changed := false
+		if t.To != "" {
+			if t.writable(attributes, t.To) {
+				changed = assign(attributes, t.To, match[1])
+			}
+			return changed
+		}
+		for i, name := range t.pattern.SubexpNames() {
+			if name == "" || !t.writable(attributes, name) {
+				continue
+			}
+			if assign(attributes, name, match[i]) {
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	return false
+}
+
+// writable은 대상 키에 값을 쓸 수 있는지 확인합니다.
+func (t *Transform) writable(attributes map[string]interface{}, key string) bool {
+	if t.Overwrite {
+		return true
+	}
+	_, exists := attributes[key]
+	return !exists
+}
+
+// assign은 key에 value를 설정하고 기존 값과 달라 실제로 바뀌었는지 반환합니다.
+func assign(attributes map[string]interface{}, key string, value interface{}) bool {
+	if existing, ok := attributes[key]; ok && reflect.DeepEqual(existing, value) {
+		return false
+	}
+	attributes[key] = value
+	return true
+}
+
+// match는 조건을 모두 만족하는지 확인합니다.
+func (c *Condition) match(service string, attributes map[string]interface{}) bool {
+	if c.Service != "" && c.Service != service {
+		return false
+	}
+	if !matchAttributes(c.Attributes, attributes) {
+		return false
+	}
+	for key, pattern := range c.matches {
+		value, ok := attributes[key]
+		if !ok || !pattern.MatchString(fmt.Sprint(value)) {
+			return false
+		}
+	}
+	for _, key := range c.Missing {
+		if _, ok := attributes[key]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// hasNamedGroup은 정규식에 이름 있는 캡처 그룹이 있는지 확인합니다.
+func hasNamedGroup(pattern *regexp.Regexp) bool {
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransformsApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		transforms  Transforms
+		signal      string
+		service     string
+		attributes  map[string]interface{}
+		want        map[string]interface{}
+		wantApplied []string
+	}{
+		{
+			name:        "set",
+			transforms:  Transforms{{Name: "t", Action: TransformSet, Key: "team", Value: "payments"}},
+			attributes:  map[string]interface{}{},
+			want:        map[string]interface{}{"team": "payments"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:       "set same value is not counted",
+			transforms: Transforms{{Name: "t", Action: TransformSet, Key: "team", Value: "payments"}},
+			attributes: map[string]interface{}{"team": "payments"},
+			want:       map[string]interface{}{"team": "payments"},
+		},
+		{
+			name:        "set overwrites other value",
+			transforms:  Transforms{{Name: "t", Action: TransformSet, Key: "team", Value: "payments"}},
+			attributes:  map[string]interface{}{"team": "search"},
+			want:        map[string]interface{}{"team": "payments"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:        "delete",
+			transforms:  Transforms{{Name: "t", Action: TransformDelete, Key: "secret"}},
+			attributes:  map[string]interface{}{"secret": "x", "keep": "y"},
+			want:        map[string]interface{}{"keep": "y"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:       "delete missing key",
+			transforms: Transforms{{Name: "t", Action: TransformDelete, Key: "secret"}},
+			attributes: map[string]interface{}{"keep": "y"},
+			want:       map[string]interface{}{"keep": "y"},
+		},
+		{
+			name:        "rename",
+			transforms:  Transforms{{Name: "t", Action: TransformRename, From: "http.status_code", To: "http.response.status_code"}},
+			attributes:  map[string]interface{}{"http.status_code": 200},
+			want:        map[string]interface{}{"http.response.status_code": 200},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:       "rename keeps existing target",
+			transforms: Transforms{{Name: "t", Action: TransformRename, From: "a", To: "b"}},
+			attributes: map[string]interface{}{"a": "1", "b": "2"},
+			want:       map[string]interface{}{"a": "1", "b": "2"},
+		},
+		{
+			name:        "rename overwrites target",
+			transforms:  Transforms{{Name: "t", Action: TransformRename, From: "a", To: "b", Overwrite: true}},
+			attributes:  map[string]interface{}{"a": "1", "b": "2"},
+			want:        map[string]interface{}{"b": "1"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:       "rename to itself",
+			transforms: Transforms{{Name: "t", Action: TransformRename, From: "a", To: "a", Overwrite: true}},
+			attributes: map[string]interface{}{"a": "1"},
+			want:       map[string]interface{}{"a": "1"},
+		},
+		{
+			name:        "copy",
+			transforms:  Transforms{{Name: "t", Action: TransformCopy, From: "a", To: "b"}},
+			attributes:  map[string]interface{}{"a": "1"},
+			want:        map[string]interface{}{"a": "1", "b": "1"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:       "copy same value is not counted",
+			transforms: Transforms{{Name: "t", Action: TransformCopy, From: "a", To: "b", Overwrite: true}},
+			attributes: map[string]interface{}{"a": []interface{}{"x"}, "b": []interface{}{"x"}},
+			want:       map[string]interface{}{"a": []interface{}{"x"}, "b": []interface{}{"x"}},
+		},
+		{
+			name:       "copy missing source",
+			transforms: Transforms{{Name: "t", Action: TransformCopy, From: "a", To: "b"}},
+			attributes: map[string]interface{}{},
+			want:       map[string]interface{}{},
+		},
+		{
+			name:        "extract to key",
+			transforms:  Transforms{{Name: "t", Action: TransformExtract, From: "ns", Pattern: "^(prod|stage|dev)-", To: "env"}},
+			attributes:  map[string]interface{}{"ns": "prod-payments"},
+			want:        map[string]interface{}{"ns": "prod-payments", "env": "prod"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:        "extract named groups",
+			transforms:  Transforms{{Name: "t", Action: TransformExtract, From: "ns", Pattern: "^(?P<env>[a-z]+)-(?P<team>[a-z]+)$"}},
+			attributes:  map[string]interface{}{"ns": "prod-payments", "env": "prod"},
+			want:        map[string]interface{}{"ns": "prod-payments", "env": "prod", "team": "payments"},
+			wantApplied: []string{"t"},
+		},
+		{
+			name:       "extract without match",
+			transforms: Transforms{{Name: "t", Action: TransformExtract, From: "ns", Pattern: "^(prod)-", To: "env"}},
+			attributes: map[string]interface{}{"ns": "qa-payments"},
+			want:       map[string]interface{}{"ns": "qa-payments"},
+		},
+		{
+			name:       "extract same value is not counted",
+			transforms: Transforms{{Name: "t", Action: TransformExtract, From: "ns", Pattern: "^(prod)-", To: "env", Overwrite: true}},
+			attributes: map[string]interface{}{"ns": "prod-payments", "env": "prod"},
+			want:       map[string]interface{}{"ns": "prod-payments", "env": "prod"},
+		},
+		{
+			name: "other signal is skipped",
+			transforms: Transforms{
+				{Name: "logs-only", Signal: SignalLogs, Action: TransformSet, Key: "a", Value: "1"},
+				{Name: "traces-only", Signal: SignalTraces, Action: TransformSet, Key: "b", Value: "2"},
+			},
+			signal:      SignalTraces,
+			attributes:  map[string]interface{}{},
+			want:        map[string]interface{}{"b": "2"},
+			wantApplied: []string{"traces-only"},
+		},
+		{
+			name: "when conditions",
+			transforms: Transforms{
+				{Name: "service", Action: TransformSet, Key: "a", Value: "1", When: &Condition{Service: "checkout"}},
+				{Name: "other-service", Action: TransformSet, Key: "b", Value: "1", When: &Condition{Service: "search"}},
+				{Name: "attribute", Action: TransformSet, Key: "c", Value: "1", When: &Condition{Attributes: map[string]string{"env": "*"}}},
+				{Name: "matches", Action: TransformSet, Key: "d", Value: "1", When: &Condition{Matches: map[string]string{"env": "^pro"}}},
+				{Name: "missing", Action: TransformSet, Key: "e", Value: "1", When: &Condition{Missing: []string{"env"}}},
+			},
+			service:     "checkout",
+			attributes:  map[string]interface{}{"env": "prod"},
+			want:        map[string]interface{}{"env": "prod", "a": "1", "c": "1", "d": "1"},
+			wantApplied: []string{"service", "attribute", "matches"},
+		},
+		{
+			name: "applied in order",
+			transforms: Transforms{
+				{Name: "copy", Action: TransformCopy, From: "a", To: "b"},
+				{Name: "delete", Action: TransformDelete, Key: "a"},
+				{Name: "rename", Action: TransformRename, From: "b", To: "c"},
+			},
+			attributes:  map[string]interface{}{"a": "1"},
+			want:        map[string]interface{}{"c": "1"},
+			wantApplied: []string{"copy", "delete", "rename"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.transforms.compile(); err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			signal := tt.signal
+			if signal == "" {
+				signal = SignalTraces
+			}
+
+			applied := tt.transforms.apply(signal, tt.service, tt.attributes)
+			if !reflect.DeepEqual(tt.attributes, tt.want) {
+				t.Errorf("attributes = %v, want %v", tt.attributes, tt.want)
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("applied = %v, want %v", applied, tt.wantApplied)
+			}
+		})
+	}
+}
+
+func TestTransformsCompile(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform Transform
+		wantErr   string // 비어 있으면 오류 없음
+	}{
+		{name: "set", transform: Transform{Action: TransformSet, Key: "a", Value: "1"}},
+		{name: "unsupported signal", transform: Transform{Signal: "metrics", Action: TransformSet, Key: "a"}, wantErr: "unsupported signal"},
+		{name: "unsupported action", transform: Transform{Action: "upper", Key: "a"}, wantErr: "unsupported action"},
+		{name: "set without key", transform: Transform{Action: TransformSet, Value: "1"}, wantErr: "key is required"},
+		{name: "delete without key", transform: Transform{Action: TransformDelete}, wantErr: "key is required"},
+		{name: "rename without to", transform: Transform{Action: TransformRename, From: "a"}, wantErr: "from and to are required"},
+		{name: "copy without from", transform: Transform{Action: TransformCopy, To: "b"}, wantErr: "from and to are required"},
+		{name: "extract without pattern", transform: Transform{Action: TransformExtract, From: "a", To: "b"}, wantErr: "from and pattern are required"},
+		{name: "extract invalid pattern", transform: Transform{Action: TransformExtract, From: "a", To: "b", Pattern: "("}, wantErr: "invalid pattern"},
+		{name: "extract without target", transform: Transform{Action: TransformExtract, From: "a", Pattern: "(x)"}, wantErr: "named capture group is required"},
+		{name: "extract without group", transform: Transform{Action: TransformExtract, From: "a", To: "b", Pattern: "x"}, wantErr: "needs a capture group"},
+		{name: "extract named group", transform: Transform{Action: TransformExtract, From: "a", Pattern: "(?P<b>x)"}},
+		{name: "invalid when matches", transform: Transform{Action: TransformSet, Key: "a", When: &Condition{Matches: map[string]string{"b": "("}}}, wantErr: "invalid when.matches[b]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Transforms{tt.transform}.compile()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("compile() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("compile() error = %v, want %q", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(err.Error(), "transforms[0]: ") {
+				t.Errorf("compile() error = %v, want transform index", err)
+			}
+		})
+	}
+}
+
+// 이름이 없는 변환은 순서와 동작으로 통계 이름을 정해야 함
+func TestTransformsCompileName(t *testing.T) {
+	ts := Transforms{{Action: TransformSet, Key: "a"}, {Name: "custom", Action: TransformDelete, Key: "a"}}
+	if err := ts.compile(); err != nil {
+		t.Fatal(err)
+	}
+	if ts[0].Name != "transform-0-set" || ts[1].Name != "custom" {
+		t.Errorf("names = %q %q, want transform-0-set custom", ts[0].Name, ts[1].Name)
+	}
+}
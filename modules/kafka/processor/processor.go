@@ -141,6 +141,7 @@ func (p *ProtoProcessor) ProcessResourceSpans(resourceSpans *tracepb.ResourceSpa
 				EndTime:     int64(span.EndTimeUnixNano / 1000000),   // nano → milli
 				Duration:    float64(span.EndTimeUnixNano-span.StartTimeUnixNano) / 1000000,
 				Status:      status,
+				Kind:        spanKindToString(span.Kind),
 				Attributes:  attributes,
 			}
 			
@@ -274,6 +275,24 @@ func (p *ProtoProcessor) ProcessResourceLogs(resourceLogs *logspb.ResourceLogs)
 	return logs
 }
 
+// spanKindToString은 OTLP 스팬 종류를 문자열로 변환합니다.
+func spanKindToString(kind tracepb.Span_SpanKind) string {
+	switch kind {
+	case tracepb.Span_SPAN_KIND_INTERNAL:
+		return traceDomain.SpanKindInternal
+	case tracepb.Span_SPAN_KIND_SERVER:
+		return traceDomain.SpanKindServer
+	case tracepb.Span_SPAN_KIND_CLIENT:
+		return traceDomain.SpanKindClient
+	case tracepb.Span_SPAN_KIND_PRODUCER:
+		return traceDomain.SpanKindProducer
+	case tracepb.Span_SPAN_KIND_CONSUMER:
+		return traceDomain.SpanKindConsumer
+	default:
+		return traceDomain.SpanKindUnspecified
+	}
+}
+
 // getAttributeValue는 속성 값을 적절한 Go 타입으로 변환합니다.
 func (p *ProtoProcessor) getAttributeValue(value *commonpb.AnyValue) interface{} {
 	if value == nil {
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// 히스토그램 버킷 수 제한
+const (
+	TargetHistogramBuckets = 60   // step=auto일 때 목표 버킷 수
+	MaxHistogramBuckets    = 1440 // 허용하는 최대 버킷 수
+)
+
+// histogramSteps는 step=auto일 때 선택할 수 있는 버킷 크기 목록입니다.
+var histogramSteps = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	3 * time.Hour,
+	6 * time.Hour,
+	12 * time.Hour,
+	24 * time.Hour,
+}
+
+// AutoHistogramStep은 시간 범위(밀리초)에 대해 버킷 수가 TargetHistogramBuckets 이하가 되는
+// 가장 작은 버킷 크기(밀리초)를 반환합니다.
+func AutoHistogramStep(startTime, endTime int64) int64 {
+	rangeMillis := endTime - startTime
+	for _, step := range histogramSteps {
+		if rangeMillis/step.Milliseconds() <= TargetHistogramBuckets {
+			return step.Milliseconds()
+		}
+	}
+	return histogramSteps[len(histogramSteps)-1].Milliseconds()
+}
+
+// LogHistogramBucket은 한 시간 버킷의 심각도별 로그 수를 정의합니다.
+type LogHistogramBucket struct {
+	Timestamp int64            `json:"timestamp"` // 버킷 시작 시간 (밀리초)
+	Total     int64            `json:"total"`
+	Counts    map[string]int64 `json:"counts"` // 심각도별 로그 수
+}
+
+// LogHistogram은 로그 볼륨 히스토그램 조회 결과를 정의합니다.
+// 로그가 없는 버킷도 0 값으로 채워져 포함됩니다.
+type LogHistogram struct {
+	Step       int64                `json:"step"` // 버킷 크기 (밀리초)
+	StartTime  int64                `json:"startTime"`
+	EndTime    int64                `json:"endTime"`
+	Severities []string             `json:"severities"` // 결과에 등장한 심각도 (높은 순)
+	Buckets    []LogHistogramBucket `json:"buckets"`
+	Total      int64                `json:"total"`
+	Took       int64                `json:"took"`
+}
@@ -14,6 +14,12 @@ type LogItem struct {
 	TraceID     string                 `json:"traceId,omitempty"`
 	SpanID      string                 `json:"spanId,omitempty"`
 	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	PatternID   string                 `json:"patternId,omitempty"`
+	TenantID    string                 `json:"tenantId,omitempty"`
+
+	// 로그가 연결된 스팬 정보 (조회 시 traces 테이블에서 채워짐)
+	SpanName        string `json:"spanName,omitempty"`
+	SpanServiceName string `json:"spanServiceName,omitempty"`
 }
 
 // SeverityLevel은 로그 심각도 수준을 정의합니다.
@@ -76,12 +82,15 @@ type SeverityAggregation struct {
 
 // LogFilter는 로그 필터링 옵션을 정의합니다.
 type LogFilter struct {
+	TenantID     string   `json:"-"`
 	StartTime    int64    `json:"startTime"`
 	EndTime      int64    `json:"endTime"`
 	ServiceNames []string `json:"serviceNames,omitempty"`
 
 	Severity      *string `json:"severity,omitempty"`
 	HasTrace      bool    `json:"hasTrace"`
+	TraceID       string  `json:"traceId,omitempty"`
+	SpanID        string  `json:"spanId,omitempty"`
 	Query         *string `json:"query,omitempty"`
 	Limit         int     `json:"limit"`
 	Offset        int     `json:"offset"`
@@ -0,0 +1,80 @@
+package domain
+
+// 패턴 추세 유형
+const (
+	PatternTrendNew    = "NEW"    // 이전 구간에 없던 패턴
+	PatternTrendUp     = "UP"     // 이전 구간 대비 증가
+	PatternTrendDown   = "DOWN"   // 이전 구간 대비 감소
+	PatternTrendStable = "STABLE" // 변화 폭이 작음
+)
+
+// 추세를 STABLE로 판단하는 최대 변화율 (%)
+const patternStableThreshold = 20.0
+
+// LogPattern은 저장된 로그 패턴(템플릿)을 정의합니다.
+type LogPattern struct {
+	TenantID    string `json:"-"`
+	PatternID   string `json:"patternId"`
+	ServiceName string `json:"serviceName"`
+	Template    string `json:"template"`
+	FirstSeen   int64  `json:"firstSeen"`
+	LastSeen    int64  `json:"lastSeen"`
+	Sample      string `json:"sample,omitempty"`
+}
+
+// LogPatternStats는 조회 구간의 패턴별 통계를 정의합니다.
+type LogPatternStats struct {
+	PatternID     string   `json:"patternId"`
+	ServiceName   string   `json:"serviceName"`
+	Template      string   `json:"template"`
+	Count         int64    `json:"count"`
+	PreviousCount int64    `json:"previousCount"` // 바로 이전 같은 길이 구간의 개수
+	ChangePercent float64  `json:"changePercent"`
+	Trend         string   `json:"trend"`
+	FirstSeen     int64    `json:"firstSeen"`
+	LastSeen      int64    `json:"lastSeen"`
+	Samples       []string `json:"samples"`
+}
+
+// LogPatternFilter는 로그 패턴 조회 옵션을 정의합니다.
+type LogPatternFilter struct {
+	TenantID     string   `json:"-"`
+	StartTime    int64    `json:"startTime"`
+	EndTime      int64    `json:"endTime"`
+	ServiceNames []string `json:"serviceNames,omitempty"`
+	Severity     *string  `json:"severity,omitempty"`
+	Limit        int      `json:"limit"`
+}
+
+// LogPatternResult는 로그 패턴 조회 결과를 정의합니다.
+type LogPatternResult struct {
+	Patterns          []LogPatternStats `json:"patterns"`
+	StartTime         int64             `json:"startTime"`
+	EndTime           int64             `json:"endTime"`
+	PreviousStartTime int64             `json:"previousStartTime"`
+	Total             int               `json:"total"`
+	Took              int64             `json:"took"`
+}
+
+// SetTrend는 이전 구간 대비 변화율과 추세를 계산합니다.
+// previousStart는 비교 대상인 이전 구간의 시작 시간입니다.
+func (s *LogPatternStats) SetTrend(previousStart int64) {
+	if s.PreviousCount == 0 {
+		s.Trend = PatternTrendNew
+		if s.FirstSeen < previousStart {
+			// 이전 구간에는 없었지만 그 이전에 본 적이 있는 패턴
+			s.Trend = PatternTrendUp
+		}
+		return
+	}
+
+	s.ChangePercent = float64(s.Count-s.PreviousCount) / float64(s.PreviousCount) * 100
+	switch {
+	case s.ChangePercent > patternStableThreshold:
+		s.Trend = PatternTrendUp
+	case s.ChangePercent < -patternStableThreshold:
+		s.Trend = PatternTrendDown
+	default:
+		s.Trend = PatternTrendStable
+	}
+}
@@ -0,0 +1,257 @@
+package pattern
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Wildcard는 템플릿에서 가변 토큰을 나타냅니다.
+const Wildcard = "<*>"
+
+// Cluster는 같은 템플릿으로 묶인 로그 메시지 그룹입니다.
+type Cluster struct {
+	ID          string
+	ServiceName string
+	Tokens      []string
+
+	leaf    *node         // 클러스터가 속한 리프 노드
+	element *list.Element // 최근 사용 순서 목록의 위치
+}
+
+// Template은 클러스터의 템플릿 문자열을 반환합니다.
+func (c *Cluster) Template() string {
+	return strings.Join(c.Tokens, " ")
+}
+
+// node는 접두사 트리의 노드입니다. 리프 노드는 클러스터 목록을 가집니다.
+type node struct {
+	children map[string]*node
+	clusters []*Cluster
+}
+
+func newNode() *node {
+	return &node{children: make(map[string]*node)}
+}
+
+// Miner는 Drain 알고리즘으로 로그 메시지를 템플릿으로 묶는 패턴 마이너입니다.
+// 서비스와 토큰 수로 첫 단계를 나누고, 앞쪽 토큰(depth-2개)으로 접두사 트리를 구성한 뒤
+// 리프 노드의 클러스터 중 토큰 유사도가 가장 높은 클러스터에 메시지를 할당합니다.
+// 최대 클러스터 수에 도달하면 가장 오래 메시지가 할당되지 않은 클러스터를 제거하고 새 클러스터를 만듭니다.
+type Miner struct {
+	mu           sync.Mutex
+	depth        int
+	simThreshold float64
+	maxChildren  int
+	maxClusters  int
+	root         map[string]*node
+	clusters     map[string]*Cluster
+	recent       *list.List // 최근에 메시지가 할당된 순서 (앞쪽이 최근)
+}
+
+// NewMiner는 새 패턴 마이너를 생성합니다.
+func NewMiner(depth int, simThreshold float64, maxChildren, maxClusters int) *Miner {
+	if depth < 3 {
+		depth = 3
+	}
+	return &Miner{
+		depth:        depth,
+		simThreshold: simThreshold,
+		maxChildren:  maxChildren,
+		maxClusters:  maxClusters,
+		root:         make(map[string]*node),
+		clusters:     make(map[string]*Cluster),
+		recent:       list.New(),
+	}
+}
+
+// Len은 현재 유지 중인 클러스터 수를 반환합니다.
+func (m *Miner) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.clusters)
+}
+
+// Load는 저장된 패턴으로 클러스터를 복원합니다. 재시작 후에도 같은 패턴 ID를 유지하기 위해 사용합니다.
+// 최근에 발견된 패턴부터 순서대로 불러온다고 보고, 나중에 불러온 패턴을 먼저 제거합니다.
+func (m *Miner) Load(id, serviceName, template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.clusters[id]; exists || len(m.clusters) >= m.maxClusters {
+		return
+	}
+	cluster := &Cluster{ID: id, ServiceName: serviceName, Tokens: strings.Fields(template)}
+	cluster.leaf = m.leaf(serviceName, cluster.Tokens, true)
+	cluster.leaf.clusters = append(cluster.leaf.clusters, cluster)
+	cluster.element = m.recent.PushBack(cluster)
+	m.clusters[id] = cluster
+}
+
+// Add는 메시지를 가장 유사한 클러스터에 할당하고 패턴 ID와 템플릿을 반환합니다.
+// 유사한 클러스터가 없으면 새 클러스터를 만들며, 최대 클러스터 수에 도달했으면 가장 오래 사용하지 않은 클러스터를 제거합니다.
+// 최대 클러스터 수가 0 이하이면 새 클러스터를 만들지 않고 ok=false를 반환합니다.
+func (m *Miner) Add(serviceName, message string) (id string, template string, ok bool) {
+	tokens := tokenize(message)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 기존 경로(와일드카드 노드 포함)에서 먼저 찾아, 일반화된 템플릿에도 매칭되도록 합니다
+	if leaf := m.leaf(serviceName, tokens, false); leaf != nil {
+		if cluster := m.match(leaf, tokens); cluster != nil {
+			return m.merge(cluster, tokens)
+		}
+	}
+
+	if m.maxClusters <= 0 {
+		return "", "", false
+	}
+	for len(m.clusters) >= m.maxClusters {
+		m.evict()
+	}
+
+	leaf := m.leaf(serviceName, tokens, true)
+	if cluster := m.match(leaf, tokens); cluster != nil {
+		return m.merge(cluster, tokens)
+	}
+
+	cluster := &Cluster{
+		ID:          clusterID(serviceName, tokens),
+		ServiceName: serviceName,
+		Tokens:      tokens,
+		leaf:        leaf,
+	}
+	leaf.clusters = append(leaf.clusters, cluster)
+	cluster.element = m.recent.PushFront(cluster)
+	m.clusters[cluster.ID] = cluster
+	return cluster.ID, cluster.Template(), true
+}
+
+// evict는 가장 오래 메시지가 할당되지 않은 클러스터를 제거합니다.
+// 같은 형태의 메시지가 다시 오면 같은 ID로 새 클러스터가 만들어집니다.
+func (m *Miner) evict() {
+	oldest := m.recent.Back()
+	if oldest == nil {
+		return
+	}
+	cluster := m.recent.Remove(oldest).(*Cluster)
+	delete(m.clusters, cluster.ID)
+
+	clusters := cluster.leaf.clusters
+	for i, c := range clusters {
+		if c == cluster {
+			cluster.leaf.clusters = append(clusters[:i], clusters[i+1:]...)
+			break
+		}
+	}
+}
+
+// merge는 클러스터 템플릿에서 메시지와 다른 위치의 토큰을 와일드카드로 바꾸고 클러스터를 최근 사용으로 표시합니다.
+func (m *Miner) merge(cluster *Cluster, tokens []string) (string, string, bool) {
+	m.recent.MoveToFront(cluster.element)
+	for i, token := range tokens {
+		if cluster.Tokens[i] != token {
+			cluster.Tokens[i] = Wildcard
+		}
+	}
+	return cluster.ID, cluster.Template(), true
+}
+
+// leaf는 토큰 목록에 해당하는 리프 노드를 찾습니다. create가 true이면 없는 노드를 생성합니다.
+func (m *Miner) leaf(serviceName string, tokens []string, create bool) *node {
+	key := serviceName + "\x00" + strconv.Itoa(len(tokens))
+	current, ok := m.root[key]
+	if !ok {
+		if !create {
+			return nil
+		}
+		current = newNode()
+		m.root[key] = current
+	}
+
+	for i := 0; i < m.depth-2 && i < len(tokens); i++ {
+		token := tokens[i]
+		if child, ok := current.children[token]; ok {
+			current = child
+			continue
+		}
+
+		if !create {
+			wildcard, ok := current.children[Wildcard]
+			if !ok {
+				return nil
+			}
+			current = wildcard
+			continue
+		}
+
+		// 자식 수가 가득 찬 경우 와일드카드 노드로 모읍니다
+		if len(current.children) >= m.maxChildren {
+			token = Wildcard
+		}
+		child, ok := current.children[token]
+		if !ok {
+			child = newNode()
+			current.children[token] = child
+		}
+		current = child
+	}
+	return current
+}
+
+// match는 리프 노드에서 유사도 임계값을 넘는 가장 유사한 클러스터를 찾습니다.
+func (m *Miner) match(leaf *node, tokens []string) *Cluster {
+	var best *Cluster
+	bestSim, bestParams := -1.0, -1
+	for _, cluster := range leaf.clusters {
+		sim, params := similarity(cluster.Tokens, tokens)
+		if sim > bestSim || (sim == bestSim && params > bestParams) {
+			best, bestSim, bestParams = cluster, sim, params
+		}
+	}
+	if best == nil || bestSim < m.simThreshold {
+		return nil
+	}
+	return best
+}
+
+// similarity는 같은 위치의 토큰이 일치하는 비율과 템플릿의 와일드카드 수를 반환합니다.
+func similarity(template, tokens []string) (float64, int) {
+	if len(tokens) == 0 {
+		return 1, 0
+	}
+	var same, params int
+	for i, token := range template {
+		if token == Wildcard {
+			params++
+			continue
+		}
+		if token == tokens[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(tokens)), params
+}
+
+// tokenize는 메시지를 공백 기준으로 나누고 숫자가 포함된 토큰(ID, 시간, IP 등)을 미리 와일드카드로 바꿉니다.
+func tokenize(message string) []string {
+	tokens := strings.Fields(message)
+	for i, token := range tokens {
+		if strings.IndexFunc(token, unicode.IsDigit) >= 0 {
+			tokens[i] = Wildcard
+		}
+	}
+	return tokens
+}
+
+// clusterID는 서비스와 최초 템플릿으로 클러스터 ID를 만듭니다.
+// 같은 최초 메시지 형태는 여러 인스턴스에서도 같은 ID를 갖습니다.
+func clusterID(serviceName string, tokens []string) string {
+	sum := sha1.Sum([]byte(serviceName + "\x00" + strings.Join(tokens, " ")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,89 @@
+package pattern
+
+import "testing"
+
+func TestMinerGroupsMessages(t *testing.T) {
+	m := NewMiner(4, 0.5, 100, 10)
+
+	id1, _, ok := m.Add("api", "user 1 logged in from web")
+	if !ok {
+		t.Fatal("Add() ok = false")
+	}
+	id2, template, _ := m.Add("api", "user 2 logged in from mobile")
+	if id1 != id2 {
+		t.Fatalf("ids = %s %s, want the same cluster", id1, id2)
+	}
+	if want := "user <*> logged in from <*>"; template != want {
+		t.Errorf("template = %q, want %q", template, want)
+	}
+
+	// 다른 서비스의 같은 메시지는 다른 클러스터
+	if id3, _, _ := m.Add("web", "user 1 logged in from web"); id3 == id1 {
+		t.Error("clusters of different services share an id")
+	}
+}
+
+// 최대 클러스터 수에 도달하면 가장 오래 사용하지 않은 클러스터를 제거하고 새 패턴을 받아야 함
+func TestMinerEvictsLeastRecentlySeen(t *testing.T) {
+	m := NewMiner(4, 0.5, 100, 2)
+
+	first, _, _ := m.Add("api", "connection opened")
+	second, _, _ := m.Add("api", "request failed badly")
+	// 첫 번째 클러스터를 다시 사용해 두 번째가 가장 오래된 클러스터가 됨
+	m.Add("api", "connection opened")
+
+	third, _, ok := m.Add("api", "cache miss for key")
+	if !ok {
+		t.Fatal("Add() ok = false after reaching max clusters, want eviction")
+	}
+	if m.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", m.Len())
+	}
+	if _, exists := m.clusters[second]; exists {
+		t.Error("least recently seen cluster was not evicted")
+	}
+	for _, id := range []string{first, third} {
+		if _, exists := m.clusters[id]; !exists {
+			t.Errorf("cluster %s was evicted, want kept", id)
+		}
+	}
+
+	// 제거된 패턴이 다시 나타나면 같은 ID로 다시 만들어짐
+	again, _, _ := m.Add("api", "request failed badly")
+	if again != second {
+		t.Errorf("re-created cluster id = %s, want %s", again, second)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+}
+
+// 복원한 패턴은 나중에 불러온(오래된) 패턴부터 제거되어야 함
+func TestMinerLoadOrder(t *testing.T) {
+	m := NewMiner(4, 0.5, 100, 2)
+	m.Load("newer", "api", "connection opened")
+	m.Load("older", "api", "request failed badly")
+	// 가득 차면 더 불러오지 않음
+	m.Load("ignored", "api", "cache miss for key")
+	if _, exists := m.clusters["ignored"]; exists {
+		t.Fatal("Load() added a cluster beyond max clusters")
+	}
+
+	if id, _, _ := m.Add("api", "connection opened"); id != "newer" {
+		t.Fatalf("id = %s, want loaded cluster newer", id)
+	}
+	m.Add("api", "cache miss for key")
+	if _, exists := m.clusters["older"]; exists {
+		t.Error("older loaded cluster was not evicted first")
+	}
+	if _, exists := m.clusters["newer"]; !exists {
+		t.Error("newer loaded cluster was evicted")
+	}
+}
+
+func TestMinerWithoutClusters(t *testing.T) {
+	m := NewMiner(4, 0.5, 100, 0)
+	if _, _, ok := m.Add("api", "connection opened"); ok {
+		t.Error("Add() ok = true with max clusters 0")
+	}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+)
+
+// 심각도 정렬 순서 (높은 심각도 우선)
+var severityOrder = map[string]int{
+	"FATAL": 1,
+	"ERROR": 2,
+	"WARN":  3,
+	"INFO":  4,
+	"DEBUG": 5,
+	"TRACE": 6,
+}
+
+// GetLogHistogram은 QueryLogs와 같은 필터 조건으로 step(밀리초) 단위 버킷의 심각도별 로그 수를 조회합니다.
+// 버킷은 epoch 기준 step 배수로 정렬되며, 로그가 없는 버킷은 0 값으로 채웁니다.
+func (r *PostgresLogRepository) GetLogHistogram(ctx context.Context, filter domain.LogFilter, step int64) (domain.LogHistogram, error) {
+	startQueryTime := time.Now()
+	result := domain.LogHistogram{
+		Step:       step,
+		StartTime:  filter.StartTime,
+		EndTime:    filter.EndTime,
+		Severities: []string{},
+		Buckets:    []domain.LogHistogramBucket{},
+	}
+
+	whereClause, queryParams := buildLogWhereClause(filter)
+	query := fmt.Sprintf(`
+		SELECT
+			timestamp - (timestamp %% $%d) AS bucket,
+			severity,
+			COUNT(*) AS count
+		FROM logs
+		WHERE %s
+		GROUP BY bucket, severity
+	`, len(queryParams)+1, whereClause)
+	queryParams = append(queryParams, step)
+
+	rows, err := r.db.ReadQueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return result, fmt.Errorf("failed to query log histogram: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]map[string]int64)
+	severities := make(map[string]bool)
+	for rows.Next() {
+		var bucket, count int64
+		var severity string
+		if err := rows.Scan(&bucket, &severity, &count); err != nil {
+			return result, fmt.Errorf("failed to scan log histogram row: %w", err)
+		}
+		if counts[bucket] == nil {
+			counts[bucket] = make(map[string]int64)
+		}
+		counts[bucket][severity] += count
+		severities[severity] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("error iterating log histogram rows: %w", err)
+	}
+
+	for severity := range severities {
+		result.Severities = append(result.Severities, severity)
+	}
+	sortSeverities(result.Severities)
+
+	// 빈 버킷 채우기
+	for bucket := filter.StartTime - filter.StartTime%step; bucket <= filter.EndTime; bucket += step {
+		entry := domain.LogHistogramBucket{
+			Timestamp: bucket,
+			Counts:    make(map[string]int64, len(result.Severities)),
+		}
+		for _, severity := range result.Severities {
+			count := counts[bucket][severity]
+			entry.Counts[severity] = count
+			entry.Total += count
+		}
+		result.Total += entry.Total
+		result.Buckets = append(result.Buckets, entry)
+	}
+
+	result.Took = time.Since(startQueryTime).Milliseconds()
+
+	return result, nil
+}
+
+// sortSeverities는 심각도를 높은 순서로 정렬합니다. 알 수 없는 심각도는 이름순으로 뒤에 둡니다.
+func sortSeverities(severities []string) {
+	rank := func(severity string) int {
+		if order, ok := severityOrder[severity]; ok {
+			return order
+		}
+		return len(severityOrder) + 1
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		ri, rj := rank(severities[i]), rank(severities[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return severities[i] < severities[j]
+	})
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+	"github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+)
+
+// 패턴별로 반환하는 최대 샘플 메시지 수
+const maxPatternSamples = 3
+
+// SaveLogPatterns는 로그 패턴을 저장합니다.
+// 이미 있는 패턴은 템플릿, 샘플을 갱신하고 처음/마지막 발견 시간을 넓힙니다.
+func (r *PostgresLogRepository) SaveLogPatterns(ctx context.Context, patterns []domain.LogPattern) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// 롤백 함수 준비
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			r.log.Error().Err(err).Msg("로그 패턴 저장 트랜잭션 롤백됨")
+		}
+	}()
+
+	for _, pattern := range patterns {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO log_patterns(
+				pattern_id, service_name, template, first_seen, last_seen, sample, tenant_id
+			) VALUES($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (tenant_id, pattern_id) DO UPDATE SET
+				template = EXCLUDED.template,
+				first_seen = LEAST(log_patterns.first_seen, EXCLUDED.first_seen),
+				last_seen = GREATEST(log_patterns.last_seen, EXCLUDED.last_seen),
+				sample = EXCLUDED.sample`,
+			pattern.PatternID,
+			pattern.ServiceName,
+			pattern.Template,
+			pattern.FirstSeen,
+			pattern.LastSeen,
+			pattern.Sample,
+			tenant.OrDefault(pattern.TenantID),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert log pattern: %w", err)
+		}
+	}
+
+	// 트랜잭션 커밋
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecentLogPatterns는 최근에 발견된 순서로 저장된 모든 테넌트의 로그 패턴을 조회합니다.
+func (r *PostgresLogRepository) GetRecentLogPatterns(ctx context.Context, limit int) ([]domain.LogPattern, error) {
+	// 시작할 때 마이너 상태를 복원하는 용도이므로 복제 지연이 없는 주 데이터베이스에서 조회
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pattern_id, tenant_id, service_name, template, first_seen, last_seen, COALESCE(sample, '')
+		FROM log_patterns
+		ORDER BY last_seen DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query log patterns: %w", err)
+	}
+	defer rows.Close()
+
+	patterns := []domain.LogPattern{}
+	for rows.Next() {
+		var pattern domain.LogPattern
+		if err := rows.Scan(
+			&pattern.PatternID,
+			&pattern.TenantID,
+			&pattern.ServiceName,
+			&pattern.Template,
+			&pattern.FirstSeen,
+			&pattern.LastSeen,
+			&pattern.Sample,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan log pattern row: %w", err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating log pattern rows: %w", err)
+	}
+
+	return patterns, nil
+}
+
+// GetLogPatterns는 조회 구간의 패턴별 로그 수와 샘플 메시지를 조회하고,
+// 바로 이전의 같은 길이 구간과 비교한 추세를 계산합니다.
+func (r *PostgresLogRepository) GetLogPatterns(ctx context.Context, filter domain.LogPatternFilter) (domain.LogPatternResult, error) {
+	startQueryTime := time.Now()
+	previousStart := filter.StartTime - (filter.EndTime - filter.StartTime)
+	result := domain.LogPatternResult{
+		Patterns:          []domain.LogPatternStats{},
+		StartTime:         filter.StartTime,
+		EndTime:           filter.EndTime,
+		PreviousStartTime: previousStart,
+	}
+
+	// 쿼리 파라미터 배열 ($1: 시작, $2: 종료, $3: 이전 구간 시작, $4: 테넌트)
+	queryParams := []interface{}{filter.StartTime, filter.EndTime, previousStart, filter.TenantID}
+	paramIndex := 5
+
+	// 공통 필터 조건
+	var conditions string
+
+	// 서비스명 필터
+	if len(filter.ServiceNames) > 0 {
+		placeholders := make([]string, len(filter.ServiceNames))
+		for i := range filter.ServiceNames {
+			placeholders[i] = fmt.Sprintf("$%d", paramIndex)
+			queryParams = append(queryParams, filter.ServiceNames[i])
+			paramIndex++
+		}
+		conditions += fmt.Sprintf(" AND service_name IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	// 심각도 필터
+	if filter.Severity != nil && *filter.Severity != "" {
+		conditions += fmt.Sprintf(" AND severity = $%d", paramIndex)
+		queryParams = append(queryParams, *filter.Severity)
+		paramIndex++
+	}
+
+	query := fmt.Sprintf(`
+		WITH current_window AS (
+			SELECT pattern_id, COUNT(*) AS count
+			FROM logs
+			WHERE tenant_id = $4 AND timestamp >= $1 AND timestamp <= $2 AND pattern_id IS NOT NULL%s
+			GROUP BY pattern_id
+		),
+		previous_window AS (
+			SELECT pattern_id, COUNT(*) AS count
+			FROM logs
+			WHERE tenant_id = $4 AND timestamp >= $3 AND timestamp < $1 AND pattern_id IS NOT NULL%s
+			GROUP BY pattern_id
+		)
+		SELECT
+			p.pattern_id,
+			p.service_name,
+			p.template,
+			c.count,
+			COALESCE(pw.count, 0),
+			p.first_seen,
+			p.last_seen,
+			(
+				SELECT json_agg(s.message)
+				FROM (
+					SELECT message
+					FROM logs l
+					WHERE l.tenant_id = $4 AND l.pattern_id = c.pattern_id AND l.timestamp >= $1 AND l.timestamp <= $2
+					ORDER BY l.timestamp DESC
+					LIMIT %d
+				) s
+			)
+		FROM current_window c
+		JOIN log_patterns p ON p.pattern_id = c.pattern_id AND p.tenant_id = $4
+		LEFT JOIN previous_window pw ON pw.pattern_id = c.pattern_id
+		ORDER BY c.count DESC
+		LIMIT $%d
+	`, conditions, conditions, maxPatternSamples, paramIndex)
+	queryParams = append(queryParams, filter.Limit)
+
+	rows, err := r.db.ReadQueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return result, fmt.Errorf("failed to query log patterns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stats domain.LogPatternStats
+		var samplesJSON sql.NullString
+
+		if err := rows.Scan(
+			&stats.PatternID,
+			&stats.ServiceName,
+			&stats.Template,
+			&stats.Count,
+			&stats.PreviousCount,
+			&stats.FirstSeen,
+			&stats.LastSeen,
+			&samplesJSON,
+		); err != nil {
+			return result, fmt.Errorf("failed to scan log pattern stats row: %w", err)
+		}
+
+		stats.Samples = []string{}
+		if samplesJSON.Valid {
+			if err := json.Unmarshal([]byte(samplesJSON.String), &stats.Samples); err != nil {
+				r.log.Error().Err(err).Msg("failed to parse log pattern samples")
+			}
+		}
+		stats.SetTrend(previousStart)
+
+		result.Patterns = append(result.Patterns, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("error iterating log pattern stats rows: %w", err)
+	}
+
+	result.Total = len(result.Patterns)
+	result.Took = time.Since(startQueryTime).Milliseconds()
+
+	return result, nil
+}
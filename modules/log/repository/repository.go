@@ -1,28 +1,46 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
 	"github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
 )
 
 // LogRepository는 로그 저장소 인터페이스입니다.
+// 조회 메서드는 모두 tenantID(또는 필터의 TenantID) 테넌트의 데이터만 조회합니다.
 type LogRepository interface {
 	// 로그 저장
-	SaveLogs(logs []domain.LogItem) error
+	SaveLogs(ctx context.Context, logs []domain.LogItem) error
 
 	// 로그 쿼리
-	QueryLogs(filter domain.LogFilter) (domain.LogQueryResult, error)
+	QueryLogs(ctx context.Context, filter domain.LogFilter) (domain.LogQueryResult, error)
+
+	// 트레이스 ID로 로그 조회
+	GetLogsByTraceID(ctx context.Context, tenantID, traceID string) ([]domain.LogItem, error)
+
+	// 심각도별 로그 볼륨 히스토그램
+	GetLogHistogram(ctx context.Context, filter domain.LogFilter, step int64) (domain.LogHistogram, error)
+
+	// 로그 패턴 저장 및 조회
+	SaveLogPatterns(ctx context.Context, patterns []domain.LogPattern) error
+	GetRecentLogPatterns(ctx context.Context, limit int) ([]domain.LogPattern, error)
+	GetLogPatterns(ctx context.Context, filter domain.LogPatternFilter) (domain.LogPatternResult, error)
 
 	// 로그 집계
-	GetServiceAggregation(startTime, endTime int64) ([]domain.ServiceAggregation, error)
-	GetSeverityAggregation(startTime, endTime int64) ([]domain.SeverityAggregation, error)
+	GetServiceAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.ServiceAggregation, error)
+	GetSeverityAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.SeverityAggregation, error)
 }
 
+// 트레이스 하나에 대해 조회하는 최대 로그 수
+const maxTraceLogs = 1000
+
 // PostgresLogRepository는 PostgreSQL 로그 저장소 구현체입니다.
 type PostgresLogRepository struct {
 	db  db.Database
@@ -38,12 +56,12 @@ func NewLogRepository(database db.Database) LogRepository {
 }
 
 // SaveLogs는 로그 데이터를 데이터베이스에 저장합니다.
-func (r *PostgresLogRepository) SaveLogs(logs []domain.LogItem) error {
+func (r *PostgresLogRepository) SaveLogs(ctx context.Context, logs []domain.LogItem) error {
 	if len(logs) == 0 {
 		return nil
 	}
 
-	tx, err := r.db.Begin()
+	tx, err := r.db.BeginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -64,18 +82,19 @@ func (r *PostgresLogRepository) SaveLogs(logs []domain.LogItem) error {
 			continue
 		}
 
-		_, err = tx.Exec(
+		_, err = tx.ExecContext(ctx,
 			`INSERT INTO logs(
 				id, timestamp, service_name, message, severity, 
-				trace_id, span_id, attributes
-			) VALUES($1, $2, $3, $4, $5, $6, $7, $8)
-			ON CONFLICT (id) DO UPDATE SET
+				trace_id, span_id, attributes, pattern_id, tenant_id
+			) VALUES($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10)
+			ON CONFLICT (tenant_id, id) DO UPDATE SET
 				service_name = EXCLUDED.service_name,
 				message = EXCLUDED.message,
 				severity = EXCLUDED.severity,
 				trace_id = EXCLUDED.trace_id,
 				span_id = EXCLUDED.span_id,
-				attributes = EXCLUDED.attributes`,
+				attributes = EXCLUDED.attributes,
+				pattern_id = EXCLUDED.pattern_id`,
 			log.ID,
 			log.Timestamp,
 			log.ServiceName,
@@ -84,6 +103,8 @@ func (r *PostgresLogRepository) SaveLogs(logs []domain.LogItem) error {
 			log.TraceID,
 			log.SpanID,
 			attributes,
+			log.PatternID,
+			tenant.OrDefault(log.TenantID),
 		)
 
 		if err != nil {
@@ -101,7 +122,7 @@ func (r *PostgresLogRepository) SaveLogs(logs []domain.LogItem) error {
 }
 
 // QueryLogs는 필터에 따라 로그를 쿼리합니다.
-func (r *PostgresLogRepository) QueryLogs(filter domain.LogFilter) (domain.LogQueryResult, error) {
+func (r *PostgresLogRepository) QueryLogs(ctx context.Context, filter domain.LogFilter) (domain.LogQueryResult, error) {
 	startTime := time.Now()
 	result := domain.LogQueryResult{
 		Logs:       []domain.LogItem{},
@@ -111,65 +132,38 @@ func (r *PostgresLogRepository) QueryLogs(filter domain.LogFilter) (domain.LogQu
 		Took:       0,
 	}
 
-	// 쿼리 파라미터 배열
-	queryParams := []interface{}{filter.StartTime, filter.EndTime}
-	paramIndex := 3
-
-	// 기본 WHERE 조건
-	whereClause := "timestamp >= $1 AND timestamp <= $2"
-
-	// 서비스명 필터
-	if len(filter.ServiceNames) > 0 {
-		placeholders := make([]string, len(filter.ServiceNames))
-		for i := range filter.ServiceNames {
-			placeholders[i] = fmt.Sprintf("$%d", paramIndex)
-			queryParams = append(queryParams, filter.ServiceNames[i])
-			paramIndex++
-		}
-		whereClause += fmt.Sprintf(" AND service_name IN (%s)", strings.Join(placeholders, ", "))
-	}
-
-	// 심각도 필터
-	if filter.Severity != nil && *filter.Severity != "" {
-		whereClause += fmt.Sprintf(" AND severity = $%d", paramIndex)
-		queryParams = append(queryParams, *filter.Severity)
-		paramIndex++
-	}
-
-	// 트레이스 연결 필터
-	if filter.HasTrace {
-		whereClause += " AND trace_id IS NOT NULL AND trace_id != ''"
-	}
-
-	// 검색어 필터
-	if filter.Query != nil && *filter.Query != "" && *filter.Query != "*" {
-		whereClause += fmt.Sprintf(` AND (
-			message ILIKE $%d OR
-			service_name ILIKE $%d
-		)`, paramIndex, paramIndex)
-		queryParams = append(queryParams, "%"+*filter.Query+"%")
-		paramIndex++
-	}
+	// WHERE 절 구성
+	whereClause, queryParams := buildLogWhereClause(filter)
+	paramIndex := len(queryParams) + 1
 
-	// 1. 로그 조회 쿼리
+	// 1. 로그 조회 쿼리 (연결된 스팬의 이름과 서비스를 함께 조회)
 	logsQuery := fmt.Sprintf(`
 		SELECT 
-			id,
-			timestamp,
-			service_name AS "serviceName",
-			message,
-			severity,
-			trace_id AS "traceId",
-			span_id AS "spanId",
-			attributes
-		FROM 
-			logs
-		WHERE 
-			%s
+			l.id,
+			l.timestamp,
+			l.service_name AS "serviceName",
+			l.message,
+			l.severity,
+			l.trace_id AS "traceId",
+			l.span_id AS "spanId",
+			l.attributes,
+			l.pattern_id AS "patternId",
+			t.name AS "spanName",
+			t.service_name AS "spanServiceName"
+		FROM (
+			SELECT *
+			FROM 
+				logs
+			WHERE 
+				%s
+			ORDER BY 
+				timestamp DESC
+			LIMIT $%d
+			OFFSET $%d
+		) l
+		LEFT JOIN traces t ON t.tenant_id = l.tenant_id AND t.trace_id = l.trace_id AND t.span_id = l.span_id
 		ORDER BY 
-			timestamp DESC
-		LIMIT $%d
-		OFFSET $%d
+			l.timestamp DESC
 	`, whereClause, paramIndex, paramIndex+1)
 
 	queryParams = append(queryParams, filter.Limit, filter.Offset)
@@ -221,40 +215,22 @@ func (r *PostgresLogRepository) QueryLogs(filter domain.LogFilter) (domain.LogQu
 	`, whereClause)
 
 	// 로그 조회
-	logsRows, err := r.db.Query(logsQuery, queryParams...)
+	logsRows, err := r.db.ReadQueryContext(ctx, logsQuery, queryParams...)
 	if err != nil {
 		return result, fmt.Errorf("failed to query logs: %w", err)
 	}
 	defer logsRows.Close()
 
 	for logsRows.Next() {
-		var log domain.LogItem
-		var attributesJSON string
-
-		err := logsRows.Scan(
-			&log.ID,
-			&log.Timestamp,
-			&log.ServiceName,
-			&log.Message,
-			&log.Severity,
-			&log.TraceID,
-			&log.SpanID,
-			&attributesJSON,
-		)
+		log, err := r.scanLogWithSpan(logsRows)
 		if err != nil {
-			return result, fmt.Errorf("failed to scan log row: %w", err)
-		}
-
-		if err := log.JSONToAttributes(attributesJSON); err != nil {
-			r.log.Error().Err(err).Msg("failed to parse log attributes")
-			log.Attributes = make(map[string]interface{})
+			return result, err
 		}
-
 		result.Logs = append(result.Logs, log)
 	}
 
 	// 서비스 집계
-	servicesRows, err := r.db.Query(servicesQuery, queryParams[:paramIndex-1]...)
+	servicesRows, err := r.db.ReadQueryContext(ctx, servicesQuery, queryParams[:paramIndex-1]...)
 	if err != nil {
 		return result, fmt.Errorf("failed to query service aggregation: %w", err)
 	}
@@ -269,7 +245,7 @@ func (r *PostgresLogRepository) QueryLogs(filter domain.LogFilter) (domain.LogQu
 	}
 
 	// 심각도 집계
-	severitiesRows, err := r.db.Query(severitiesQuery, queryParams[:paramIndex-1]...)
+	severitiesRows, err := r.db.ReadQueryContext(ctx, severitiesQuery, queryParams[:paramIndex-1]...)
 	if err != nil {
 		return result, fmt.Errorf("failed to query severity aggregation: %w", err)
 	}
@@ -285,7 +261,7 @@ func (r *PostgresLogRepository) QueryLogs(filter domain.LogFilter) (domain.LogQu
 
 	// 총 개수 카운트
 	var total int
-	err = r.db.QueryRow(countQuery, queryParams[:paramIndex-1]...).Scan(&total)
+	err = r.db.ReadQueryRowContext(ctx, countQuery, queryParams[:paramIndex-1]...).Scan(&total)
 	if err != nil {
 		return result, fmt.Errorf("failed to count logs: %w", err)
 	}
@@ -297,20 +273,158 @@ func (r *PostgresLogRepository) QueryLogs(filter domain.LogFilter) (domain.LogQu
 	return result, nil
 }
 
+// buildLogWhereClause는 로그 필터로부터 WHERE 절과 쿼리 파라미터를 구성합니다.
+// QueryLogs와 GetLogHistogram이 같은 필터 조건을 사용하도록 공유합니다.
+func buildLogWhereClause(filter domain.LogFilter) (string, []interface{}) {
+	// 쿼리 파라미터 배열
+	queryParams := []interface{}{filter.TenantID, filter.StartTime, filter.EndTime}
+	paramIndex := 4
+
+	// 기본 WHERE 조건
+	whereClause := "tenant_id = $1 AND timestamp >= $2 AND timestamp <= $3"
+
+	// 서비스명 필터
+	if len(filter.ServiceNames) > 0 {
+		placeholders := make([]string, len(filter.ServiceNames))
+		for i := range filter.ServiceNames {
+			placeholders[i] = fmt.Sprintf("$%d", paramIndex)
+			queryParams = append(queryParams, filter.ServiceNames[i])
+			paramIndex++
+		}
+		whereClause += fmt.Sprintf(" AND service_name IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	// 심각도 필터
+	if filter.Severity != nil && *filter.Severity != "" {
+		whereClause += fmt.Sprintf(" AND severity = $%d", paramIndex)
+		queryParams = append(queryParams, *filter.Severity)
+		paramIndex++
+	}
+
+	// 트레이스 연결 필터
+	if filter.HasTrace {
+		whereClause += " AND trace_id IS NOT NULL AND trace_id != ''"
+	}
+
+	// 트레이스 ID / 스팬 ID 필터 (인덱스가 있는 컬럼에 대한 정확한 일치)
+	if filter.TraceID != "" {
+		whereClause += fmt.Sprintf(" AND trace_id = $%d", paramIndex)
+		queryParams = append(queryParams, filter.TraceID)
+		paramIndex++
+	}
+	if filter.SpanID != "" {
+		whereClause += fmt.Sprintf(" AND span_id = $%d", paramIndex)
+		queryParams = append(queryParams, filter.SpanID)
+		paramIndex++
+	}
+
+	// 검색어 필터
+	if filter.Query != nil && *filter.Query != "" && *filter.Query != "*" {
+		whereClause += fmt.Sprintf(` AND (
+			message ILIKE $%d OR
+			service_name ILIKE $%d
+		)`, paramIndex, paramIndex)
+		queryParams = append(queryParams, "%"+*filter.Query+"%")
+	}
+
+	return whereClause, queryParams
+}
+
+// GetLogsByTraceID는 트레이스에 연결된 로그를 시간 순으로 조회합니다.
+// trace_id 인덱스를 사용하며 최대 maxTraceLogs개까지 반환합니다.
+func (r *PostgresLogRepository) GetLogsByTraceID(ctx context.Context, tenantID, traceID string) ([]domain.LogItem, error) {
+	query := `
+		SELECT 
+			l.id,
+			l.timestamp,
+			l.service_name,
+			l.message,
+			l.severity,
+			l.trace_id,
+			l.span_id,
+			l.attributes,
+			l.pattern_id,
+			t.name,
+			t.service_name
+		FROM logs l
+		LEFT JOIN traces t ON t.tenant_id = l.tenant_id AND t.trace_id = l.trace_id AND t.span_id = l.span_id
+		WHERE l.trace_id = $1 AND l.tenant_id = $3
+		ORDER BY l.timestamp ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.ReadQueryContext(ctx, query, traceID, maxTraceLogs, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs by trace ID: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []domain.LogItem{}
+	for rows.Next() {
+		log, err := r.scanLogWithSpan(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating log rows: %w", err)
+	}
+
+	return logs, nil
+}
+
+// scanLogWithSpan은 로그 컬럼(패턴 ID 포함)과 연결된 스팬의 이름/서비스 컬럼으로 구성된 행을 읽습니다.
+func (r *PostgresLogRepository) scanLogWithSpan(rows *sql.Rows) (domain.LogItem, error) {
+	var log domain.LogItem
+	var attributesJSON string
+	var traceID, spanID, patternID, spanName, spanServiceName sql.NullString
+
+	if err := rows.Scan(
+		&log.ID,
+		&log.Timestamp,
+		&log.ServiceName,
+		&log.Message,
+		&log.Severity,
+		&traceID,
+		&spanID,
+		&attributesJSON,
+		&patternID,
+		&spanName,
+		&spanServiceName,
+	); err != nil {
+		return log, fmt.Errorf("failed to scan log row: %w", err)
+	}
+
+	log.TraceID = traceID.String
+	log.SpanID = spanID.String
+	log.PatternID = patternID.String
+	log.SpanName = spanName.String
+	log.SpanServiceName = spanServiceName.String
+
+	if err := log.JSONToAttributes(attributesJSON); err != nil {
+		r.log.Error().Err(err).Msg("failed to parse log attributes")
+		log.Attributes = make(map[string]interface{})
+	}
+
+	return log, nil
+}
+
 // GetServiceAggregation은 서비스 이름 집계를 가져옵니다.
-func (r *PostgresLogRepository) GetServiceAggregation(startTime, endTime int64) ([]domain.ServiceAggregation, error) {
+func (r *PostgresLogRepository) GetServiceAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.ServiceAggregation, error) {
 	query := `
 		SELECT 
 			service_name as name,
 			COUNT(*) as count
 		FROM logs
-		WHERE timestamp >= $1 AND timestamp <= $2
+		WHERE timestamp >= $1 AND timestamp <= $2 AND tenant_id = $3
 		GROUP BY service_name
 		ORDER BY count DESC
 		LIMIT 20
 	`
 
-	rows, err := r.db.Query(query, startTime, endTime)
+	rows, err := r.db.ReadQueryContext(ctx, query, startTime, endTime, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query service aggregation: %w", err)
 	}
@@ -333,13 +447,13 @@ func (r *PostgresLogRepository) GetServiceAggregation(startTime, endTime int64)
 }
 
 // GetSeverityAggregation은 심각도 수준 집계를 가져옵니다.
-func (r *PostgresLogRepository) GetSeverityAggregation(startTime, endTime int64) ([]domain.SeverityAggregation, error) {
+func (r *PostgresLogRepository) GetSeverityAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.SeverityAggregation, error) {
 	query := `
 		SELECT 
 			severity as name,
 			COUNT(*) as count
 		FROM logs
-		WHERE timestamp >= $1 AND timestamp <= $2
+		WHERE timestamp >= $1 AND timestamp <= $2 AND tenant_id = $3
 		GROUP BY severity
 		ORDER BY 
 			CASE 
@@ -353,7 +467,7 @@ func (r *PostgresLogRepository) GetSeverityAggregation(startTime, endTime int64)
 			END
 	`
 
-	rows, err := r.db.Query(query, startTime, endTime)
+	rows, err := r.db.ReadQueryContext(ctx, query, startTime, endTime, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query severity aggregation: %w", err)
 	}
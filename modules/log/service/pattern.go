@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
+	"github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+)
+
+// assignPatterns는 로그 메시지를 패턴 마이너에 넣어 각 로그에 패턴 ID를 할당하고,
+// 배치에서 발견된 패턴을 저장합니다. 패턴 저장에 실패해도 로그 저장은 계속 진행합니다.
+func (s *LogServiceImpl) assignPatterns(ctx context.Context, logs []domain.LogItem) {
+	if s.miner == nil || len(logs) == 0 {
+		return
+	}
+
+	// 재시작 후에도 같은 패턴 ID를 쓰도록 처음 한 번 저장된 패턴을 불러옵니다
+	s.patternOnce.Do(func() { s.loadPatterns(ctx) })
+
+	patterns := make(map[string]*domain.LogPattern)
+	order := []string{}
+	for i := range logs {
+		log := &logs[i]
+		tenantID := tenant.OrDefault(log.TenantID)
+		id, template, ok := s.miner.Add(patternScope(tenantID, log.ServiceName), log.Message)
+		if !ok {
+			continue
+		}
+		log.PatternID = id
+
+		p, exists := patterns[id]
+		if !exists {
+			p = &domain.LogPattern{
+				PatternID:   id,
+				TenantID:    tenantID,
+				ServiceName: log.ServiceName,
+				FirstSeen:   log.Timestamp,
+				LastSeen:    log.Timestamp,
+			}
+			patterns[id] = p
+			order = append(order, id)
+		}
+		// 배치 안에서 템플릿이 일반화될 수 있으므로 마지막 템플릿을 사용합니다
+		p.Template = template
+		if log.Timestamp < p.FirstSeen {
+			p.FirstSeen = log.Timestamp
+		}
+		if log.Timestamp >= p.LastSeen {
+			p.LastSeen = log.Timestamp
+			p.Sample = log.Message
+		}
+	}
+
+	batch := make([]domain.LogPattern, 0, len(order))
+	for _, id := range order {
+		batch = append(batch, *patterns[id])
+	}
+
+	if err := s.repository.SaveLogPatterns(ctx, batch); err != nil {
+		s.log.Error().Err(err).Int("patterns", len(batch)).Msg("로그 패턴 저장 실패")
+	}
+}
+
+// loadPatterns는 저장된 최근 패턴으로 패턴 마이너를 복원합니다.
+// 새 패턴을 만들 여유를 남기기 위해 최대 패턴 수의 절반까지만 불러옵니다.
+func (s *LogServiceImpl) loadPatterns(ctx context.Context) {
+	patterns, err := s.repository.GetRecentLogPatterns(ctx, config.GetConfig().LogPattern.MaxClusters/2)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("저장된 로그 패턴을 불러오지 못했습니다")
+		return
+	}
+
+	for _, p := range patterns {
+		s.miner.Load(p.PatternID, patternScope(tenant.OrDefault(p.TenantID), p.ServiceName), p.Template)
+	}
+	s.log.Info().Int("patterns", len(patterns)).Msg("로그 패턴 복원 완료")
+}
+
+// patternScope는 패턴 마이너에서 패턴을 구분하는 범위입니다.
+// 테넌트마다 패턴과 패턴 ID가 분리되도록 기본 테넌트가 아니면 테넌트 ID를 앞에 붙입니다.
+// 서비스 이름에 들어갈 수 있는 문자로 구분하면 다른 테넌트의 서비스와 범위가 겹칠 수 있으므로 NUL로 구분합니다.
+func patternScope(tenantID, serviceName string) string {
+	if tenantID == tenant.Default {
+		return serviceName
+	}
+	return tenantID + "\x00" + serviceName
+}
@@ -0,0 +1,26 @@
+package service
+
+import "testing"
+
+// 테넌트와 서비스 이름을 합친 범위가 다른 테넌트, 서비스 조합과 겹치지 않아야 함
+func TestPatternScope(t *testing.T) {
+	scopes := map[string][2]string{}
+	for _, pair := range [][2]string{
+		{"default", "acme/checkout"},
+		{"acme", "checkout"},
+		{"acme", "web/checkout"},
+		{"acme/web", "checkout"},
+		{"default", "checkout"},
+	} {
+		scope := patternScope(pair[0], pair[1])
+		if other, exists := scopes[scope]; exists {
+			t.Errorf("patternScope(%q, %q) = patternScope(%q, %q)", pair[0], pair[1], other[0], other[1])
+		}
+		scopes[scope] = pair
+	}
+
+	// 기본 테넌트는 기존 패턴 ID를 유지하도록 서비스 이름만 사용
+	if got := patternScope("default", "checkout"); got != "checkout" {
+		t.Errorf("patternScope(default, checkout) = %q, want checkout", got)
+	}
+}
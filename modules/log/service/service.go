@@ -1,45 +1,76 @@
 package service
 
 import (
+	"context"
+	"sync"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
 	"github.com/seongpil0948/otel-kafka-pg/modules/log/domain"
+	"github.com/seongpil0948/otel-kafka-pg/modules/log/pattern"
 	"github.com/seongpil0948/otel-kafka-pg/modules/log/repository"
 )
 
 // LogService는 로그 서비스 인터페이스입니다.
 type LogService interface {
 	// 로그 저장
-	SaveLogs(logs []domain.LogItem) error
+	SaveLogs(ctx context.Context, logs []domain.LogItem) error
 	
 	// 로그 쿼리
-	QueryLogs(filter domain.LogFilter) (domain.LogQueryResult, error)
+	QueryLogs(ctx context.Context, filter domain.LogFilter) (domain.LogQueryResult, error)
+	
+	// 트레이스 ID로 로그 조회
+	GetLogsByTraceID(ctx context.Context, tenantID, traceID string) ([]domain.LogItem, error)
+	
+	// 심각도별 로그 볼륨 히스토그램
+	GetLogHistogram(ctx context.Context, filter domain.LogFilter, step int64) (domain.LogHistogram, error)
+	
+	// 로그 패턴 조회
+	GetLogPatterns(ctx context.Context, filter domain.LogPatternFilter) (domain.LogPatternResult, error)
 	
 	// 로그 집계
-	GetServiceAggregation(startTime, endTime int64) ([]domain.ServiceAggregation, error)
-	GetSeverityAggregation(startTime, endTime int64) ([]domain.SeverityAggregation, error)
+	GetServiceAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.ServiceAggregation, error)
+	GetSeverityAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.SeverityAggregation, error)
 }
 
 // LogServiceImpl은 로그 서비스 구현체입니다.
 type LogServiceImpl struct {
 	repository repository.LogRepository
 	log        logger.Logger
+
+	// 로그 패턴 마이닝 (비활성화 시 nil)
+	miner       *pattern.Miner
+	patternOnce sync.Once
 }
 
 // NewLogService는 새 로그 서비스 인스턴스를 생성합니다.
 func NewLogService(repo repository.LogRepository) LogService {
-	return &LogServiceImpl{
+	s := &LogServiceImpl{
 		repository: repo,
 		log:        logger.GetLogger(),
 	}
+
+	cfg := config.GetConfig()
+	if cfg.LogPattern.Enabled {
+		s.miner = pattern.NewMiner(
+			cfg.LogPattern.Depth,
+			cfg.LogPattern.SimThreshold,
+			cfg.LogPattern.MaxChildren,
+			cfg.LogPattern.MaxClusters,
+		)
+	}
+
+	return s
 }
 
-// SaveLogs는 로그를 저장합니다.
-func (s *LogServiceImpl) SaveLogs(logs []domain.LogItem) error {
-	return s.repository.SaveLogs(logs)
+// SaveLogs는 로그에 패턴 ID를 할당한 뒤 저장합니다.
+func (s *LogServiceImpl) SaveLogs(ctx context.Context, logs []domain.LogItem) error {
+	s.assignPatterns(ctx, logs)
+	return s.repository.SaveLogs(ctx, logs)
 }
 
 // QueryLogs는 로그를 쿼리합니다.
-func (s *LogServiceImpl) QueryLogs(filter domain.LogFilter) (domain.LogQueryResult, error) {
+func (s *LogServiceImpl) QueryLogs(ctx context.Context, filter domain.LogFilter) (domain.LogQueryResult, error) {
 	// 기본값 설정
 	if filter.Limit <= 0 {
 		filter.Limit = 20
@@ -50,15 +81,36 @@ func (s *LogServiceImpl) QueryLogs(filter domain.LogFilter) (domain.LogQueryResu
 		filter.Limit = 100
 	}
 	
-	return s.repository.QueryLogs(filter)
+	return s.repository.QueryLogs(ctx, filter)
+}
+
+// GetLogsByTraceID는 트레이스에 연결된 로그를 시간 순으로 가져옵니다.
+func (s *LogServiceImpl) GetLogsByTraceID(ctx context.Context, tenantID, traceID string) ([]domain.LogItem, error) {
+	return s.repository.GetLogsByTraceID(ctx, tenantID, traceID)
+}
+
+// GetLogHistogram은 필터 조건에 맞는 로그의 버킷별, 심각도별 개수를 가져옵니다.
+func (s *LogServiceImpl) GetLogHistogram(ctx context.Context, filter domain.LogFilter, step int64) (domain.LogHistogram, error) {
+	return s.repository.GetLogHistogram(ctx, filter, step)
+}
+
+// GetLogPatterns는 구간 내 로그 패턴별 개수, 샘플과 이전 구간 대비 추세를 가져옵니다.
+func (s *LogServiceImpl) GetLogPatterns(ctx context.Context, filter domain.LogPatternFilter) (domain.LogPatternResult, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+	return s.repository.GetLogPatterns(ctx, filter)
 }
 
 // GetServiceAggregation은 서비스 이름 집계를 가져옵니다.
-func (s *LogServiceImpl) GetServiceAggregation(startTime, endTime int64) ([]domain.ServiceAggregation, error) {
-	return s.repository.GetServiceAggregation(startTime, endTime)
+func (s *LogServiceImpl) GetServiceAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.ServiceAggregation, error) {
+	return s.repository.GetServiceAggregation(ctx, tenantID, startTime, endTime)
 }
 
 // GetSeverityAggregation은 심각도 수준 집계를 가져옵니다.
-func (s *LogServiceImpl) GetSeverityAggregation(startTime, endTime int64) ([]domain.SeverityAggregation, error) {
-	return s.repository.GetSeverityAggregation(startTime, endTime)
+func (s *LogServiceImpl) GetSeverityAggregation(ctx context.Context, tenantID string, startTime, endTime int64) ([]domain.SeverityAggregation, error) {
+	return s.repository.GetSeverityAggregation(ctx, tenantID, startTime, endTime)
 }
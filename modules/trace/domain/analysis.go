@@ -0,0 +1,394 @@
+package domain
+
+import (
+	"sort"
+)
+
+// 스팬 이상 징후 유형
+const (
+	AnomalyClockSkew        = "CLOCK_SKEW"        // 자식 스팬이 부모 스팬보다 먼저 시작함
+	AnomalyExceedsParent    = "EXCEEDS_PARENT"    // 자식 스팬이 부모 스팬보다 늦게 끝남 (비동기 호출 또는 시계 오차)
+	AnomalyNegativeDuration = "NEGATIVE_DURATION" // 종료 시간이 시작 시간보다 이름
+	AnomalyCycle            = "CYCLE"             // 부모 관계가 순환하여 어느 루트에서도 닿을 수 없음
+)
+
+// SpanNode는 스팬 트리의 노드를 정의합니다.
+type SpanNode struct {
+	Span
+	Depth          int         `json:"depth"`
+	SelfTime       float64     `json:"selfTime"`
+	OnCriticalPath bool        `json:"onCriticalPath"`
+	Orphan         bool        `json:"orphan,omitempty"`
+	Cycle          bool        `json:"cycle,omitempty"`
+	Children       []*SpanNode `json:"children,omitempty"`
+}
+
+// CriticalPathSegment는 임계 경로를 구성하는 구간을 정의합니다.
+type CriticalPathSegment struct {
+	SpanID      string  `json:"spanId"`
+	Name        string  `json:"name"`
+	ServiceName string  `json:"serviceName"`
+	StartTime   float64 `json:"startTime"`
+	EndTime     float64 `json:"endTime"`
+	Duration    float64 `json:"duration"`
+}
+
+// SpanAnomaly는 스팬에서 발견된 이상 징후를 정의합니다.
+type SpanAnomaly struct {
+	SpanID       string  `json:"spanId"`
+	ParentSpanID string  `json:"parentSpanId,omitempty"`
+	Type         string  `json:"type"`
+	Delta        float64 `json:"delta"` // 벗어난 시간 (밀리초)
+}
+
+// TraceAnalysis는 단일 트레이스 분석 결과를 정의합니다.
+type TraceAnalysis struct {
+	Roots                []*SpanNode           `json:"roots"`
+	CriticalPath         []CriticalPathSegment `json:"criticalPath"`
+	CriticalPathDuration float64               `json:"criticalPathDuration"`
+	ServiceSelfTime      map[string]float64    `json:"serviceSelfTime"`
+	Orphans              []string              `json:"orphans"`
+	Anomalies            []SpanAnomaly         `json:"anomalies"`
+}
+
+// interval은 밀리초 단위 시간 구간입니다.
+type interval struct {
+	start float64
+	end   float64
+}
+
+// spanInterval은 스팬의 시작/종료 구간을 반환합니다.
+// StartTime은 밀리초 단위로 잘려 있으므로 종료 시점은 Duration으로 계산합니다.
+func spanInterval(s *Span) interval {
+	start := float64(s.StartTime)
+	return interval{start: start, end: start + s.Duration}
+}
+
+// BuildSpanTree는 스팬 목록을 parent_span_id 기준의 트리로 구성합니다.
+// 부모 스팬을 찾을 수 없는 스팬(고아 스팬)은 루트로 취급하며 Orphan으로 표시합니다.
+// 부모 관계가 순환하는 스팬은 순환을 끊어 루트로 붙이고 Cycle로 표시합니다.
+func BuildSpanTree(spans []Span) (roots []*SpanNode, orphans []string) {
+	nodes := make(map[string]*SpanNode, len(spans))
+	ordered := make([]*SpanNode, 0, len(spans))
+	for i := range spans {
+		node := &SpanNode{Span: spans[i]}
+		nodes[node.SpanID] = node
+		ordered = append(ordered, node)
+	}
+
+	roots = []*SpanNode{}
+	orphans = []string{}
+	for _, node := range ordered {
+		if node.ParentSpanID == "" || node.ParentSpanID == node.SpanID {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[node.ParentSpanID]
+		if !ok {
+			node.Orphan = true
+			roots = append(roots, node)
+			orphans = append(orphans, node.SpanID)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	roots = append(roots, breakCycles(roots, ordered, nodes)...)
+
+	// 자식 스팬은 시작 시간 순으로 정렬
+	for _, node := range ordered {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].StartTime < node.Children[j].StartTime
+		})
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].StartTime < roots[j].StartTime
+	})
+
+	for _, root := range roots {
+		setDepth(root, 0)
+	}
+
+	return roots, orphans
+}
+
+// breakCycles는 어느 루트에서도 닿을 수 없는 스팬, 즉 부모 관계가 순환하는 스팬을 찾아
+// 순환마다 스팬 하나를 부모에서 떼어 내고 Cycle로 표시한 새 루트로 반환합니다.
+func breakCycles(roots, ordered []*SpanNode, nodes map[string]*SpanNode) []*SpanNode {
+	visited := make(map[*SpanNode]bool, len(ordered))
+	var visit func(node *SpanNode)
+	visit = func(node *SpanNode) {
+		visited[node] = true
+		for _, child := range node.Children {
+			if !visited[child] {
+				visit(child)
+			}
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+
+	var cycleRoots []*SpanNode
+	for _, node := range ordered {
+		if visited[node] {
+			continue
+		}
+
+		// 부모를 따라 올라가 처음으로 다시 만나는 스팬이 순환 위의 스팬
+		seen := map[*SpanNode]bool{}
+		current := node
+		for !seen[current] {
+			seen[current] = true
+			current = nodes[current.ParentSpanID]
+		}
+
+		parent := nodes[current.ParentSpanID]
+		for i, child := range parent.Children {
+			if child == current {
+				parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+				break
+			}
+		}
+		current.Cycle = true
+		cycleRoots = append(cycleRoots, current)
+		visit(current)
+	}
+	return cycleRoots
+}
+
+// setDepth는 노드와 하위 노드의 깊이를 설정합니다.
+func setDepth(node *SpanNode, depth int) {
+	node.Depth = depth
+	for _, child := range node.Children {
+		setDepth(child, depth+1)
+	}
+}
+
+// AnalyzeTrace는 트레이스의 스팬 트리를 구성하고 셀프 타임, 임계 경로, 고아 스팬,
+// 시계 오차 등의 이상 징후를 계산합니다.
+func AnalyzeTrace(trace *Trace) *TraceAnalysis {
+	analysis := &TraceAnalysis{
+		Roots:           []*SpanNode{},
+		CriticalPath:    []CriticalPathSegment{},
+		ServiceSelfTime: map[string]float64{},
+		Orphans:         []string{},
+		Anomalies:       []SpanAnomaly{},
+	}
+	if trace == nil || len(trace.Spans) == 0 {
+		return analysis
+	}
+
+	analysis.Roots, analysis.Orphans = BuildSpanTree(trace.Spans)
+
+	// 셀프 타임 및 이상 징후 계산
+	for _, root := range analysis.Roots {
+		walkSpanTree(root, func(node *SpanNode) {
+			node.SelfTime = selfTime(node)
+			analysis.ServiceSelfTime[node.ServiceName] += node.SelfTime
+			analysis.Anomalies = append(analysis.Anomalies, detectAnomalies(node)...)
+		})
+		if root.Cycle {
+			analysis.Anomalies = append(analysis.Anomalies, SpanAnomaly{
+				SpanID:       root.SpanID,
+				ParentSpanID: root.ParentSpanID,
+				Type:         AnomalyCycle,
+			})
+		}
+	}
+
+	// 임계 경로는 가장 긴 루트 스팬(고아가 아닌 스팬 우선)을 기준으로 계산
+	mainRoot := selectMainRoot(analysis.Roots)
+	mainInterval := spanInterval(&mainRoot.Span)
+	segments := criticalPath(mainRoot, mainInterval.start, mainInterval.end)
+
+	// 수집된 구간은 역순이므로 시간 순서로 뒤집기
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	analysis.CriticalPath = mergeSegments(segments)
+	for _, segment := range analysis.CriticalPath {
+		analysis.CriticalPathDuration += segment.Duration
+	}
+
+	return analysis
+}
+
+// walkSpanTree는 트리를 전위 순회하며 fn을 호출합니다.
+func walkSpanTree(node *SpanNode, fn func(*SpanNode)) {
+	fn(node)
+	for _, child := range node.Children {
+		walkSpanTree(child, fn)
+	}
+}
+
+// selfTime은 스팬 지속 시간에서 자식 스팬이 겹치는 구간을 뺀 값을 반환합니다.
+// 자식 구간은 부모 구간으로 잘라낸 뒤 합집합으로 계산하여 병렬 호출이 중복 차감되지 않도록 합니다.
+func selfTime(node *SpanNode) float64 {
+	parent := spanInterval(&node.Span)
+	if parent.end <= parent.start {
+		return 0
+	}
+
+	clipped := make([]interval, 0, len(node.Children))
+	for _, child := range node.Children {
+		ci := spanInterval(&child.Span)
+		if ci.start < parent.start {
+			ci.start = parent.start
+		}
+		if ci.end > parent.end {
+			ci.end = parent.end
+		}
+		if ci.end > ci.start {
+			clipped = append(clipped, ci)
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i].start < clipped[j].start })
+
+	var covered float64
+	var current *interval
+	for i := range clipped {
+		ci := clipped[i]
+		if current == nil || ci.start > current.end {
+			if current != nil {
+				covered += current.end - current.start
+			}
+			current = &ci
+			continue
+		}
+		if ci.end > current.end {
+			current.end = ci.end
+		}
+	}
+	if current != nil {
+		covered += current.end - current.start
+	}
+
+	self := (parent.end - parent.start) - covered
+	if self < 0 {
+		return 0
+	}
+	return self
+}
+
+// detectAnomalies는 스팬과 부모 스팬 간의 시간 관계를 검사합니다.
+func detectAnomalies(node *SpanNode) []SpanAnomaly {
+	var anomalies []SpanAnomaly
+	if node.Duration < 0 || node.EndTime < node.StartTime {
+		anomalies = append(anomalies, SpanAnomaly{
+			SpanID:       node.SpanID,
+			ParentSpanID: node.ParentSpanID,
+			Type:         AnomalyNegativeDuration,
+			Delta:        float64(node.StartTime - node.EndTime),
+		})
+	}
+
+	parent := spanInterval(&node.Span)
+	for _, child := range node.Children {
+		ci := spanInterval(&child.Span)
+		if ci.start < parent.start {
+			anomalies = append(anomalies, SpanAnomaly{
+				SpanID:       child.SpanID,
+				ParentSpanID: node.SpanID,
+				Type:         AnomalyClockSkew,
+				Delta:        parent.start - ci.start,
+			})
+		}
+		if ci.end > parent.end {
+			anomalies = append(anomalies, SpanAnomaly{
+				SpanID:       child.SpanID,
+				ParentSpanID: node.SpanID,
+				Type:         AnomalyExceedsParent,
+				Delta:        ci.end - parent.end,
+			})
+		}
+	}
+	return anomalies
+}
+
+// selectMainRoot는 임계 경로 계산의 기준이 될 루트 스팬을 선택합니다.
+func selectMainRoot(roots []*SpanNode) *SpanNode {
+	var main *SpanNode
+	for _, root := range roots {
+		if main == nil ||
+			(main.Orphan && !root.Orphan) ||
+			(main.Orphan == root.Orphan && root.Duration > main.Duration) {
+			main = root
+		}
+	}
+	return main
+}
+
+// criticalPath는 [floor, cursor] 구간 안에서 node의 임계 경로 구간을 역순으로 반환합니다.
+// 가장 늦게 끝난 자식부터 거슬러 올라가며, 자식이 실행되지 않은 구간은 node 자신의 구간이 됩니다.
+// 시계 오차로 자식이 부모 범위를 벗어나더라도 구간이 겹치지 않도록 부모 범위로 잘라냅니다.
+func criticalPath(node *SpanNode, floor, cursor float64) []CriticalPathSegment {
+	node.OnCriticalPath = true
+	own := spanInterval(&node.Span)
+	if own.start < floor {
+		own.start = floor
+	}
+	if cursor > own.end {
+		cursor = own.end
+	}
+
+	children := make([]*SpanNode, len(node.Children))
+	copy(children, node.Children)
+	sort.SliceStable(children, func(i, j int) bool {
+		return spanInterval(&children[i].Span).end > spanInterval(&children[j].Span).end
+	})
+
+	var segments []CriticalPathSegment
+	for _, child := range children {
+		ci := spanInterval(&child.Span)
+		if ci.start >= cursor || ci.end <= own.start {
+			continue
+		}
+
+		childEnd := ci.end
+		if childEnd > cursor {
+			childEnd = cursor
+		}
+		if cursor > childEnd {
+			segments = append(segments, newSegment(node, childEnd, cursor))
+		}
+
+		segments = append(segments, criticalPath(child, own.start, childEnd)...)
+
+		cursor = ci.start
+		if cursor < own.start {
+			cursor = own.start
+		}
+	}
+
+	if cursor > own.start {
+		segments = append(segments, newSegment(node, own.start, cursor))
+	}
+	return segments
+}
+
+// newSegment는 임계 경로 구간을 생성합니다.
+func newSegment(node *SpanNode, start, end float64) CriticalPathSegment {
+	return CriticalPathSegment{
+		SpanID:      node.SpanID,
+		Name:        node.Name,
+		ServiceName: node.ServiceName,
+		StartTime:   start,
+		EndTime:     end,
+		Duration:    end - start,
+	}
+}
+
+// mergeSegments는 같은 스팬의 연속된 구간을 하나로 합칩니다.
+func mergeSegments(segments []CriticalPathSegment) []CriticalPathSegment {
+	merged := []CriticalPathSegment{}
+	for _, segment := range segments {
+		if n := len(merged); n > 0 && merged[n-1].SpanID == segment.SpanID && merged[n-1].EndTime == segment.StartTime {
+			merged[n-1].EndTime = segment.EndTime
+			merged[n-1].Duration += segment.Duration
+			continue
+		}
+		merged = append(merged, segment)
+	}
+	return merged
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func testSpan(id, parent string, start int64, duration float64) Span {
+	return Span{SpanID: id, ParentSpanID: parent, Name: id, ServiceName: "api", StartTime: start, EndTime: start + int64(duration), Duration: duration}
+}
+
+// spanIDs는 트리를 전위 순회한 스팬 ID 목록을 반환합니다.
+func spanIDs(roots []*SpanNode) []string {
+	ids := []string{}
+	for _, root := range roots {
+		walkSpanTree(root, func(node *SpanNode) { ids = append(ids, node.SpanID) })
+	}
+	return ids
+}
+
+func TestBuildSpanTreeCycles(t *testing.T) {
+	tests := []struct {
+		name       string
+		spans      []Span
+		wantRoots  []string // 시작 시간 순
+		wantCycles []string
+		wantOrphan []string
+	}{
+		{
+			name:      "tree",
+			spans:     []Span{testSpan("a", "", 0, 100), testSpan("b", "a", 10, 50), testSpan("c", "b", 20, 10)},
+			wantRoots: []string{"a"},
+		},
+		{
+			name:      "self parent is a root",
+			spans:     []Span{testSpan("a", "a", 0, 100)},
+			wantRoots: []string{"a"},
+		},
+		{
+			name:       "two span cycle",
+			spans:      []Span{testSpan("root", "", 0, 100), testSpan("a", "b", 10, 50), testSpan("b", "a", 20, 10)},
+			wantRoots:  []string{"root", "a"},
+			wantCycles: []string{"a"},
+		},
+		{
+			name: "cycle with descendants",
+			spans: []Span{
+				testSpan("d", "c", 40, 5), // 순환 아래의 스팬이 먼저 나와도 순환 위의 스팬을 끊음
+				testSpan("a", "c", 10, 50),
+				testSpan("b", "a", 20, 30),
+				testSpan("c", "b", 30, 20),
+			},
+			wantRoots:  []string{"c"},
+			wantCycles: []string{"c"},
+		},
+		{
+			name: "two cycles and an orphan",
+			spans: []Span{
+				testSpan("a", "b", 0, 10), testSpan("b", "a", 1, 10),
+				testSpan("x", "y", 5, 10), testSpan("y", "x", 6, 10),
+				testSpan("o", "missing", 7, 10),
+			},
+			wantRoots:  []string{"a", "x", "o"},
+			wantCycles: []string{"a", "x"},
+			wantOrphan: []string{"o"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots, orphans := BuildSpanTree(tt.spans)
+
+			var gotRoots, gotCycles []string
+			for _, root := range roots {
+				gotRoots = append(gotRoots, root.SpanID)
+				if root.Cycle {
+					gotCycles = append(gotCycles, root.SpanID)
+				}
+			}
+			if !slices.Equal(gotRoots, tt.wantRoots) {
+				t.Errorf("roots = %v, want %v", gotRoots, tt.wantRoots)
+			}
+			if !slices.Equal(gotCycles, tt.wantCycles) {
+				t.Errorf("cycle roots = %v, want %v", gotCycles, tt.wantCycles)
+			}
+			if !slices.Equal(orphans, tt.wantOrphan) {
+				t.Errorf("orphans = %v, want %v", orphans, tt.wantOrphan)
+			}
+
+			// 모든 스팬이 트리에 정확히 한 번씩 나타나야 함
+			ids := spanIDs(roots)
+			want := make([]string, 0, len(tt.spans))
+			for _, span := range tt.spans {
+				want = append(want, span.SpanID)
+			}
+			sort.Strings(ids)
+			sort.Strings(want)
+			if !slices.Equal(ids, want) {
+				t.Errorf("spans in tree = %v, want %v", ids, want)
+			}
+		})
+	}
+}
+
+// 순환이 있어도 분석이 끝나고 CYCLE 이상 징후를 보고해야 함
+func TestAnalyzeTraceReportsCycles(t *testing.T) {
+	trace := &Trace{Spans: []Span{testSpan("root", "", 0, 100), testSpan("a", "b", 10, 50), testSpan("b", "a", 20, 10)}}
+
+	analysis := AnalyzeTrace(trace)
+
+	var cycles []SpanAnomaly
+	for _, anomaly := range analysis.Anomalies {
+		if anomaly.Type == AnomalyCycle {
+			cycles = append(cycles, anomaly)
+		}
+	}
+	if len(cycles) != 1 || cycles[0].SpanID != "a" || cycles[0].ParentSpanID != "b" {
+		t.Errorf("cycle anomalies = %+v, want one for span a with parent b", cycles)
+	}
+	if len(analysis.Orphans) != 0 {
+		t.Errorf("orphans = %v, want none", analysis.Orphans)
+	}
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// 속성 변경 유형
+const (
+	AttributeAdded   = "ADDED"
+	AttributeRemoved = "REMOVED"
+	AttributeChanged = "CHANGED"
+)
+
+// AttributeDiff는 두 스팬 간의 속성 차이를 정의합니다.
+type AttributeDiff struct {
+	Key    string      `json:"key"`
+	Change string      `json:"change"`
+	ValueA interface{} `json:"valueA,omitempty"`
+	ValueB interface{} `json:"valueB,omitempty"`
+}
+
+// SpanDiff는 두 트레이스에서 같은 위치(서비스+오퍼레이션 경로)에 정렬된 스팬 쌍의 차이를 정의합니다.
+type SpanDiff struct {
+	Path           string          `json:"path"`
+	ServiceName    string          `json:"serviceName"`
+	Name           string          `json:"name"`
+	SpanIDA        string          `json:"spanIdA"`
+	SpanIDB        string          `json:"spanIdB"`
+	DurationA      float64         `json:"durationA"`
+	DurationB      float64         `json:"durationB"`
+	DurationDelta  float64         `json:"durationDelta"`
+	DeltaPercent   float64         `json:"deltaPercent"`
+	SelfTimeA      float64         `json:"selfTimeA"`
+	SelfTimeB      float64         `json:"selfTimeB"`
+	SelfTimeDelta  float64         `json:"selfTimeDelta"`
+	StatusA        string          `json:"statusA,omitempty"`
+	StatusB        string          `json:"statusB,omitempty"`
+	AttributeDiffs []AttributeDiff `json:"attributeDiffs,omitempty"`
+}
+
+// SpanSummary는 한쪽 트레이스에만 존재하는 스팬을 정의합니다.
+type SpanSummary struct {
+	Path        string  `json:"path"`
+	SpanID      string  `json:"spanId"`
+	ServiceName string  `json:"serviceName"`
+	Name        string  `json:"name"`
+	Duration    float64 `json:"duration"`
+	Status      string  `json:"status,omitempty"`
+}
+
+// TraceComparison은 두 트레이스 비교 결과를 정의합니다.
+type TraceComparison struct {
+	TraceIDA          string        `json:"traceIdA"`
+	TraceIDB          string        `json:"traceIdB"`
+	RootOperationA    string        `json:"rootOperationA"`
+	RootOperationB    string        `json:"rootOperationB"`
+	SameRootOperation bool          `json:"sameRootOperation"`
+	DurationA         float64       `json:"durationA"`
+	DurationB         float64       `json:"durationB"`
+	DurationDelta     float64       `json:"durationDelta"`
+	SpanCountA        int           `json:"spanCountA"`
+	SpanCountB        int           `json:"spanCountB"`
+	Matched           []SpanDiff    `json:"matched"`
+	Added             []SpanSummary `json:"added"`
+	Removed           []SpanSummary `json:"removed"`
+}
+
+// alignedSpan은 경로 키로 식별된 스팬입니다.
+type alignedSpan struct {
+	path string
+	node *SpanNode
+}
+
+// CompareTraces는 두 트레이스의 스팬 트리를 서비스+오퍼레이션 경로로 정렬하여 비교합니다.
+// 같은 부모 아래 동일한 서비스+오퍼레이션이 여러 번 나오면 시작 시간 순서대로 짝을 짓습니다.
+// A를 기준(보통 빠른 트레이스)으로 B에만 있는 스팬은 Added, A에만 있는 스팬은 Removed로 보고합니다.
+func CompareTraces(a, b *Trace) *TraceComparison {
+	result := &TraceComparison{
+		TraceIDA: a.TraceID,
+		TraceIDB: b.TraceID,
+		Matched:  []SpanDiff{},
+		Added:    []SpanSummary{},
+		Removed:  []SpanSummary{},
+	}
+
+	analysisA := AnalyzeTrace(a)
+	analysisB := AnalyzeTrace(b)
+
+	if root := selectMainRoot(analysisA.Roots); root != nil {
+		result.RootOperationA = operationKey(root)
+		result.DurationA = root.Duration
+	}
+	if root := selectMainRoot(analysisB.Roots); root != nil {
+		result.RootOperationB = operationKey(root)
+		result.DurationB = root.Duration
+	}
+	result.SameRootOperation = result.RootOperationA == result.RootOperationB
+	result.DurationDelta = result.DurationB - result.DurationA
+	result.SpanCountA = len(a.Spans)
+	result.SpanCountB = len(b.Spans)
+
+	spansA := alignSpans(analysisA.Roots)
+	spansB := alignSpans(analysisB.Roots)
+
+	indexB := make(map[string]*SpanNode, len(spansB))
+	for _, aligned := range spansB {
+		indexB[aligned.path] = aligned.node
+	}
+
+	matchedPaths := make(map[string]bool, len(spansA))
+	for _, aligned := range spansA {
+		nodeB, ok := indexB[aligned.path]
+		if !ok {
+			result.Removed = append(result.Removed, newSpanSummary(aligned))
+			continue
+		}
+		matchedPaths[aligned.path] = true
+		result.Matched = append(result.Matched, newSpanDiff(aligned.path, aligned.node, nodeB))
+	}
+
+	for _, aligned := range spansB {
+		if !matchedPaths[aligned.path] {
+			result.Added = append(result.Added, newSpanSummary(aligned))
+		}
+	}
+
+	// 지속 시간 변화가 큰 순서로 정렬
+	sort.SliceStable(result.Matched, func(i, j int) bool {
+		return math.Abs(result.Matched[i].DurationDelta) > math.Abs(result.Matched[j].DurationDelta)
+	})
+
+	return result
+}
+
+// operationKey는 스팬의 서비스+오퍼레이션 식별자를 반환합니다.
+func operationKey(node *SpanNode) string {
+	return node.ServiceName + ":" + node.Name
+}
+
+// alignSpans는 트리를 순회하며 각 스팬에 루트부터의 서비스+오퍼레이션 경로 키를 부여합니다.
+func alignSpans(roots []*SpanNode) []alignedSpan {
+	var spans []alignedSpan
+	var visit func(nodes []*SpanNode, parentPath string)
+	visit = func(nodes []*SpanNode, parentPath string) {
+		occurrences := make(map[string]int)
+		for _, node := range nodes {
+			key := operationKey(node)
+			path := key
+			if parentPath != "" {
+				path = parentPath + " > " + key
+			}
+			if n := occurrences[key]; n > 0 {
+				path = fmt.Sprintf("%s[%d]", path, n)
+			}
+			occurrences[key]++
+
+			spans = append(spans, alignedSpan{path: path, node: node})
+			visit(node.Children, path)
+		}
+	}
+	visit(roots, "")
+	return spans
+}
+
+// newSpanSummary는 정렬된 스팬의 요약을 생성합니다.
+func newSpanSummary(aligned alignedSpan) SpanSummary {
+	return SpanSummary{
+		Path:        aligned.path,
+		SpanID:      aligned.node.SpanID,
+		ServiceName: aligned.node.ServiceName,
+		Name:        aligned.node.Name,
+		Duration:    aligned.node.Duration,
+		Status:      aligned.node.Status,
+	}
+}
+
+// newSpanDiff는 정렬된 두 스팬의 차이를 계산합니다.
+func newSpanDiff(path string, a, b *SpanNode) SpanDiff {
+	diff := SpanDiff{
+		Path:           path,
+		ServiceName:    a.ServiceName,
+		Name:           a.Name,
+		SpanIDA:        a.SpanID,
+		SpanIDB:        b.SpanID,
+		DurationA:      a.Duration,
+		DurationB:      b.Duration,
+		DurationDelta:  b.Duration - a.Duration,
+		SelfTimeA:      a.SelfTime,
+		SelfTimeB:      b.SelfTime,
+		SelfTimeDelta:  b.SelfTime - a.SelfTime,
+		StatusA:        a.Status,
+		StatusB:        b.Status,
+		AttributeDiffs: diffAttributes(a.Attributes, b.Attributes),
+	}
+	if a.Duration > 0 {
+		diff.DeltaPercent = diff.DurationDelta / a.Duration * 100
+	}
+	return diff
+}
+
+// diffAttributes는 두 속성 맵의 차이를 키 순서대로 반환합니다.
+func diffAttributes(a, b map[string]interface{}) []AttributeDiff {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var diffs []AttributeDiff
+	for _, key := range sorted {
+		valueA, inA := a[key]
+		valueB, inB := b[key]
+		switch {
+		case inA && !inB:
+			diffs = append(diffs, AttributeDiff{Key: key, Change: AttributeRemoved, ValueA: valueA})
+		case !inA && inB:
+			diffs = append(diffs, AttributeDiff{Key: key, Change: AttributeAdded, ValueB: valueB})
+		case !reflect.DeepEqual(valueA, valueB):
+			diffs = append(diffs, AttributeDiff{Key: key, Change: AttributeChanged, ValueA: valueA, ValueB: valueB})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,36 @@
+package domain
+
+// ServiceNode는 서비스 의존성 그래프의 노드(서비스)를 정의합니다.
+type ServiceNode struct {
+	Name         string  `json:"name"`
+	RequestCount int64   `json:"requestCount"`
+	ErrorCount   int64   `json:"errorCount"`
+	ErrorRate    float64 `json:"errorRate"`
+	AvgLatency   float64 `json:"avgLatency"`
+	P95Latency   float64 `json:"p95Latency"`
+	P99Latency   float64 `json:"p99Latency"`
+}
+
+// ServiceEdge는 부모 서비스에서 자식 서비스로의 호출 관계를 정의합니다.
+type ServiceEdge struct {
+	Source     string  `json:"source"`
+	Target     string  `json:"target"`
+	CallCount  int64   `json:"callCount"`
+	ErrorCount int64   `json:"errorCount"`
+	ErrorRate  float64 `json:"errorRate"`
+	AvgLatency float64 `json:"avgLatency"`
+	MinLatency float64 `json:"minLatency"`
+	MaxLatency float64 `json:"maxLatency"`
+	P50Latency float64 `json:"p50Latency"`
+	P95Latency float64 `json:"p95Latency"`
+	P99Latency float64 `json:"p99Latency"`
+}
+
+// ServiceGraph는 서비스 의존성 그래프 조회 결과를 정의합니다.
+type ServiceGraph struct {
+	Nodes     []ServiceNode `json:"nodes"`
+	Edges     []ServiceEdge `json:"edges"`
+	StartTime int64         `json:"startTime"`
+	EndTime   int64         `json:"endTime"`
+	Took      int64         `json:"took"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+// 스팬 종류 (OTLP SpanKind)
+const (
+	SpanKindUnspecified = "UNSPECIFIED"
+	SpanKindInternal    = "INTERNAL"
+	SpanKindServer      = "SERVER"
+	SpanKindClient      = "CLIENT"
+	SpanKindProducer    = "PRODUCER"
+	SpanKindConsumer    = "CONSUMER"
+)
+
+// OperationStats는 서비스 내 오퍼레이션(스팬 이름)과 스팬 종류별 통계를 정의합니다.
+type OperationStats struct {
+	Name         string  `json:"name"`
+	SpanKind     string  `json:"spanKind"`
+	RequestCount int64   `json:"requestCount"`
+	ErrorCount   int64   `json:"errorCount"`
+	ErrorRate    float64 `json:"errorRate"`
+	AvgLatency   float64 `json:"avgLatency"`
+	P50Latency   float64 `json:"p50Latency"`
+	P95Latency   float64 `json:"p95Latency"`
+	P99Latency   float64 `json:"p99Latency"`
+}
+
+// OperationFilter는 오퍼레이션 통계 조회 옵션을 정의합니다.
+type OperationFilter struct {
+	TenantID      string `json:"-"`
+	ServiceName   string `json:"serviceName"`
+	StartTime     int64  `json:"startTime"`
+	EndTime       int64  `json:"endTime"`
+	SpanKind      string `json:"spanKind,omitempty"`
+	SortField     string `json:"sortField,omitempty"`
+	SortDirection string `json:"sortDirection,omitempty"`
+	Limit         int    `json:"limit"`
+}
+
+// OperationListResult는 오퍼레이션 통계 조회 결과를 정의합니다.
+type OperationListResult struct {
+	ServiceName string           `json:"serviceName"`
+	Operations  []OperationStats `json:"operations"`
+	Total       int              `json:"total"`
+	StartTime   int64            `json:"startTime"`
+	EndTime     int64            `json:"endTime"`
+	Took        int64            `json:"took"`
+}
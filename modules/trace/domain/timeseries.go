@@ -0,0 +1,28 @@
+package domain
+
+// 시계열 조회 시 허용하는 최대 버킷 수
+const MaxTimeSeriesPoints = 1440
+
+// REDPoint는 한 시간 버킷의 요청률(Rate), 오류(Errors), 지연 시간(Duration) 지표를 정의합니다.
+type REDPoint struct {
+	Timestamp    int64   `json:"timestamp"`    // 버킷 시작 시간 (밀리초)
+	RequestCount int64   `json:"requestCount"` // 버킷 내 요청 수
+	RequestRate  float64 `json:"requestRate"`  // 초당 요청 수
+	ErrorCount   int64   `json:"errorCount"`
+	ErrorRate    float64 `json:"errorRate"` // 오류율 (%)
+	AvgLatency   float64 `json:"avgLatency"`
+	P50Latency   float64 `json:"p50Latency"`
+	P95Latency   float64 `json:"p95Latency"`
+	P99Latency   float64 `json:"p99Latency"`
+}
+
+// ServiceTimeSeries는 서비스의 RED 지표 시계열 조회 결과를 정의합니다.
+// 요청이 없는 버킷도 0 값으로 채워져 포함됩니다.
+type ServiceTimeSeries struct {
+	ServiceName string     `json:"serviceName"`
+	Step        int64      `json:"step"` // 버킷 크기 (밀리초)
+	StartTime   int64      `json:"startTime"`
+	EndTime     int64      `json:"endTime"`
+	Points      []REDPoint `json:"points"`
+	Took        int64      `json:"took"`
+}
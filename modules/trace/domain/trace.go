@@ -16,7 +16,9 @@ type TraceItem struct {
 	EndTime      int64                  `json:"endTime"`
 	Duration     float64                `json:"duration"`
 	Status       string                 `json:"status,omitempty"`
+	Kind         string                 `json:"kind,omitempty"`
 	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	TenantID     string                 `json:"tenantId,omitempty"`
 }
 
 // Span은 스팬 데이터 구조를 정의합니다.
@@ -31,6 +33,7 @@ type Span struct {
 	TraceID      string                 `json:"traceId"`
 	SpanID       string                 `json:"spanId"`
 	Status       string                 `json:"status,omitempty"`
+	Kind         string                 `json:"kind,omitempty"`
 	Attributes   map[string]interface{} `json:"attributes,omitempty"`
 }
 
@@ -89,6 +92,7 @@ func (s *Span) JSONToAttributes(jsonStr string) error {
 
 // TraceFilter는 트레이스 필터링 옵션을 정의합니다.
 type TraceFilter struct {
+	TenantID      string   `json:"-"`
 	StartTime     int64    `json:"startTime"`
 	EndTime       int64    `json:"endTime"`
 	ServiceNames  []string `json:"serviceNames,omitempty"`
@@ -0,0 +1,347 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
+	"github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// RollupMetrics는 [from, to) 구간의 스팬을 테넌트별, bucketSize(밀리초) 단위로 집계하여
+// service_metrics, service_graph_edges 및 operation_metrics 테이블에 저장합니다.
+// 같은 시간대를 다시 집계하면 기존 값을 덮어쓰므로 재실행해도 안전합니다.
+func (r *PostgresTraceRepository) RollupMetrics(ctx context.Context, from, to, bucketSize int64) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// 롤백 함수 준비
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			r.log.Error().Err(err).Msg("트레이스 집계 트랜잭션 롤백됨")
+		}
+	}()
+
+	// 집계 쿼리가 API 조회용 SQL 실행 시간 제한에 걸리지 않도록 해제
+	if err = db.DisableStatementTimeout(tx); err != nil {
+		return err
+	}
+
+	// 서비스별 집계
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO service_metrics(
+			tenant_id, service_name, time_bucket, request_count, error_count,
+			total_duration, min_duration, max_duration, p95_duration, p99_duration
+		)
+		SELECT
+			tenant_id,
+			service_name,
+			start_time - (start_time % $3) AS bucket,
+			COUNT(*),
+			COUNT(CASE WHEN status = 'ERROR' THEN 1 END),
+			SUM(duration),
+			MIN(duration),
+			MAX(duration),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY duration),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY duration)
+		FROM traces
+		WHERE start_time >= $1 AND start_time < $2
+		GROUP BY tenant_id, service_name, bucket
+		ON CONFLICT (tenant_id, service_name, time_bucket) DO UPDATE SET
+			request_count = EXCLUDED.request_count,
+			error_count = EXCLUDED.error_count,
+			total_duration = EXCLUDED.total_duration,
+			min_duration = EXCLUDED.min_duration,
+			max_duration = EXCLUDED.max_duration,
+			p95_duration = EXCLUDED.p95_duration,
+			p99_duration = EXCLUDED.p99_duration`,
+		from, to, bucketSize,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to rollup service metrics: %w", err)
+	}
+
+	// 서비스 간 호출 관계 집계 (자식 스팬을 같은 트레이스의 부모 스팬과 조인)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO service_graph_edges(
+			tenant_id, parent_service, child_service, time_bucket, call_count, error_count,
+			total_duration, min_duration, max_duration, p50_duration, p95_duration, p99_duration
+		)
+		SELECT
+			c.tenant_id,
+			p.service_name,
+			c.service_name,
+			c.start_time - (c.start_time % $3) AS bucket,
+			COUNT(*),
+			COUNT(CASE WHEN c.status = 'ERROR' THEN 1 END),
+			SUM(c.duration),
+			MIN(c.duration),
+			MAX(c.duration),
+			PERCENTILE_CONT(0.50) WITHIN GROUP (ORDER BY c.duration),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY c.duration),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY c.duration)
+		FROM traces c
+		JOIN traces p ON p.tenant_id = c.tenant_id AND p.trace_id = c.trace_id AND p.span_id = c.parent_span_id
+		WHERE c.start_time >= $1 AND c.start_time < $2
+			AND c.parent_span_id <> ''
+			AND p.service_name <> c.service_name
+		GROUP BY c.tenant_id, p.service_name, c.service_name, bucket
+		ON CONFLICT (tenant_id, parent_service, child_service, time_bucket) DO UPDATE SET
+			call_count = EXCLUDED.call_count,
+			error_count = EXCLUDED.error_count,
+			total_duration = EXCLUDED.total_duration,
+			min_duration = EXCLUDED.min_duration,
+			max_duration = EXCLUDED.max_duration,
+			p50_duration = EXCLUDED.p50_duration,
+			p95_duration = EXCLUDED.p95_duration,
+			p99_duration = EXCLUDED.p99_duration`,
+		from, to, bucketSize,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to rollup service graph edges: %w", err)
+	}
+
+	// 서비스 내 오퍼레이션(스팬 이름, 스팬 종류)별 집계
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO operation_metrics(
+			tenant_id, service_name, operation_name, span_kind, time_bucket, request_count, error_count,
+			total_duration, min_duration, max_duration, p50_duration, p95_duration, p99_duration
+		)
+		SELECT
+			tenant_id,
+			service_name,
+			name,
+			kind,
+			start_time - (start_time % $3) AS bucket,
+			COUNT(*),
+			COUNT(CASE WHEN status = 'ERROR' THEN 1 END),
+			SUM(duration),
+			MIN(duration),
+			MAX(duration),
+			PERCENTILE_CONT(0.50) WITHIN GROUP (ORDER BY duration),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY duration),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY duration)
+		FROM traces
+		WHERE start_time >= $1 AND start_time < $2
+		GROUP BY tenant_id, service_name, name, kind, bucket
+		ON CONFLICT (tenant_id, service_name, operation_name, span_kind, time_bucket) DO UPDATE SET
+			request_count = EXCLUDED.request_count,
+			error_count = EXCLUDED.error_count,
+			total_duration = EXCLUDED.total_duration,
+			min_duration = EXCLUDED.min_duration,
+			max_duration = EXCLUDED.max_duration,
+			p50_duration = EXCLUDED.p50_duration,
+			p95_duration = EXCLUDED.p95_duration,
+			p99_duration = EXCLUDED.p99_duration`,
+		from, to, bucketSize,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to rollup operation metrics: %w", err)
+	}
+
+	// 트랜잭션 커밋
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastRollupBucket은 마지막으로 집계된 시간대를 반환합니다. 집계된 데이터가 없으면 0을 반환합니다.
+func (r *PostgresTraceRepository) GetLastRollupBucket(ctx context.Context) (int64, error) {
+	var lastBucket int64
+	// 집계 작업의 진행 위치이므로 복제 지연이 없는 주 데이터베이스에서 조회
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(time_bucket), 0) FROM service_metrics`).Scan(&lastBucket)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query last rollup bucket: %w", err)
+	}
+	return lastBucket, nil
+}
+
+// GetFirstTraceTime은 가장 오래된 스팬의 시작 시간을 반환합니다. 스팬이 없으면 0을 반환합니다.
+func (r *PostgresTraceRepository) GetFirstTraceTime(ctx context.Context) (int64, error) {
+	var firstTime int64
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MIN(start_time), 0) FROM traces`).Scan(&firstTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query first trace time: %w", err)
+	}
+	return firstTime, nil
+}
+
+// GetServiceGraph는 집계 테이블에서 서비스 의존성 그래프를 조회합니다.
+// 여러 시간대에 걸친 백분위 지연 시간은 시간대별 값을 호출 수로 가중 평균한 근사치입니다.
+func (r *PostgresTraceRepository) GetServiceGraph(ctx context.Context, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error) {
+	nodesQuery := `
+		SELECT
+			service_name,
+			SUM(request_count),
+			SUM(error_count),
+			SUM(total_duration) / NULLIF(SUM(request_count), 0),
+			SUM(p95_duration * request_count) / NULLIF(SUM(request_count), 0),
+			SUM(p99_duration * request_count) / NULLIF(SUM(request_count), 0)
+		FROM service_metrics
+		WHERE time_bucket >= $1 AND time_bucket <= $2 AND tenant_id = $3
+		GROUP BY service_name
+		ORDER BY 2 DESC
+	`
+
+	edgesQuery := `
+		SELECT
+			parent_service,
+			child_service,
+			SUM(call_count),
+			SUM(error_count),
+			SUM(total_duration) / NULLIF(SUM(call_count), 0),
+			MIN(min_duration),
+			MAX(max_duration),
+			SUM(p50_duration * call_count) / NULLIF(SUM(call_count), 0),
+			SUM(p95_duration * call_count) / NULLIF(SUM(call_count), 0),
+			SUM(p99_duration * call_count) / NULLIF(SUM(call_count), 0)
+		FROM service_graph_edges
+		WHERE time_bucket >= $1 AND time_bucket <= $2 AND tenant_id = $3
+		GROUP BY parent_service, child_service
+		ORDER BY 3 DESC
+	`
+
+	return r.queryServiceGraph(ctx, nodesQuery, edgesQuery, tenantID, startTime, endTime)
+}
+
+// ComputeServiceGraph는 집계 테이블 없이 traces 테이블에서 직접 서비스 의존성 그래프를 계산합니다.
+func (r *PostgresTraceRepository) ComputeServiceGraph(ctx context.Context, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error) {
+	nodesQuery := `
+		SELECT
+			service_name,
+			COUNT(*),
+			COUNT(CASE WHEN status = 'ERROR' THEN 1 END),
+			AVG(duration),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY duration),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY duration)
+		FROM traces
+		WHERE start_time >= $1 AND start_time <= $2 AND tenant_id = $3
+		GROUP BY service_name
+		ORDER BY 2 DESC
+	`
+
+	edgesQuery := `
+		SELECT
+			p.service_name,
+			c.service_name,
+			COUNT(*),
+			COUNT(CASE WHEN c.status = 'ERROR' THEN 1 END),
+			AVG(c.duration),
+			MIN(c.duration),
+			MAX(c.duration),
+			PERCENTILE_CONT(0.50) WITHIN GROUP (ORDER BY c.duration),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY c.duration),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY c.duration)
+		FROM traces c
+		JOIN traces p ON p.tenant_id = c.tenant_id AND p.trace_id = c.trace_id AND p.span_id = c.parent_span_id
+		WHERE c.start_time >= $1 AND c.start_time <= $2 AND c.tenant_id = $3
+			AND c.parent_span_id <> ''
+			AND p.service_name <> c.service_name
+		GROUP BY p.service_name, c.service_name
+		ORDER BY 3 DESC
+	`
+
+	return r.queryServiceGraph(ctx, nodesQuery, edgesQuery, tenantID, startTime, endTime)
+}
+
+// queryServiceGraph는 노드/엣지 쿼리($1: 시작, $2: 종료, $3: 테넌트)를 실행하여 서비스 의존성 그래프를 구성합니다.
+func (r *PostgresTraceRepository) queryServiceGraph(ctx context.Context, nodesQuery, edgesQuery, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error) {
+	startQueryTime := time.Now()
+	result := domain.ServiceGraph{
+		Nodes:     []domain.ServiceNode{},
+		Edges:     []domain.ServiceEdge{},
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+
+	// 노드 조회
+	nodeRows, err := r.db.ReadQueryContext(ctx, nodesQuery, startTime, endTime, tenantID)
+	if err != nil {
+		return result, fmt.Errorf("failed to query service graph nodes: %w", err)
+	}
+	defer nodeRows.Close()
+
+	for nodeRows.Next() {
+		var node domain.ServiceNode
+		var avgLatency, p95Latency, p99Latency sql.NullFloat64
+
+		if err := nodeRows.Scan(
+			&node.Name,
+			&node.RequestCount,
+			&node.ErrorCount,
+			&avgLatency,
+			&p95Latency,
+			&p99Latency,
+		); err != nil {
+			return result, fmt.Errorf("failed to scan service graph node row: %w", err)
+		}
+
+		node.AvgLatency = avgLatency.Float64
+		node.P95Latency = p95Latency.Float64
+		node.P99Latency = p99Latency.Float64
+		if node.RequestCount > 0 {
+			node.ErrorRate = float64(node.ErrorCount) / float64(node.RequestCount) * 100
+		}
+
+		result.Nodes = append(result.Nodes, node)
+	}
+
+	if err := nodeRows.Err(); err != nil {
+		return result, fmt.Errorf("error iterating service graph node rows: %w", err)
+	}
+
+	// 엣지 조회
+	edgeRows, err := r.db.ReadQueryContext(ctx, edgesQuery, startTime, endTime, tenantID)
+	if err != nil {
+		return result, fmt.Errorf("failed to query service graph edges: %w", err)
+	}
+	defer edgeRows.Close()
+
+	for edgeRows.Next() {
+		var edge domain.ServiceEdge
+		var avgLatency, minLatency, maxLatency, p50Latency, p95Latency, p99Latency sql.NullFloat64
+
+		if err := edgeRows.Scan(
+			&edge.Source,
+			&edge.Target,
+			&edge.CallCount,
+			&edge.ErrorCount,
+			&avgLatency,
+			&minLatency,
+			&maxLatency,
+			&p50Latency,
+			&p95Latency,
+			&p99Latency,
+		); err != nil {
+			return result, fmt.Errorf("failed to scan service graph edge row: %w", err)
+		}
+
+		edge.AvgLatency = avgLatency.Float64
+		edge.MinLatency = minLatency.Float64
+		edge.MaxLatency = maxLatency.Float64
+		edge.P50Latency = p50Latency.Float64
+		edge.P95Latency = p95Latency.Float64
+		edge.P99Latency = p99Latency.Float64
+		if edge.CallCount > 0 {
+			edge.ErrorRate = float64(edge.ErrorCount) / float64(edge.CallCount) * 100
+		}
+
+		result.Edges = append(result.Edges, edge)
+	}
+
+	if err := edgeRows.Err(); err != nil {
+		return result, fmt.Errorf("error iterating service graph edge rows: %w", err)
+	}
+
+	// 실행 시간 계산
+	result.Took = time.Since(startQueryTime).Milliseconds()
+
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
+)
+
+// 오퍼레이션 통계 조회 시 기본/최대 반환 개수
+const (
+	defaultOperationLimit = 100
+	maxOperationLimit     = 1000
+)
+
+// spanKindOrDefault는 비어 있는 스팬 종류를 UNSPECIFIED로 대체합니다.
+func spanKindOrDefault(kind string) string {
+	if kind == "" {
+		return domain.SpanKindUnspecified
+	}
+	return kind
+}
+
+// GetOperationStats는 집계 테이블에서 서비스의 오퍼레이션별 통계를 조회합니다.
+// 여러 시간대에 걸친 백분위 지연 시간은 시간대별 값을 요청 수로 가중 평균한 근사치입니다.
+func (r *PostgresTraceRepository) GetOperationStats(ctx context.Context, filter domain.OperationFilter) (domain.OperationListResult, error) {
+	query := `
+		SELECT
+			operation_name,
+			span_kind,
+			SUM(request_count) AS request_count,
+			SUM(error_count) AS error_count,
+			SUM(total_duration) / NULLIF(SUM(request_count), 0) AS avg_latency,
+			SUM(p50_duration * request_count) / NULLIF(SUM(request_count), 0) AS p50_latency,
+			SUM(p95_duration * request_count) / NULLIF(SUM(request_count), 0) AS p95_latency,
+			SUM(p99_duration * request_count) / NULLIF(SUM(request_count), 0) AS p99_latency
+		FROM operation_metrics
+		WHERE %s
+		GROUP BY operation_name, span_kind
+	`
+
+	return r.queryOperationStats(ctx, query, "service_name", "time_bucket", "span_kind", filter)
+}
+
+// ComputeOperationStats는 집계 테이블 없이 traces 테이블에서 직접 오퍼레이션별 통계를 계산합니다.
+func (r *PostgresTraceRepository) ComputeOperationStats(ctx context.Context, filter domain.OperationFilter) (domain.OperationListResult, error) {
+	query := `
+		SELECT
+			name AS operation_name,
+			kind AS span_kind,
+			COUNT(*) AS request_count,
+			COUNT(CASE WHEN status = 'ERROR' THEN 1 END) AS error_count,
+			AVG(duration) AS avg_latency,
+			PERCENTILE_CONT(0.50) WITHIN GROUP (ORDER BY duration) AS p50_latency,
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY duration) AS p95_latency,
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY duration) AS p99_latency
+		FROM traces
+		WHERE %s
+		GROUP BY name, kind
+	`
+
+	return r.queryOperationStats(ctx, query, "service_name", "start_time", "kind", filter)
+}
+
+// queryOperationStats는 WHERE 절을 채운 집계 쿼리를 정렬/개수 제한 쿼리로 감싸 실행합니다.
+func (r *PostgresTraceRepository) queryOperationStats(ctx context.Context, queryTemplate, serviceColumn, timeColumn, kindColumn string, filter domain.OperationFilter) (domain.OperationListResult, error) {
+	startQueryTime := time.Now()
+	result := domain.OperationListResult{
+		ServiceName: filter.ServiceName,
+		Operations:  []domain.OperationStats{},
+		StartTime:   filter.StartTime,
+		EndTime:     filter.EndTime,
+	}
+
+	// 정렬 필드 매핑 (SQL 인젝션 방지를 위해 허용된 필드만 사용)
+	sortFieldMap := map[string]string{
+		"name":         "operation_name",
+		"requestCount": "request_count",
+		"errorCount":   "error_count",
+		"errorRate":    "error_count::float / NULLIF(request_count, 0)",
+		"avgLatency":   "avg_latency",
+		"p50Latency":   "p50_latency",
+		"p95Latency":   "p95_latency",
+		"p99Latency":   "p99_latency",
+	}
+
+	sortField := "request_count"
+	if filter.SortField != "" {
+		if dbField, ok := sortFieldMap[filter.SortField]; ok {
+			sortField = dbField
+		} else {
+			r.log.Warn().Str("sortField", filter.SortField).Msg("지원하지 않는 정렬 필드, 기본값(requestCount) 사용")
+		}
+	}
+
+	sortDirection := "DESC"
+	if strings.ToUpper(filter.SortDirection) == "ASC" {
+		sortDirection = "ASC"
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultOperationLimit
+	}
+	if limit > maxOperationLimit {
+		limit = maxOperationLimit
+	}
+
+	// WHERE 절 구성
+	queryParams := []interface{}{filter.ServiceName, filter.StartTime, filter.EndTime, filter.TenantID}
+	whereClause := fmt.Sprintf("%s = $1 AND %s >= $2 AND %s <= $3 AND tenant_id = $4", serviceColumn, timeColumn, timeColumn)
+
+	if filter.SpanKind != "" {
+		whereClause += fmt.Sprintf(" AND %s = $5", kindColumn)
+		queryParams = append(queryParams, strings.ToUpper(filter.SpanKind))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM (%s) ops
+		ORDER BY %s %s NULLS LAST, operation_name ASC
+		LIMIT %d
+	`, fmt.Sprintf(queryTemplate, whereClause), sortField, sortDirection, limit)
+
+	rows, err := r.db.ReadQueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return result, fmt.Errorf("failed to query operation stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var op domain.OperationStats
+		var avgLatency, p50Latency, p95Latency, p99Latency sql.NullFloat64
+
+		if err := rows.Scan(
+			&op.Name,
+			&op.SpanKind,
+			&op.RequestCount,
+			&op.ErrorCount,
+			&avgLatency,
+			&p50Latency,
+			&p95Latency,
+			&p99Latency,
+		); err != nil {
+			return result, fmt.Errorf("failed to scan operation stats row: %w", err)
+		}
+
+		op.AvgLatency = avgLatency.Float64
+		op.P50Latency = p50Latency.Float64
+		op.P95Latency = p95Latency.Float64
+		op.P99Latency = p99Latency.Float64
+		if op.RequestCount > 0 {
+			op.ErrorRate = float64(op.ErrorCount) / float64(op.RequestCount) * 100
+		}
+
+		result.Operations = append(result.Operations, op)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("error iterating operation stats rows: %w", err)
+	}
+
+	result.Total = len(result.Operations)
+	result.Took = time.Since(startQueryTime).Milliseconds()
+
+	return result, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -10,25 +11,47 @@ import (
 	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/db"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/tenant"
 	"github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 )
 
 // TraceRepository는 트레이스 저장소 인터페이스입니다.
+// 조회 메서드는 모두 tenantID(또는 필터의 TenantID) 테넌트의 데이터만 조회합니다.
 type TraceRepository interface {
 	// 트레이스 저장
-	SaveTraces(traces []domain.TraceItem) error
+	SaveTraces(ctx context.Context, traces []domain.TraceItem) error
 
 	// 특정 트레이스 조회
-	GetTraceByID(traceID string) (*domain.Trace, error)
+	GetTraceByID(ctx context.Context, tenantID, traceID string) (*domain.Trace, error)
 
 	// 트레이스 쿼리
-	QueryTraces(filter domain.TraceFilter) (domain.TraceQueryResult, error)
+	QueryTraces(ctx context.Context, filter domain.TraceFilter) (domain.TraceQueryResult, error)
 
 	// 서비스 목록 조회
-	GetServices(startTime, endTime int64, filter string) (domain.ServiceListResult, error)
+	GetServices(ctx context.Context, tenantID string, startTime, endTime int64, filter string) (domain.ServiceListResult, error)
 
 	// 서비스 메트릭 조회
-	GetServiceMetrics(startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error)
+	GetServiceMetrics(ctx context.Context, tenantID string, startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error)
+
+	// 서비스 의존성 그래프 조회 (집계 테이블 기반)
+	GetServiceGraph(ctx context.Context, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error)
+
+	// 서비스 의존성 그래프 계산 (traces 테이블 직접 조회)
+	ComputeServiceGraph(ctx context.Context, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error)
+
+	// 오퍼레이션별 통계 조회 (집계 테이블 기반)
+	GetOperationStats(ctx context.Context, filter domain.OperationFilter) (domain.OperationListResult, error)
+
+	// 오퍼레이션별 통계 계산 (traces 테이블 직접 조회)
+	ComputeOperationStats(ctx context.Context, filter domain.OperationFilter) (domain.OperationListResult, error)
+
+	// 서비스 RED 지표 시계열 조회
+	GetServiceTimeSeries(ctx context.Context, tenantID, serviceName string, startTime, endTime, step int64) (domain.ServiceTimeSeries, error)
+
+	// 시간대별 집계 (모든 테넌트를 테넌트별로 집계)
+	RollupMetrics(ctx context.Context, from, to, bucketSize int64) error
+	GetLastRollupBucket(ctx context.Context) (int64, error)
+	GetFirstTraceTime(ctx context.Context) (int64, error)
 }
 
 // PostgresTraceRepository는 PostgreSQL 트레이스 저장소 구현체입니다.
@@ -46,12 +69,12 @@ func NewTraceRepository(database db.Database) TraceRepository {
 }
 
 // SaveTraces는 트레이스 데이터를 데이터베이스에 저장합니다.
-func (r *PostgresTraceRepository) SaveTraces(traces []domain.TraceItem) error {
+func (r *PostgresTraceRepository) SaveTraces(ctx context.Context, traces []domain.TraceItem) error {
 	if len(traces) == 0 {
 		return nil
 	}
 
-	tx, err := r.db.Begin()
+	tx, err := r.db.BeginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -72,18 +95,19 @@ func (r *PostgresTraceRepository) SaveTraces(traces []domain.TraceItem) error {
 			continue
 		}
 
-		_, err = tx.Exec(
+		_, err = tx.ExecContext(ctx,
 			`INSERT INTO traces(
 				id, trace_id, span_id, parent_span_id, name, service_name, 
-				start_time, end_time, duration, status, attributes
-			) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-			ON CONFLICT (id) DO UPDATE SET
+				start_time, end_time, duration, status, kind, attributes, tenant_id
+			) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			ON CONFLICT (tenant_id, id) DO UPDATE SET
 				name = EXCLUDED.name,
 				service_name = EXCLUDED.service_name,
 				start_time = EXCLUDED.start_time,
 				end_time = EXCLUDED.end_time,
 				duration = EXCLUDED.duration,
 				status = EXCLUDED.status,
+				kind = EXCLUDED.kind,
 				attributes = EXCLUDED.attributes`,
 			trace.ID,
 			trace.TraceID,
@@ -95,7 +119,9 @@ func (r *PostgresTraceRepository) SaveTraces(traces []domain.TraceItem) error {
 			trace.EndTime,
 			trace.Duration,
 			trace.Status,
+			spanKindOrDefault(trace.Kind),
 			attributes,
+			tenant.OrDefault(trace.TenantID),
 		)
 
 		if err != nil {
@@ -112,18 +138,18 @@ func (r *PostgresTraceRepository) SaveTraces(traces []domain.TraceItem) error {
 	return nil
 }
 
-func (r *PostgresTraceRepository) GetTraceByID(traceID string) (*domain.Trace, error) {
+func (r *PostgresTraceRepository) GetTraceByID(ctx context.Context, tenantID, traceID string) (*domain.Trace, error) {
 	query := `
 		SELECT 
 			id, trace_id, span_id, parent_span_id,
 			name, service_name, start_time, 
-			end_time, duration, status, attributes
+			end_time, duration, status, kind, attributes
 		FROM traces
-		WHERE trace_id = $1
+		WHERE trace_id = $1 AND tenant_id = $2
 		ORDER BY start_time ASC
 	`
 
-	rows, err := r.db.Query(query, traceID)
+	rows, err := r.db.ReadQueryContext(ctx, query, traceID, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query trace by ID: %w", err)
 	}
@@ -150,6 +176,7 @@ func (r *PostgresTraceRepository) GetTraceByID(traceID string) (*domain.Trace, e
 			&span.EndTime,
 			&span.Duration,
 			&span.Status,
+			&span.Kind,
 			&attributesJSON,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan span row: %w", err)
@@ -210,7 +237,7 @@ func (r *PostgresTraceRepository) GetTraceByID(traceID string) (*domain.Trace, e
 	return trace, nil
 }
 
-func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain.TraceQueryResult, error) {
+func (r *PostgresTraceRepository) QueryTraces(ctx context.Context, filter domain.TraceFilter) (domain.TraceQueryResult, error) {
 	startTime := time.Now()
 	result := domain.TraceQueryResult{
 		Traces:      []domain.TraceItem{},
@@ -218,9 +245,9 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 		Total:       0,
 		Took:        0,
 	}
-	queryParams := []interface{}{filter.StartTime, filter.EndTime}
-	paramIndex := 3
-	whereClause := "start_time >= $1 AND start_time <= $2"
+	queryParams := []interface{}{filter.TenantID, filter.StartTime, filter.EndTime}
+	paramIndex := 4
+	whereClause := "tenant_id = $1 AND start_time >= $2 AND start_time <= $3"
 
 	sortFieldMap := map[string]string{
 		"startTime":   "start_time",
@@ -261,11 +288,6 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 		whereClause += fmt.Sprintf(" AND service_name IN (%s)", strings.Join(placeholders, ", "))
 	}
 
-	// RootSpansOnly 필터 추가
-	if filter.RootSpansOnly {
-		whereClause += " AND parent_span_id = '' OR parent_span_id IS NULL"
-	}
-
 	// 상태 필터
 	if filter.Status != nil && *filter.Status != "" {
 		whereClause += fmt.Sprintf(" AND status = $%d", paramIndex)
@@ -296,6 +318,8 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 		queryParams = append(queryParams, "%"+*filter.Query+"%")
 		paramIndex++
 	}
+
+	// RootSpansOnly 필터 (OR 조건이 테넌트 조건을 벗어나지 않도록 괄호로 묶음)
 	if filter.RootSpansOnly {
 		whereClause += " AND (parent_span_id = '' OR parent_span_id IS NULL)"
 	}
@@ -360,7 +384,7 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 		Msg("정렬 적용된 트레이스 쿼리 실행")
 
 	// 트레이스 조회
-	tracesRows, err := r.db.Query(tracesQuery, queryParams...)
+	tracesRows, err := r.db.ReadQueryContext(ctx, tracesQuery, queryParams...)
 	if err != nil {
 		return result, fmt.Errorf("failed to query traces: %w", err)
 	}
@@ -407,7 +431,7 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 	}
 
 	// 트레이스 그룹 조회
-	traceGroupsRows, err := r.db.Query(traceGroupsQuery, queryParams[:paramIndex-1]...)
+	traceGroupsRows, err := r.db.ReadQueryContext(ctx, traceGroupsQuery, queryParams[:paramIndex-1]...)
 	if err != nil {
 		return result, fmt.Errorf("failed to query trace groups: %w", err)
 	}
@@ -440,7 +464,7 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 
 	// 총 개수 카운트
 	var total int
-	err = r.db.QueryRow(countQuery, queryParams[:paramIndex-1]...).Scan(&total)
+	err = r.db.ReadQueryRowContext(ctx, countQuery, queryParams[:paramIndex-1]...).Scan(&total)
 	if err != nil {
 		return result, fmt.Errorf("failed to count traces: %w", err)
 	}
@@ -453,7 +477,7 @@ func (r *PostgresTraceRepository) QueryTraces(filter domain.TraceFilter) (domain
 }
 
 // GetServices는 서비스 목록과 기본 통계 정보를 반환합니다.
-func (r *PostgresTraceRepository) GetServices(startTime, endTime int64, filter string) (domain.ServiceListResult, error) {
+func (r *PostgresTraceRepository) GetServices(ctx context.Context, tenantID string, startTime, endTime int64, filter string) (domain.ServiceListResult, error) {
 	startQueryTime := time.Now()
 	result := domain.ServiceListResult{
 		Services: []domain.ServiceInfo{},
@@ -462,11 +486,11 @@ func (r *PostgresTraceRepository) GetServices(startTime, endTime int64, filter s
 	}
 
 	// 쿼리 파라미터 배열
-	queryParams := []interface{}{startTime, endTime}
-	paramIndex := 3
+	queryParams := []interface{}{tenantID, startTime, endTime}
+	paramIndex := 4
 
 	// 기본 WHERE 조건
-	whereClause := "start_time >= $1 AND start_time <= $2 AND service_name IS NOT NULL"
+	whereClause := "tenant_id = $1 AND start_time >= $2 AND start_time <= $3 AND service_name IS NOT NULL"
 
 	// 서비스명 필터 (부분 일치 지원)
 	if filter != "" {
@@ -494,7 +518,7 @@ func (r *PostgresTraceRepository) GetServices(startTime, endTime int64, filter s
     `, whereClause)
 
 	// 쿼리 실행
-	rows, err := r.db.Query(servicesQuery, queryParams...)
+	rows, err := r.db.ReadQueryContext(ctx, servicesQuery, queryParams...)
 	if err != nil {
 		return result, fmt.Errorf("failed to query services: %w", err)
 	}
@@ -534,13 +558,13 @@ func (r *PostgresTraceRepository) GetServices(startTime, endTime int64, filter s
 }
 
 // GetServiceMetrics 메서드 개선 (기존 메서드 수정)
-func (r *PostgresTraceRepository) GetServiceMetrics(startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error) {
+func (r *PostgresTraceRepository) GetServiceMetrics(ctx context.Context, tenantID string, startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error) {
 	// 쿼리 파라미터 배열
-	queryParams := []interface{}{startTime, endTime}
-	paramIndex := 3
+	queryParams := []interface{}{tenantID, startTime, endTime}
+	paramIndex := 4
 
 	// 기본 WHERE 조건
-	whereClause := "start_time >= $1 AND start_time <= $2 AND service_name IS NOT NULL"
+	whereClause := "tenant_id = $1 AND start_time >= $2 AND start_time <= $3 AND service_name IS NOT NULL"
 
 	// 서비스명 필터 (선택적)
 	if serviceName != "" {
@@ -569,7 +593,7 @@ func (r *PostgresTraceRepository) GetServiceMetrics(startTime, endTime int64, se
         LIMIT 50
     `, whereClause)
 
-	rows, err := r.db.Query(query, queryParams...)
+	rows, err := r.db.ReadQueryContext(ctx, query, queryParams...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query service metrics: %w", err)
 	}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
+	"github.com/seongpil0948/otel-kafka-pg/modules/trace/repository"
+)
+
+// 집계 쿼리 한 번이 다루는 최대 구간 (1시간). 오래 중단된 뒤에는 빠진 구간을 이 크기로 나눠 모두 집계
+const rollupChunk = int64(3600000)
+
+// RollupService는 트레이스를 시간대별로 집계하는 백그라운드 작업입니다.
+type RollupService interface {
+	// Start는 집계 작업을 시작합니다.
+	Start(ctx context.Context) error
+	// Stop은 집계 작업을 중지합니다.
+	Stop() error
+}
+
+// rollupServiceImpl은 RollupService 인터페이스의 구현체입니다.
+type rollupServiceImpl struct {
+	repository repository.TraceRepository
+	config     *config.Config
+	log        logger.Logger
+	ticker     *time.Ticker
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+	isRunning  bool
+	watermark  int64 // 집계를 마친 마지막 시간대 경계 (밀리초, 0이면 집계 이력 없음)
+}
+
+// NewRollupService는 새 RollupService 인스턴스를 생성합니다.
+func NewRollupService(repo repository.TraceRepository, config *config.Config) RollupService {
+	return &rollupServiceImpl{
+		repository: repo,
+		config:     config,
+		log:        logger.GetLogger(),
+		stopChan:   make(chan struct{}),
+		isRunning:  false,
+	}
+}
+
+// Start는 집계 작업을 시작합니다.
+func (r *rollupServiceImpl) Start(ctx context.Context) error {
+	if r.isRunning {
+		r.log.Info().Msg("트레이스 집계 작업이 이미 실행 중입니다")
+		return nil
+	}
+
+	if !r.config.Rollup.Enabled {
+		r.log.Info().Msg("트레이스 집계 작업이 비활성화되어 있습니다")
+		return nil
+	}
+
+	// 마지막 집계 시간대 이후부터 이어서 집계
+	lastBucket, err := r.repository.GetLastRollupBucket(ctx)
+	if err != nil {
+		return err
+	}
+	r.watermark = lastBucket
+
+	interval := time.Duration(r.config.Rollup.Interval) * time.Second
+	r.ticker = time.NewTicker(interval)
+	r.stopChan = make(chan struct{})
+	r.isRunning = true
+
+	r.log.Info().
+		Int("interval_seconds", r.config.Rollup.Interval).
+		Int("bucket_seconds", r.config.Rollup.BucketSize).
+		Int64("watermark", r.watermark).
+		Msg("트레이스 집계 작업 시작")
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		// 시작 시 즉시 한 번 실행 (빠진 구간을 채우는 동안 시작을 막지 않음)
+		if err := r.rollup(ctx); err != nil {
+			r.log.Error().Err(err).Msg("초기 트레이스 집계 중 오류 발생")
+		}
+
+		for {
+			select {
+			case <-r.ticker.C:
+				if err := r.rollup(ctx); err != nil {
+					r.log.Error().Err(err).Msg("트레이스 집계 중 오류 발생")
+				}
+			case <-r.stopChan:
+				r.log.Info().Msg("트레이스 집계 작업 루프 종료")
+				return
+			case <-ctx.Done():
+				r.log.Info().Msg("컨텍스트 종료로 인한 트레이스 집계 작업 루프 종료")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop은 집계 작업을 중지합니다.
+func (r *rollupServiceImpl) Stop() error {
+	if !r.isRunning {
+		return nil
+	}
+
+	r.ticker.Stop()
+	close(r.stopChan)
+	// 진행 중인 집계가 끝날 때까지 기다려 다시 시작한 작업과 겹치지 않도록 함
+	r.wg.Wait()
+	r.isRunning = false
+	r.log.Info().Msg("트레이스 집계 작업 중지됨")
+	return nil
+}
+
+// rollup은 유예 시간이 지난 시간대를 집계합니다.
+// 유예 시간 뒤에 도착한 스팬을 반영하도록 워터마크 이전 Lookback 구간을 다시 집계하고(결과는 시간대별로 덮어씀),
+// 오래 중단되어 빠진 구간은 건너뛰지 않고 rollupChunk 단위로 나눠 모두 집계합니다.
+// 집계 이력이 없으면 가장 오래된 스팬부터 집계합니다.
+func (r *rollupServiceImpl) rollup(ctx context.Context) error {
+	bucketSize := int64(r.config.Rollup.BucketSize) * 1000
+	lag := int64(r.config.Rollup.Lag) * 1000
+	lookback := int64(r.config.Rollup.Lookback) * 1000
+
+	// 유예 시간이 지나 더 이상 스팬이 추가되지 않을 것으로 보는 마지막 시간대 경계
+	until := time.Now().UnixMilli() - lag
+	until -= until % bucketSize
+
+	from := r.watermark - lookback
+	if r.watermark == 0 {
+		firstTime, err := r.repository.GetFirstTraceTime(ctx)
+		if err != nil {
+			return err
+		}
+		if firstTime == 0 {
+			return nil
+		}
+		from = firstTime
+	}
+	if from < 0 {
+		from = 0
+	}
+	from -= from % bucketSize
+	if from >= until {
+		return nil
+	}
+
+	// 시간대가 청크 경계에 걸치지 않도록 청크 크기를 시간대 크기의 배수로 맞춤
+	chunk := rollupChunk - rollupChunk%bucketSize
+	if chunk < bucketSize {
+		chunk = bucketSize
+	}
+
+	startTime := time.Now()
+	for chunkStart := from; chunkStart < until; chunkStart += chunk {
+		chunkEnd := chunkStart + chunk
+		if chunkEnd > until {
+			chunkEnd = until
+		}
+
+		if err := r.repository.RollupMetrics(ctx, chunkStart, chunkEnd, bucketSize); err != nil {
+			return err
+		}
+		// 청크마다 진행 위치를 남겨 중간에 실패하거나 중지되어도 다음 실행이 이어서 집계
+		if chunkEnd > r.watermark {
+			r.watermark = chunkEnd
+		}
+
+		if r.stopped(ctx) {
+			return nil
+		}
+	}
+
+	r.log.Debug().
+		Int64("from", from).
+		Int64("until", until).
+		Dur("duration", time.Since(startTime)).
+		Msg("트레이스 집계 완료")
+
+	return nil
+}
+
+// stopped는 집계 작업이 중지되었거나 컨텍스트가 종료되었는지 확인합니다.
+func (r *rollupServiceImpl) stopped(ctx context.Context) bool {
+	select {
+	case <-r.stopChan:
+		return true
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
+	"github.com/seongpil0948/otel-kafka-pg/modules/trace/repository"
+)
+
+// fakeRollupRepository는 집계 호출 구간을 기록하는 저장소입니다.
+type fakeRollupRepository struct {
+	repository.TraceRepository
+	firstTraceTime int64
+	calls          [][2]int64
+}
+
+func (r *fakeRollupRepository) RollupMetrics(ctx context.Context, from, to, bucketSize int64) error {
+	r.calls = append(r.calls, [2]int64{from, to})
+	return nil
+}
+
+func (r *fakeRollupRepository) GetLastRollupBucket(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeRollupRepository) GetFirstTraceTime(ctx context.Context) (int64, error) {
+	return r.firstTraceTime, nil
+}
+
+func TestRollupRanges(t *testing.T) {
+	const (
+		minute = int64(60_000)
+		hour   = 60 * minute
+	)
+
+	cfg := &config.Config{}
+	cfg.Rollup.BucketSize = 60
+	cfg.Rollup.Lag = 60
+	cfg.Rollup.Lookback = 600
+
+	now := time.Now().UnixMilli()
+	until := now - minute
+	until -= until % minute
+
+	tests := []struct {
+		name       string
+		watermark  int64
+		firstTrace int64
+		wantFrom   int64 // 0이면 집계하지 않음
+	}{
+		{name: "no history and no traces", watermark: 0, firstTrace: 0, wantFrom: 0},
+		{name: "no history backfills from first trace", watermark: 0, firstTrace: until - 3*hour + 30_000, wantFrom: until - 3*hour},
+		{name: "recent watermark re-aggregates lookback", watermark: until - 5*minute, wantFrom: until - 15*minute},
+		{name: "long outage is backfilled", watermark: until - 26*hour, wantFrom: until - 26*hour - 10*minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRollupRepository{firstTraceTime: tt.firstTrace}
+			r := NewRollupService(repo, cfg).(*rollupServiceImpl)
+			r.watermark = tt.watermark
+
+			if err := r.rollup(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantFrom == 0 {
+				if len(repo.calls) != 0 {
+					t.Fatalf("calls = %v, want none", repo.calls)
+				}
+				return
+			}
+			if len(repo.calls) == 0 {
+				t.Fatal("no rollup calls")
+			}
+
+			// 빈틈 없이 이어지고, 청크 하나가 한 시간을 넘지 않으며, 시간대 경계에 맞아야 함
+			if got := repo.calls[0][0]; got != tt.wantFrom {
+				t.Errorf("first chunk from = %d, want %d", got, tt.wantFrom)
+			}
+			for i, call := range repo.calls {
+				if call[1]-call[0] > hour || call[1] <= call[0] {
+					t.Errorf("chunk %d = %v, want 0 < size <= 1h", i, call)
+				}
+				if call[0]%minute != 0 || call[1]%minute != 0 {
+					t.Errorf("chunk %d = %v is not aligned to buckets", i, call)
+				}
+				if i > 0 && call[0] != repo.calls[i-1][1] {
+					t.Errorf("gap between chunk %d and %d: %v %v", i-1, i, repo.calls[i-1], call)
+				}
+			}
+			// 호출하는 사이 분이 바뀌어도 맞도록 마지막 경계는 범위로 확인
+			last := repo.calls[len(repo.calls)-1][1]
+			if last < until || last > until+minute {
+				t.Errorf("last chunk until = %d, want %d", last, until)
+			}
+			if r.watermark != last {
+				t.Errorf("watermark = %d, want %d", r.watermark, last)
+			}
+		})
+	}
+}
+
+// 중지되면 남은 청크를 집계하지 않고, 다음 실행이 이어서 집계할 수 있도록 진행 위치를 남겨야 함
+func TestRollupStopsBetweenChunks(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Rollup.BucketSize = 60
+	cfg.Rollup.Lag = 60
+	cfg.Rollup.Lookback = 0
+
+	repo := &fakeRollupRepository{}
+	r := NewRollupService(repo, cfg).(*rollupServiceImpl)
+	r.watermark = time.Now().Add(-5 * time.Hour).UnixMilli()
+	r.watermark -= r.watermark % 60_000
+	close(r.stopChan)
+
+	if err := r.rollup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.calls) != 1 {
+		t.Fatalf("calls = %d, want 1", len(repo.calls))
+	}
+	if r.watermark != repo.calls[0][1] {
+		t.Errorf("watermark = %d, want %d", r.watermark, repo.calls[0][1])
+	}
+}
+
+// 리더 자격을 잃었다가 다시 얻으면 같은 인스턴스를 다시 시작할 수 있어야 함
+func TestRollupRestart(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Rollup.Enabled = true
+	cfg.Rollup.Interval = 60
+	cfg.Rollup.BucketSize = 60
+	cfg.Rollup.Lag = 60
+
+	r := NewRollupService(&fakeRollupRepository{}, cfg)
+	for i := 0; i < 2; i++ {
+		if err := r.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -1,33 +1,57 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/seongpil0948/otel-kafka-pg/modules/api/dto"
+	"github.com/seongpil0948/otel-kafka-pg/modules/common/config"
 	"github.com/seongpil0948/otel-kafka-pg/modules/common/logger"
 	"github.com/seongpil0948/otel-kafka-pg/modules/trace/domain"
 	"github.com/seongpil0948/otel-kafka-pg/modules/trace/repository"
 )
 
+// ErrTraceNotFound는 요청한 트레이스가 존재하지 않을 때 반환됩니다.
+var ErrTraceNotFound = errors.New("trace not found")
+
+// maxTraceLimit은 트레이스 목록 조회 한 번에 반환하는 최대 트레이스 수입니다.
+const maxTraceLimit = 1000
+
 // TraceService는 트레이스 서비스 인터페이스입니다.
 type TraceService interface {
 	// 트레이스 저장
-	SaveTraces(traces []domain.TraceItem) error
+	SaveTraces(ctx context.Context, traces []domain.TraceItem) error
 
 	// 특정 트레이스 조회
-	GetTraceByID(traceID string) (*domain.Trace, error)
+	GetTraceByID(ctx context.Context, tenantID, traceID string) (*domain.Trace, error)
 
 	// 트레이스 쿼리
-	QueryTraces(filter domain.TraceFilter) (domain.TraceQueryResult, error)
+	QueryTraces(ctx context.Context, filter domain.TraceFilter) (domain.TraceQueryResult, error)
+
+	GetServices(ctx context.Context, tenantID string, startTime, endTime int64, filter string) (domain.ServiceListResult, error)
+
+	GetServiceMetrics(ctx context.Context, tenantID string, startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error)
+
+	// 서비스 의존성 그래프 조회
+	GetServiceGraph(ctx context.Context, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error)
+
+	// 서비스의 오퍼레이션별 통계 조회
+	GetOperationStats(ctx context.Context, filter domain.OperationFilter) (domain.OperationListResult, error)
 
-	GetServices(startTime, endTime int64, filter string) (domain.ServiceListResult, error)
+	// 서비스 RED 지표 시계열 조회
+	GetServiceTimeSeries(ctx context.Context, tenantID, serviceName string, startTime, endTime, step int64) (domain.ServiceTimeSeries, error)
 
-	GetServiceMetrics(startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error)
+	// 두 트레이스 비교
+	CompareTraces(ctx context.Context, tenantID, traceIDA, traceIDB string) (*domain.TraceComparison, error)
 }
 
 // TraceServiceImpl은 트레이스 서비스 구현체입니다.
 type TraceServiceImpl struct {
 	repository repository.TraceRepository
+	config     *config.Config
 	log        logger.Logger
 }
 
@@ -35,21 +59,27 @@ type TraceServiceImpl struct {
 func NewTraceService(repo repository.TraceRepository) TraceService {
 	return &TraceServiceImpl{
 		repository: repo,
+		config:     config.GetConfig(),
 		log:        logger.GetLogger(),
 	}
 }
 
 // SaveTraces는 트레이스를 저장합니다.
-func (s *TraceServiceImpl) SaveTraces(traces []domain.TraceItem) error {
-	return s.repository.SaveTraces(traces)
+func (s *TraceServiceImpl) SaveTraces(ctx context.Context, traces []domain.TraceItem) error {
+	return s.repository.SaveTraces(ctx, traces)
 }
 
 // GetTraceByID는 특정 트레이스를 조회합니다.
-func (s *TraceServiceImpl) GetTraceByID(traceID string) (*domain.Trace, error) {
-	return s.repository.GetTraceByID(traceID)
+// 트레이스가 존재하지 않으면 ErrTraceNotFound를 반환합니다.
+func (s *TraceServiceImpl) GetTraceByID(ctx context.Context, tenantID, traceID string) (*domain.Trace, error) {
+	trace, err := s.repository.GetTraceByID(ctx, tenantID, traceID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && trace == nil) {
+		return nil, fmt.Errorf("%w: %s", ErrTraceNotFound, traceID)
+	}
+	return trace, err
 }
 
-func (s *TraceServiceImpl) QueryTraces(filter domain.TraceFilter) (domain.TraceQueryResult, error) {
+func (s *TraceServiceImpl) QueryTraces(ctx context.Context, filter domain.TraceFilter) (domain.TraceQueryResult, error) {
 	now := time.Now().UnixMilli()
 	if filter.EndTime == 0 {
 		filter.EndTime = now
@@ -57,17 +87,65 @@ func (s *TraceServiceImpl) QueryTraces(filter domain.TraceFilter) (domain.TraceQ
 	if filter.StartTime == 0 {
 		filter.StartTime = now - 3600000 // 기본값: 최근 1시간
 	}
-	if filter.Limit == 0 {
+	if filter.Limit <= 0 {
 		filter.Limit = 100 // 기본값: 100개
 	}
+	if filter.Limit > maxTraceLimit {
+		filter.Limit = maxTraceLimit
+	}
+
+	return s.repository.QueryTraces(ctx, filter)
+}
+
+func (s *TraceServiceImpl) GetServices(ctx context.Context, tenantID string, startTime, endTime int64, filter string) (domain.ServiceListResult, error) {
+	return s.repository.GetServices(ctx, tenantID, startTime, endTime, filter)
+}
+
+func (s *TraceServiceImpl) GetServiceMetrics(ctx context.Context, tenantID string, startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error) {
+	return s.repository.GetServiceMetrics(ctx, tenantID, startTime, endTime, serviceName)
+}
+
+// GetServiceGraph는 서비스 의존성 그래프를 조회합니다.
+// 집계 작업이 활성화된 경우 집계 테이블을, 그렇지 않으면 traces 테이블을 직접 조회합니다.
+func (s *TraceServiceImpl) GetServiceGraph(ctx context.Context, tenantID string, startTime, endTime int64) (domain.ServiceGraph, error) {
+	if s.config.Rollup.Enabled {
+		return s.repository.GetServiceGraph(ctx, tenantID, startTime, endTime)
+	}
+	return s.repository.ComputeServiceGraph(ctx, tenantID, startTime, endTime)
+}
 
-	return s.repository.QueryTraces(filter)
+// GetOperationStats는 서비스의 오퍼레이션(스팬 이름, 스팬 종류)별 통계를 조회합니다.
+// 집계 작업이 활성화된 경우 집계 테이블을, 그렇지 않으면 traces 테이블을 직접 조회합니다.
+func (s *TraceServiceImpl) GetOperationStats(ctx context.Context, filter domain.OperationFilter) (domain.OperationListResult, error) {
+	if s.config.Rollup.Enabled {
+		return s.repository.GetOperationStats(ctx, filter)
+	}
+	return s.repository.ComputeOperationStats(ctx, filter)
 }
 
-func (s *TraceServiceImpl) GetServices(startTime, endTime int64, filter string) (domain.ServiceListResult, error) {
-	return s.repository.GetServices(startTime, endTime, filter)
+// GetServiceTimeSeries는 서비스의 요청률, 오류율, 지연 시간 백분위를 시간 버킷별로 조회합니다.
+func (s *TraceServiceImpl) GetServiceTimeSeries(ctx context.Context, tenantID, serviceName string, startTime, endTime, step int64) (domain.ServiceTimeSeries, error) {
+	return s.repository.GetServiceTimeSeries(ctx, tenantID, serviceName, startTime, endTime, step)
 }
 
-func (s *TraceServiceImpl) GetServiceMetrics(startTime, endTime int64, serviceName string) ([]dto.ServiceMetric, error) {
-	return s.repository.GetServiceMetrics(startTime, endTime, serviceName)
+// CompareTraces는 두 트레이스를 조회하여 스팬 트리를 비교합니다.
+// 어느 한쪽이라도 존재하지 않으면 ErrTraceNotFound를 반환합니다.
+func (s *TraceServiceImpl) CompareTraces(ctx context.Context, tenantID, traceIDA, traceIDB string) (*domain.TraceComparison, error) {
+	traceA, err := s.repository.GetTraceByID(ctx, tenantID, traceIDA)
+	if err != nil {
+		return nil, err
+	}
+	if traceA == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTraceNotFound, traceIDA)
+	}
+
+	traceB, err := s.repository.GetTraceByID(ctx, tenantID, traceIDB)
+	if err != nil {
+		return nil, err
+	}
+	if traceB == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTraceNotFound, traceIDB)
+	}
+
+	return domain.CompareTraces(traceA, traceB), nil
 }
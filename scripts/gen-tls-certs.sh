@@ -0,0 +1,45 @@
+#!/bin/bash
+set -e
+
+# 로컬 TLS 테스트용 자체 서명 인증서 생성 (docker-compose.tls.yaml에서 사용)
+# 운영 환경에서는 사용하지 마세요.
+
+CERT_DIR=${1:-./certs}
+DAYS=${CERT_DAYS:-365}
+# 서버 인증서에 넣을 호스트 이름 (컨테이너 이름과 로컬 접속용 이름)
+SERVER_SAN=${SERVER_SAN:-DNS:localhost,DNS:pg,DNS:redis,DNS:kafka,IP:127.0.0.1}
+
+echo "==== TLS 테스트 인증서 생성: $CERT_DIR ===="
+mkdir -p "$CERT_DIR"
+cd "$CERT_DIR"
+
+# CA
+openssl req -x509 -new -nodes -newkey rsa:2048 -sha256 -days "$DAYS" \
+    -keyout ca.key -out ca.crt -subj "/CN=otel-kafka-pg-test-ca"
+
+# 서버 인증서 (PostgreSQL, Redis, Kafka 공용)
+openssl req -new -nodes -newkey rsa:2048 -keyout server.key -out server.csr -subj "/CN=localhost"
+openssl x509 -req -in server.csr -CA ca.crt -CAkey ca.key -CAcreateserial -sha256 -days "$DAYS" \
+    -extfile <(printf "subjectAltName=%s\nextendedKeyUsage=serverAuth" "$SERVER_SAN") -out server.crt
+
+# 클라이언트 인증서 (상호 TLS), PostgreSQL 클라이언트 인증서의 CN은 접속 사용자 이름과 같아야 함
+CLIENT_CN=${CLIENT_CN:-${POSTGRES_USER:-postgres}}
+openssl req -new -nodes -newkey rsa:2048 -keyout client.key -out client.csr -subj "/CN=$CLIENT_CN"
+openssl x509 -req -in client.csr -CA ca.crt -CAkey ca.key -CAcreateserial -sha256 -days "$DAYS" \
+    -extfile <(printf "extendedKeyUsage=clientAuth") -out client.crt
+
+# Kafka PEM 키스토어 (개인 키 + 인증서)
+cat server.key server.crt > kafka-keystore.pem
+
+rm -f server.csr client.csr ca.srl
+
+# Kafka SASL/PLAIN 테스트 사용자(telemetry) 비밀번호 (KAFKA_SASL_PASSWORD_FILE 예시)
+printf 'telemetry-secret\n' > kafka-sasl-password
+
+# 컨테이너의 비 root 사용자가 읽을 수 있도록 권한 설정 (테스트 전용)
+# lib/pq는 클라이언트 개인 키 권한이 0600이어야 하므로 client.key는 소유자만 읽을 수 있게 둠
+chmod 644 ca.crt server.crt server.key client.crt kafka-keystore.pem kafka-sasl-password
+chmod 600 ca.key client.key
+
+echo "생성 완료:"
+ls -l
@@ -0,0 +1,60 @@
+#!/bin/bash
+set -e
+
+# TLS 구성(docker-compose.tls.yaml)의 PostgreSQL, Kafka에 접속하는 통합 테스트
+# - PostgreSQL: verify-full + SCRAM 비밀번호 + 클라이언트 인증서(상호 TLS)
+# - Kafka: SASL_SSL + SCRAM-SHA-512 + 클라이언트 인증서(상호 TLS)
+
+ROOT=$(cd "$(dirname "$0")/.." && pwd)
+CERT_DIR="$ROOT/certs"
+COMPOSE="docker compose -f $ROOT/docker-compose.yaml -f $ROOT/docker-compose.tls.yaml"
+SCRAM_PASSWORD=${KAFKA_SCRAM_PASSWORD:-telemetry-scram-secret}
+
+test -f "$CERT_DIR/ca.crt" || "$ROOT/scripts/gen-tls-certs.sh" "$CERT_DIR"
+
+echo "==== PostgreSQL, Kafka 실행 ===="
+$COMPOSE up -d --wait pg
+$COMPOSE up -d kafka
+
+# KRaft 모드에서는 브로커가 뜬 뒤 SCRAM 사용자를 만들 수 있으므로 준비될 때까지 재시도
+echo "==== Kafka SCRAM 사용자(telemetry) 생성 ===="
+for i in $(seq 1 30); do
+    if docker exec telemetry-kafka /opt/kafka/bin/kafka-configs.sh --bootstrap-server localhost:9092 \
+        --alter --entity-type users --entity-name telemetry \
+        --add-config "SCRAM-SHA-512=[password=$SCRAM_PASSWORD]" >/dev/null 2>&1; then
+        break
+    fi
+    if [ "$i" = 30 ]; then
+        echo "Kafka SCRAM 사용자 생성 실패" >&2
+        exit 1
+    fi
+    sleep 2
+done
+
+echo "==== 통합 테스트 실행 ===="
+(
+    cd "$ROOT/modules/common"
+    POSTGRES_HOST=localhost \
+    POSTGRES_PORT=5432 \
+    POSTGRES_USER=${POSTGRES_USER:-postgres} \
+    POSTGRES_PASSWORD=${POSTGRES_PASSWORD:-postgres} \
+    POSTGRES_DB=${POSTGRES_DB:-telemetry} \
+    POSTGRES_SSLMODE=verify-full \
+    POSTGRES_SSLROOTCERT="$CERT_DIR/ca.crt" \
+    POSTGRES_SSLCERT="$CERT_DIR/client.crt" \
+    POSTGRES_SSLKEY="$CERT_DIR/client.key" \
+    go test -tags integration -count=1 -v ./db/ -run 'TestTLS'
+)
+(
+    cd "$ROOT/modules/kafka/consumer"
+    KAFKA_BROKERS=localhost:9095 \
+    KAFKA_SASL_MECHANISM=SCRAM-SHA-512 \
+    KAFKA_SASL_USERNAME=telemetry \
+    KAFKA_SASL_PASSWORD="$SCRAM_PASSWORD" \
+    KAFKA_SSL_CA_LOCATION="$CERT_DIR/ca.crt" \
+    KAFKA_SSL_CERT_LOCATION="$CERT_DIR/client.crt" \
+    KAFKA_SSL_KEY_LOCATION="$CERT_DIR/client.key" \
+    go test -tags integration -count=1 -v . -run 'TestSASLSSL'
+)
+
+echo "TLS 통합 테스트 완료"